@@ -1,10 +1,14 @@
 package text
 
 import (
+	"math"
 	"sort"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/SCKelemen/unicode/v6/uax14"
+	"github.com/SCKelemen/unicode/v6/uax29"
 )
 
 // Advanced CSS Text Module Features
@@ -242,12 +246,14 @@ func (t *Text) justifyInterWord(text string, extraSpace float64) string {
 	gaps := len(words) - 1
 	spacePerGap := extraSpace / float64(gaps)
 
+	// Each gap is the original space plus its share of the extra
+	pads := t.gapPaddings(gaps, t.Width(" ")+spacePerGap)
+
 	var result strings.Builder
 	for i, word := range words {
 		result.WriteString(word)
 		if i < len(words)-1 {
-			// Add original space plus extra
-			result.WriteString(t.makePadding(1.0 + spacePerGap))
+			result.WriteString(pads[i])
 		}
 	}
 
@@ -263,18 +269,77 @@ func (t *Text) justifyInterCharacter(text string, extraSpace float64) string {
 
 	gaps := len(graphemes) - 1
 	spacePerGap := extraSpace / float64(gaps)
+	pads := t.gapPaddings(gaps, spacePerGap)
 
 	var result strings.Builder
 	for i, g := range graphemes {
 		result.WriteString(g)
 		if i < len(graphemes)-1 {
-			result.WriteString(t.makePadding(spacePerGap))
+			result.WriteString(pads[i])
 		}
 	}
 
 	return result.String()
 }
 
+// JustifyLines justifies wrapped lines to targetWidth, leaving the last line
+// of each paragraph unjustified as CSS does for text-align-last.
+//
+// A line is the last of its paragraph when it ends in a hard break or is the
+// final line. Those lines are aligned with lastLineAlign instead (typically
+// AlignLeft; AlignJustify justifies them too). Trailing spaces are dropped
+// before justifying, and lines already at or beyond targetWidth are left
+// unchanged, as are lines with nothing to stretch. Justified lines get
+// Width set to targetWidth.
+//
+// Only Content and Width change: Start, End and BreakType are copied from
+// the input, so Start/End still index the text that was wrapped, not the
+// padded Content.
+//
+// Example:
+//
+//	txt := text.NewTerminal()
+//	lines := txt.Wrap("The quick brown fox jumps over the lazy dog", text.WrapOptions{MaxWidth: 20})
+//	justified := txt.JustifyLines(lines, 20, text.TextJustifyInterWord, text.AlignLeft)
+func (t *Text) JustifyLines(lines []Line, targetWidth float64, method TextJustify, lastLineAlign Alignment) []Line {
+	result := make([]Line, len(lines))
+	copy(result, lines)
+
+	for i := range result {
+		content := strings.TrimRight(result[i].Content, " ")
+		width := t.Width(content)
+		if width >= targetWidth {
+			continue
+		}
+
+		isLastLine := i == len(result)-1 || result[i].BreakType == BreakHard
+		if isLastLine && lastLineAlign != AlignJustify {
+			result[i].Content = t.Align(content, targetWidth, lastLineAlign)
+			result[i].Width = t.Width(result[i].Content)
+			continue
+		}
+
+		var justified string
+		switch method {
+		case TextJustifyNone:
+			continue
+		case TextJustifyInterCharacter, TextJustifyDistribute:
+			justified = t.justifyInterCharacter(content, targetWidth-width)
+		default:
+			justified = t.justifyInterWord(content, targetWidth-width)
+		}
+
+		// A single word has no gaps to stretch
+		if justified == content {
+			continue
+		}
+		result[i].Content = justified
+		result[i].Width = targetWidth
+	}
+
+	return result
+}
+
 // ═══════════════════════════════════════════════════════════════
 //  Hanging Punctuation (CSS Text §6)
 // ═══════════════════════════════════════════════════════════════
@@ -377,6 +442,55 @@ func (t *Text) ShouldHang(text string, position int, mode HangingPunctuation) (s
 	return false, 0
 }
 
+// ShouldHangAtLine reports how much punctuation hangs outside the line
+// box of the wrapped line runes[lineStart:lineEnd].
+//
+// Unlike ShouldHang, which only looks at the start and end of the whole
+// text, the conditions are evaluated at the line's own boundaries:
+//
+//   - startHang is the width of an opening bracket or quote at lineStart
+//     when mode includes HangingPunctuationFirst.
+//   - endHang is the width of a closing bracket or quote (with
+//     HangingPunctuationLast), or of a stop or comma (with
+//     HangingPunctuationForceEnd or HangingPunctuationAllowEnd), that ends
+//     the line. Trailing white space is skipped, since it hangs anyway.
+//
+// Both are 0 when nothing hangs at that boundary.
+//
+// Example:
+//
+//	txt := text.NewTerminal()
+//	runes := []rune("He said “go” now")
+//	_, end := txt.ShouldHangAtLine(runes, 0, 13, text.HangingPunctuationLast)
+//	// end == 1: the line "He said “go” " ends with a hanging ”
+func (t *Text) ShouldHangAtLine(runes []rune, lineStart, lineEnd int, mode HangingPunctuation) (startHang, endHang float64) {
+	lineStart = max(lineStart, 0)
+	lineEnd = min(lineEnd, len(runes))
+	if mode == HangingPunctuationNone || lineStart >= lineEnd {
+		return 0, 0
+	}
+
+	if first := runes[lineStart]; mode&HangingPunctuationFirst != 0 && IsOpeningPunctuation(first) {
+		startHang = t.config.MeasureFunc(first)
+	}
+
+	last := lineEnd - 1
+	for last > lineStart && unicode.IsSpace(runes[last]) {
+		last--
+	}
+	if last == lineStart && startHang > 0 {
+		return startHang, 0 // a lone character cannot hang at both ends
+	}
+
+	r := runes[last]
+	switch {
+	case mode&HangingPunctuationLast != 0 && IsClosingPunctuation(r),
+		mode&(HangingPunctuationForceEnd|HangingPunctuationAllowEnd) != 0 && IsStopPunctuation(r):
+		endHang = t.config.MeasureFunc(r)
+	}
+	return startHang, endHang
+}
+
 // ═══════════════════════════════════════════════════════════════
 //  Tab Size (CSS Text §7)
 // ═══════════════════════════════════════════════════════════════
@@ -392,6 +506,17 @@ type TabSize struct {
 
 	// Unit determines if Value is spaces or a length
 	Unit TabSizeUnit
+
+	// LeaderRune, if non-zero, is drawn at the start of each expanded tab
+	// (for example '→'), with the rest of the gap filled with spaces.
+	// The expansion has the same width as a plain one; if the leader does
+	// not fit in the gap, only spaces are used.
+	LeaderRune rune
+
+	// RepeatLeader fills the whole gap with LeaderRune instead of spaces
+	// (for example dot leaders in a table of contents). Any remainder
+	// narrower than the leader is padded with spaces.
+	RepeatLeader bool
 }
 
 // TabSizeUnit specifies how tab size is measured.
@@ -414,43 +539,87 @@ func DefaultTabSize() TabSize {
 }
 
 // ExpandTabs expands tab characters according to tab-size.
+//
+// Each tab advances the column to the next tab stop, where stops are
+// multiples of the tab size in the same abstract units MeasureFunc returns:
+// Value spaces for TabSizeSpaces, or Value units for TabSizeLength. The
+// column is tracked per grapheme cluster, so wide CJK characters and emoji
+// move it by their full width. The gap is always filled with a whole number
+// of spaces; a stop closer than half a space is skipped in favor of the next
+// one, as CSS specifies.
+//
+// Example:
+//
+//	txt := text.NewTerminal()
+//	txt.ExpandTabs("世界\tx", text.TabSize{Value: 8}) // "世界" + 4 spaces + "x"
 func (t *Text) ExpandTabs(text string, tabSize TabSize) string {
 	if !strings.Contains(text, "\t") {
 		return text
 	}
+	expanded, _ := t.expandTabsFrom(text, tabSize, 0)
+	return expanded
+}
+
+// expandTabsFrom expands tabs in text as if it started at column, and
+// returns the expansion with the column where it ends.
+func (t *Text) expandTabsFrom(text string, tabSize TabSize, column float64) (string, float64) {
+	spaceWidth := t.config.MeasureFunc(' ')
+	tabStop := tabSize.Value
+	if tabSize.Unit == TabSizeSpaces {
+		tabStop *= spaceWidth
+	}
 
 	var result strings.Builder
-	column := 0.0
-
-	for _, r := range text {
-		if r == '\t' {
-			// Calculate tab stop position
-			var tabWidth float64
-			if tabSize.Unit == TabSizeSpaces {
-				spaceWidth := t.config.MeasureFunc(' ')
-				tabStop := tabSize.Value * spaceWidth
-				// Advance to next tab stop
-				tabWidth = tabStop - (column - (float64(int(column/tabStop)) * tabStop))
-			} else {
-				// Use length directly
-				tabWidth = tabSize.Value
+	result.Grow(len(text))
+
+	for _, g := range uax29.Graphemes(text) {
+		switch {
+		case g == "\t":
+			if tabStop <= 0 || spaceWidth <= 0 {
+				continue // tab-size: 0 renders tabs with no width
 			}
 
-			// Insert spaces to reach tab stop
-			numSpaces := int(tabWidth / t.config.MeasureFunc(' '))
-			result.WriteString(strings.Repeat(" ", numSpaces))
-			column += tabWidth
-		} else {
-			result.WriteRune(r)
-			if r == '\n' {
-				column = 0
-			} else {
-				column += t.config.MeasureFunc(r)
+			next := (math.Floor(column/tabStop) + 1) * tabStop
+			if next-column < spaceWidth/2 {
+				next += tabStop
 			}
+
+			numSpaces := int(math.Round((next - column) / spaceWidth))
+			t.writeTabFill(&result, numSpaces, spaceWidth, tabSize)
+			column += float64(numSpaces) * spaceWidth
+
+		case strings.ContainsRune(g, '\n'):
+			result.WriteString(g)
+			column = 0
+
+		default:
+			result.WriteString(g)
+			column += t.graphemeWidth(g)
 		}
 	}
 
-	return result.String()
+	return result.String(), column
+}
+
+// writeTabFill writes a tab expansion as wide as numSpaces spaces,
+// starting with tabSize.LeaderRune when one is set.
+func (t *Text) writeTabFill(result *strings.Builder, numSpaces int, spaceWidth float64, tabSize TabSize) {
+	gap := float64(numSpaces) * spaceWidth
+	leaderWidth := t.config.MeasureFunc(tabSize.LeaderRune)
+
+	if tabSize.LeaderRune == 0 || leaderWidth <= 0 || leaderWidth > gap {
+		result.WriteString(strings.Repeat(" ", numSpaces))
+		return
+	}
+
+	leaders := 1
+	if tabSize.RepeatLeader {
+		leaders = int(gap / leaderWidth)
+	}
+	result.WriteString(strings.Repeat(string(tabSize.LeaderRune), leaders))
+
+	remaining := int(math.Round((gap - float64(leaders)*leaderWidth) / spaceWidth))
+	result.WriteString(strings.Repeat(" ", remaining))
 }
 
 // ═══════════════════════════════════════════════════════════════
@@ -486,107 +655,209 @@ const (
 )
 
 // WrapBalanced wraps text with balanced line lengths.
+//
+// It uses as many lines as greedy wrapping at maxWidth would, but picks the
+// narrowest width that still needs no more lines, so the lines come out
+// close to equal length. Words wider than maxWidth are broken at grapheme
+// boundaries; no line is wider than maxWidth apart from trailing white
+// space.
 func (t *Text) WrapBalanced(text string, maxWidth float64) []Line {
-	words := t.Words(text)
-	if len(words) == 0 {
+	if strings.TrimSpace(text) == "" {
 		return nil
 	}
 
-	// Calculate total width
-	totalWidth := t.Width(text)
-
-	// Estimate optimal line count
-	optimalLines := int((totalWidth / maxWidth) + 0.5)
-	if optimalLines < 1 {
-		optimalLines = 1
+	lines := t.Wrap(text, WrapOptions{MaxWidth: maxWidth, BreakWords: true})
+	if len(lines) < 2 {
+		return lines
 	}
 
-	// Try to distribute evenly
-	targetWidth := totalWidth / float64(optimalLines)
+	// Never go narrower than the widest unbreakable segment, or balancing
+	// would start splitting words that fit at maxWidth.
+	lo := 0.0
+	breakPoints := uax14.FindLineBreakOpportunities(text, t.config.HyphenationMode)
+	for i := 1; i < len(breakPoints); i++ {
+		lo = max(lo, t.Width(text[breakPoints[i-1]:breakPoints[i]]))
+	}
+	if lo >= maxWidth {
+		return lines
+	}
+	if narrow := t.Wrap(text, WrapOptions{MaxWidth: lo, BreakWords: true}); len(narrow) <= len(lines) {
+		return narrow
+	}
 
-	// Build lines
-	var lines []Line
-	currentLine := ""
-	currentWidth := 0.0
-	lineStart := 0
+	// Line count only grows as the width shrinks, so bisect for the
+	// narrowest width that keeps it.
+	hi := maxWidth
+	for range 24 {
+		mid := (lo + hi) / 2
+		if candidate := t.Wrap(text, WrapOptions{MaxWidth: mid, BreakWords: true}); len(candidate) <= len(lines) {
+			hi = mid
+			lines = candidate
+		} else {
+			lo = mid
+		}
+	}
+	return lines
+}
 
-	for _, word := range words {
-		wordWidth := t.Width(word)
-		spaceWidth := t.Width(" ")
+// WrapPretty wraps text optimizing for readability.
+//
+// It starts from greedy wrapping and then avoids a very short last line
+// (under 40% of maxWidth): words move from the end of the second-to-last
+// line down to the last line, one at a time, as long as the last line stays
+// short and both lines still fit within maxWidth. Words never move across a
+// hard break.
+func (t *Text) WrapPretty(text string, maxWidth float64) []Line {
+	lines := t.Wrap(text, WrapOptions{MaxWidth: maxWidth})
 
-		// Check if adding this word exceeds target or max width
-		nextWidth := currentWidth
-		if currentWidth > 0 {
-			nextWidth += spaceWidth
+	for len(lines) >= 2 {
+		prev, last := &lines[len(lines)-2], &lines[len(lines)-1]
+		if last.Width >= maxWidth*0.4 || prev.BreakType != BreakSoft {
+			break
 		}
-		nextWidth += wordWidth
 
-		if currentWidth > 0 && (nextWidth > maxWidth || nextWidth > targetWidth) {
-			// Start new line
-			lines = append(lines, Line{
-				Content: strings.TrimSpace(currentLine),
-				Width:   currentWidth,
-				Start:   lineStart,
-				End:     lineStart + len([]rune(currentLine)),
-			})
-			currentLine = word
-			currentWidth = wordWidth
-			lineStart += len([]rune(currentLine))
-		} else {
-			if currentWidth > 0 {
-				currentLine += " "
-				currentWidth += spaceWidth
+		// The last break opportunity inside prev starts the word to move.
+		breakPoints := uax14.FindLineBreakOpportunities(prev.Content, t.config.HyphenationMode)
+		cut := 0
+		for _, bp := range breakPoints {
+			if bp > 0 && bp < len(prev.Content) {
+				cut = bp
 			}
-			currentLine += word
-			currentWidth += wordWidth
 		}
-	}
+		if cut == 0 {
+			break
+		}
 
-	// Add last line
-	if currentLine != "" {
-		lines = append(lines, Line{
-			Content: strings.TrimSpace(currentLine),
-			Width:   currentWidth,
-			Start:   lineStart,
-			End:     lineStart + len([]rune(currentLine)),
-		})
+		kept := prev.Content[:cut]
+		moved := prev.Content[cut:] + last.Content
+		keptWidth, movedWidth := t.Width(kept), t.Width(moved)
+		if movedWidth > maxWidth || keptWidth > maxWidth {
+			break
+		}
+
+		prev.Content, prev.Width = kept, keptWidth
+		prev.End = prev.Start + utf8.RuneCountInString(kept)
+		last.Content, last.Width = moved, movedWidth
+		last.Start = prev.End
 	}
 
 	return lines
 }
 
-// WrapPretty wraps text optimizing for readability.
-func (t *Text) WrapPretty(text string, maxWidth float64) []Line {
-	// Pretty wrapping avoids:
-	// - Orphans (single word on last line)
-	// - Very short last lines
-	// - Hyphenated words at end of paragraphs
+// WrapStable wraps text so that editing reflows as few earlier lines as
+// possible (CSS text-wrap: stable).
+//
+// Each line is filled greedily and only wraps when the next word does not
+// fit, so a line's breaks depend on the text before it and on the first
+// word of the line after it. An edit can therefore change the line it falls
+// on, the line before that one (a shortened word may now fit there, for
+// example), and the lines after it; lines further back stay where they are.
+func (t *Text) WrapStable(text string, maxWidth float64) []Line {
+	return t.Wrap(text, WrapOptions{MaxWidth: maxWidth})
+}
 
-	lines := t.Wrap(text, WrapOptions{MaxWidth: maxWidth})
+// WrapMode wraps text according to a CSS text-wrap value, providing a single
+// entry point for all TextWrap modes.
+//
+// Example:
+//
+//	txt := text.NewTerminal()
+//	lines := txt.WrapMode("A heading that wraps", 12, text.TextWrapBalance)
+func (t *Text) WrapMode(text string, maxWidth float64, mode TextWrap) []Line {
+	switch mode {
+	case TextWrapNowrap:
+		return t.Wrap(text, WrapOptions{})
+	case TextWrapBalance:
+		return t.WrapBalanced(text, maxWidth)
+	case TextWrapPretty:
+		return t.WrapPretty(text, maxWidth)
+	case TextWrapStable:
+		return t.WrapStable(text, maxWidth)
+	default:
+		return t.Wrap(text, WrapOptions{MaxWidth: maxWidth})
+	}
+}
+
+// ═══════════════════════════════════════════════════════════════
+//  Widows and Orphans (CSS Fragmentation §4)
+// ═══════════════════════════════════════════════════════════════
+
+// FlowColumns distributes wrapped lines into columns of colHeight lines,
+// honoring the CSS widows and orphans properties.
+//
+// A paragraph ends at a line with BreakHard (see WrapOptions.PreserveNewlines).
+// When a paragraph has to be broken across columns, at least minOrphans of
+// its lines must stay at the bottom of the first column and at least
+// minWidows must move to the top of the next. If that is impossible the
+// paragraph is pushed forward to the next column. A paragraph that does not
+// fit even at the top of an empty column is broken regardless. Negative
+// minWidows and minOrphans are treated as 0, and colHeight is at least 1.
+//
+// Specification:
+//   - CSS Fragmentation Level 3: https://www.w3.org/TR/css-break-3/#widows-orphans
+//
+// Example:
+//
+//	txt := text.NewTerminal()
+//	lines := txt.Wrap(article, text.WrapOptions{MaxWidth: 30, PreserveNewlines: true})
+//	columns := txt.FlowColumns(lines, 20, 2, 2)
+func (t *Text) FlowColumns(lines []Line, colHeight, minWidows, minOrphans int) [][]Line {
+	if len(lines) == 0 {
+		return nil
+	}
+	colHeight = max(colHeight, 1)
+	minWidows = max(minWidows, 0)
+	minOrphans = max(minOrphans, 0)
+
+	// Split into paragraphs at hard breaks
+	var paragraphs [][]Line
+	start := 0
+	for i, line := range lines {
+		if line.BreakType == BreakHard {
+			paragraphs = append(paragraphs, lines[start:i+1])
+			start = i + 1
+		}
+	}
+	if start < len(lines) {
+		paragraphs = append(paragraphs, lines[start:])
+	}
+
+	var columns [][]Line
+	var column []Line
 
-	// If last line is too short (< 40% of max), try to pull word from previous
-	if len(lines) >= 2 {
-		lastLine := lines[len(lines)-1]
-		if lastLine.Width < maxWidth*0.4 {
-			// Pull last word from second-to-last line
-			secondLast := lines[len(lines)-2]
-			words := strings.Fields(secondLast.Content)
-
-			if len(words) > 1 {
-				// Move last word to last line
-				pullWord := words[len(words)-1]
-				newSecondLast := strings.Join(words[:len(words)-1], " ")
-				newLastLine := pullWord + " " + lastLine.Content
-
-				lines[len(lines)-2].Content = newSecondLast
-				lines[len(lines)-2].Width = t.Width(newSecondLast)
-				lines[len(lines)-1].Content = newLastLine
-				lines[len(lines)-1].Width = t.Width(newLastLine)
+	for _, para := range paragraphs {
+		for len(para) > 0 {
+			space := colHeight - len(column)
+			if len(para) <= space {
+				column = append(column, para...)
+				break
 			}
+
+			// Lines to keep in this column
+			keep := space
+			if len(para)-keep < minWidows {
+				keep = len(para) - minWidows
+			}
+			if keep < minOrphans {
+				keep = 0
+			}
+			if keep <= 0 && len(column) == 0 {
+				// Nothing earlier to push against; break anyway
+				keep = space
+			}
+
+			column = append(column, para[:keep]...)
+			para = para[keep:]
+			columns = append(columns, column)
+			column = nil
 		}
 	}
 
-	return lines
+	if len(column) > 0 {
+		columns = append(columns, column)
+	}
+
+	return columns
 }
 
 // ═══════════════════════════════════════════════════════════════
@@ -610,8 +881,14 @@ const (
 	// TextSpacingTrimSpaceFirst trims space at line start.
 	TextSpacingTrimSpaceFirst
 
-	// TextSpacingTrimAuto uses language-specific rules.
+	// TextSpacingTrimAuto applies the CSS Text Level 4 defaults: spaces
+	// after fullwidth opening punctuation and before fullwidth closing or
+	// stop punctuation are removed, as are spaces at the start and end of
+	// each line next to CJK text.
 	TextSpacingTrimAuto
+
+	// TextSpacingTrimSpaceLast trims space at line end after CJK text.
+	TextSpacingTrimSpaceLast
 )
 
 // IsCJKIdeograph returns true if the rune is a CJK ideograph.
@@ -627,40 +904,90 @@ func IsCJKIdeograph(r rune) bool {
 }
 
 // TrimCJKSpacing trims spacing around CJK characters according to the trim mode.
+//
+// Runs of spaces are kept or removed as a whole. Lines are delimited by
+// '\n', and a space run is at a line start or end when it touches one.
+//
+// Example:
+//
+//	txt.TrimCJKSpacing("「 世界 」", text.TextSpacingTrimAuto) // "「世界」"
 func (t *Text) TrimCJKSpacing(text string, mode TextSpacingTrim) string {
 	if mode == TextSpacingTrimNone {
 		return text
 	}
 
 	runes := []rune(text)
-	var result []rune
+	result := make([]rune, 0, len(runes))
 
-	for i, r := range runes {
-		// Skip spaces between CJK characters
-		if r == ' ' && i > 0 && i < len(runes)-1 {
-			prevCJK := IsCJKIdeograph(runes[i-1])
-			nextCJK := IsCJKIdeograph(runes[i+1])
+	for i := 0; i < len(runes); {
+		if runes[i] != ' ' {
+			result = append(result, runes[i])
+			i++
+			continue
+		}
 
-			if mode == TextSpacingTrimSpaceAll && prevCJK && nextCJK {
-				continue // Skip this space
-			}
+		j := i
+		for j < len(runes) && runes[j] == ' ' {
+			j++
 		}
 
-		// Skip leading space before CJK at line start
-		if mode == TextSpacingTrimSpaceFirst && r == ' ' && i < len(runes)-1 {
-			if i == 0 || runes[i-1] == '\n' {
-				if IsCJKIdeograph(runes[i+1]) {
-					continue
-				}
-			}
+		var prev, next rune
+		if i > 0 {
+			prev = runes[i-1]
+		}
+		if j < len(runes) {
+			next = runes[j]
 		}
 
-		result = append(result, r)
+		if !trimSpaceRun(prev, next, mode) {
+			result = append(result, runes[i:j]...)
+		}
+		i = j
 	}
 
 	return string(result)
 }
 
+// trimSpaceRun reports whether a run of spaces between prev and next should
+// be removed under mode. A zero rune or '\n' marks a text or line boundary.
+func trimSpaceRun(prev, next rune, mode TextSpacingTrim) bool {
+	lineStart := prev == 0 || prev == '\n'
+	lineEnd := next == 0 || next == '\n' || next == '\r'
+
+	switch mode {
+	case TextSpacingTrimSpaceAll:
+		// Skip spaces between CJK characters
+		return IsCJKIdeograph(prev) && IsCJKIdeograph(next)
+
+	case TextSpacingTrimSpaceFirst:
+		// Skip leading space before CJK at line start
+		return lineStart && IsCJKIdeograph(next)
+
+	case TextSpacingTrimSpaceLast:
+		return lineEnd && isCJKSpacingTrimTarget(prev)
+
+	case TextSpacingTrimAuto:
+		switch {
+		case IsOpeningFullwidthPunctuation(prev):
+			return true
+		case IsFullwidthPunctuation(next) && !IsOpeningFullwidthPunctuation(next):
+			return true
+		case lineStart:
+			return isCJKSpacingTrimTarget(next)
+		case lineEnd:
+			return isCJKSpacingTrimTarget(prev)
+		}
+	}
+
+	return false
+}
+
+// isCJKSpacingTrimTarget reports whether r is an ideograph or fullwidth
+// punctuation, the characters line-edge spacing trim applies next to.
+func isCJKSpacingTrimTarget(r rune) bool {
+	return IsCJKIdeograph(r) || IsFullwidthPunctuation(r)
+}
+
 // ═══════════════════════════════════════════════════════════════
 //  Wrap Before/After Controls (CSS Text Level 4)
 // ═══════════════════════════════════════════════════════════════
@@ -719,8 +1046,12 @@ func (t *Text) WrapWithControls(text string, maxWidth float64, controls []WrapPo
 		controlMap[wp.Position] = wp
 	}
 
-	// Get UAX #14 break opportunities
+	// Get UAX #14 break opportunities as rune indices, like the controls
 	breakOpportunities := uax14.FindLineBreakOpportunities(text, uax14.HyphensManual)
+	for i, bp := range breakOpportunities {
+		breakOpportunities[i] = ByteIndexToRune(text, bp)
+	}
+	runeCount := utf8.RuneCountInString(text)
 
 	// Filter and modify break opportunities based on controls
 	var allowedBreaks []int
@@ -770,7 +1101,7 @@ func (t *Text) WrapWithControls(text string, maxWidth float64, controls []WrapPo
 					break
 				}
 			}
-			if !found && pos+1 <= len(text) {
+			if !found && pos+1 <= runeCount {
 				allowedBreaks = append(allowedBreaks, pos+1)
 			}
 		}
@@ -796,7 +1127,8 @@ func (t *Text) WrapWithControls(text string, maxWidth float64, controls []WrapPo
 	return t.buildLinesFromBreaks(text, allowedBreaks, maxWidth)
 }
 
-// buildLinesFromBreaks creates lines from break opportunities.
+// buildLinesFromBreaks creates lines from break opportunities, given as
+// rune indices into text.
 func (t *Text) buildLinesFromBreaks(text string, breakPoints []int, maxWidth float64) []Line {
 	if len(breakPoints) == 0 {
 		return []Line{{
@@ -832,16 +1164,17 @@ func (t *Text) buildLinesFromBreaks(text string, breakPoints []int, maxWidth flo
 		if testWidth > maxWidth && currentLine != "" {
 			// Line is full, commit current line
 			lines = append(lines, Line{
-				Content: currentLine,
-				Width:   currentWidth,
-				Start:   lineStartIdx,
-				End:     lineStartIdx + len([]rune(currentLine)),
+				Content:   currentLine,
+				Width:     currentWidth,
+				Start:     lineStartIdx,
+				End:       lineStartIdx + len([]rune(currentLine)),
+				BreakType: BreakSoft,
 			})
 
 			// Start new line
+			lineStartIdx += len([]rune(currentLine))
 			currentLine = segment
 			currentWidth = t.Width(segment)
-			lineStartIdx += len([]rune(currentLine))
 		} else {
 			// Add to current line
 			currentLine = testLine
@@ -900,8 +1233,13 @@ const (
 	// AutospacePunctuation adjusts spacing around fullwidth punctuation.
 	AutospacePunctuation AutospaceFlags = 1 << 2
 
+	// AutospaceSymbols adds spacing between ideographic characters and
+	// currency or measurement symbols (see IsAutospaceSymbol).
+	// For example, "価格$100" becomes "価格 $100".
+	AutospaceSymbols AutospaceFlags = 1 << 3
+
 	// AutospaceAll enables all autospace features.
-	AutospaceAll = AutospaceIdeographAlpha | AutospaceIdeographNumeric | AutospacePunctuation
+	AutospaceAll = AutospaceIdeographAlpha | AutospaceIdeographNumeric | AutospacePunctuation | AutospaceSymbols
 )
 
 // IsIdeographic returns true if the rune is an ideographic character.
@@ -966,6 +1304,24 @@ func IsClosingFullwidthPunctuation(r rune) bool {
 	return closing[r]
 }
 
+// IsAutospaceSymbol returns true if the rune is a symbol that
+// AutospaceSymbols separates from adjacent ideographs:
+//   - Currency symbols (Unicode category Sc), such as $, €, £, ¥
+//   - Measurement symbols: % ‰ ‱ ° ℃ ℉ ′ ″
+//
+// Fullwidth forms such as ＄ and ％ are excluded; they are already set
+// on the ideographic grid and take no extra space.
+func IsAutospaceSymbol(r rune) bool {
+	if r >= 0xFF00 && r <= 0xFFEF {
+		return false // Halfwidth and Fullwidth Forms
+	}
+	switch r {
+	case '%', '‰', '‱', '°', '℃', '℉', '′', '″':
+		return true
+	}
+	return unicode.Is(unicode.Sc, r)
+}
+
 // ApplyAutospace applies automatic spacing according to text-autospace rules.
 //
 // Example:
@@ -978,17 +1334,73 @@ func (t *Text) ApplyAutospace(text string, flags AutospaceFlags) string {
 		return text
 	}
 
-	runes := []rune(text)
-	if len(runes) == 0 {
+	clusters, insert, drop := t.autospaceEdits(text, flags)
+	if len(clusters) == 0 {
 		return text
 	}
 
-	var result []rune
-	result = append(result, runes[0])
+	var result strings.Builder
+	result.Grow(len(text))
+	for i, cluster := range clusters {
+		if insert[i] {
+			result.WriteByte(' ')
+		}
+		if !drop[i] {
+			result.WriteString(cluster)
+		}
+	}
+	return result.String()
+}
 
-	for i := 1; i < len(runes); i++ {
-		prev := runes[i-1]
-		curr := runes[i]
+// AutospacePositions returns the rune indices in text before which
+// text-autospace would insert spacing, without modifying the text.
+//
+// Use it when the gap should be rendered as a sized space (typically 1/8 em)
+// in a layout model rather than as a literal U+0020 character.
+// AutospacePunctuation only removes spaces, so it adds no positions.
+//
+// Example:
+//
+//	txt := text.NewTerminal()
+//	positions := txt.AutospacePositions("Hello世界123", text.AutospaceAll)
+//	// Returns [5 7]: before "世" and before "1"
+func (t *Text) AutospacePositions(text string, flags AutospaceFlags) []int {
+	if flags == AutospaceNone {
+		return nil
+	}
+
+	clusters, insert, _ := t.autospaceEdits(text, flags)
+
+	var positions []int
+	runeIdx := 0
+	for i, cluster := range clusters {
+		if insert[i] {
+			positions = append(positions, runeIdx)
+		}
+		runeIdx += utf8.RuneCountInString(cluster)
+	}
+	return positions
+}
+
+// autospaceEdits splits text into grapheme clusters and reports, per
+// cluster, whether a space is inserted before it and whether it is dropped
+// by punctuation squeezing.
+//
+// Spacing never separates a base from its combining marks or variation
+// selectors; each cluster is classified by its base (first) rune.
+func (t *Text) autospaceEdits(text string, flags AutospaceFlags) (clusters []string, insert, drop []bool) {
+	clusters = t.Graphemes(text)
+	insert = make([]bool, len(clusters))
+	drop = make([]bool, len(clusters))
+
+	base := func(cluster string) rune {
+		r, _ := utf8.DecodeRuneInString(cluster)
+		return r
+	}
+
+	for i := 1; i < len(clusters); i++ {
+		prev := base(clusters[i-1])
+		curr := base(clusters[i])
 
 		// Check if we need to insert space
 		needSpace := false
@@ -1022,32 +1434,32 @@ func (t *Text) ApplyAutospace(text string, flags AutospaceFlags) string {
 			}
 		}
 
+		// Ideograph-Symbol spacing
+		if (flags & AutospaceSymbols) != 0 {
+			if (IsIdeographic(prev) && IsAutospaceSymbol(curr)) ||
+				(IsAutospaceSymbol(prev) && IsIdeographic(curr)) {
+				needSpace = true
+			}
+		}
+
 		// Punctuation spacing
 		if (flags & AutospacePunctuation) != 0 {
 			// Reduce space after opening punctuation
-			if IsOpeningFullwidthPunctuation(prev) && curr == ' ' {
-				// Skip the space (don't add it)
+			if IsOpeningFullwidthPunctuation(prev) && clusters[i] == " " {
+				drop[i] = true
 				continue
 			}
 
 			// Reduce space before closing punctuation
-			if prev == ' ' && IsClosingFullwidthPunctuation(curr) {
-				// Remove the previous space we added
-				if len(result) > 0 && result[len(result)-1] == ' ' {
-					result = result[:len(result)-1]
-				}
+			if clusters[i-1] == " " && IsClosingFullwidthPunctuation(curr) {
+				drop[i-1] = true
 			}
 		}
 
-		// Insert spacing if needed
-		if needSpace && prev != ' ' {
-			result = append(result, ' ')
-		}
-
-		result = append(result, curr)
+		insert[i] = needSpace && prev != ' '
 	}
 
-	return string(result)
+	return clusters, insert, drop
 }
 
 // ApplyAutospaceMode applies text-autospace with predefined mode.
@@ -1211,7 +1623,7 @@ func (t *Text) WrapWithPhrasesAndControls(text string, maxWidth float64, breaker
 					break
 				}
 			}
-			if !found && checkPos > 0 && checkPos <= len(text) {
+			if !found && checkPos > 0 && checkPos <= utf8.RuneCountInString(text) {
 				allowedBreaks = append(allowedBreaks, checkPos)
 			}
 		}
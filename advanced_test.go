@@ -1,6 +1,8 @@
 package text
 
 import (
+	"fmt"
+	"slices"
 	"strings"
 	"testing"
 )
@@ -127,6 +129,114 @@ func TestJustifyText_SingleWord(t *testing.T) {
 	}
 }
 
+func TestJustifyLines(t *testing.T) {
+	txt := NewTerminal()
+
+	lines := []Line{
+		{Content: "aa bb ", Width: 6, BreakType: BreakSoft},
+		{Content: "cc dd ee", Width: 8, BreakType: BreakSoft},
+		{Content: "ff gg", Width: 5, BreakType: BreakHard},
+		{Content: "hhhhhh ", Width: 7, BreakType: BreakSoft},
+		{Content: "ii jj", Width: 5, BreakType: BreakNone},
+	}
+
+	tests := []struct {
+		name      string
+		method    TextJustify
+		lastAlign Alignment
+		want      []string
+		wantWidth []float64
+	}{
+		{
+			name:      "Last lines left aligned",
+			method:    TextJustifyInterWord,
+			lastAlign: AlignLeft,
+			want:      []string{"aa      bb", "cc  dd  ee", "ff gg     ", "hhhhhh ", "ii jj     "},
+			wantWidth: []float64{10, 10, 10, 7, 10},
+		},
+		{
+			name:      "Last lines right aligned",
+			method:    TextJustifyInterWord,
+			lastAlign: AlignRight,
+			want:      []string{"aa      bb", "cc  dd  ee", "     ff gg", "hhhhhh ", "     ii jj"},
+			wantWidth: []float64{10, 10, 10, 7, 10},
+		},
+		{
+			name:      "Last lines justified",
+			method:    TextJustifyInterWord,
+			lastAlign: AlignJustify,
+			want:      []string{"aa      bb", "cc  dd  ee", "ff      gg", "hhhhhh ", "ii      jj"},
+			wantWidth: []float64{10, 10, 10, 7, 10},
+		},
+		{
+			name:      "Justification disabled",
+			method:    TextJustifyNone,
+			lastAlign: AlignLeft,
+			want:      []string{"aa bb ", "cc dd ee", "ff gg     ", "hhhhhh ", "ii jj     "},
+			wantWidth: []float64{6, 8, 10, 7, 10},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := txt.JustifyLines(lines, 10, tt.method, tt.lastAlign)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d lines, want %d", len(got), len(tt.want))
+			}
+			for i, line := range got {
+				if line.Content != tt.want[i] || line.Width != tt.wantWidth[i] {
+					t.Errorf("line %d = (%q, %v), want (%q, %v)",
+						i, line.Content, line.Width, tt.want[i], tt.wantWidth[i])
+				}
+			}
+		})
+	}
+
+	if lines[0].Content != "aa bb " {
+		t.Errorf("JustifyLines modified its input: %q", lines[0].Content)
+	}
+}
+
+func TestJustifyLines_KeepsSourceOffsets(t *testing.T) {
+	txt := NewTerminal()
+	text := "The quick brown fox jumps over the lazy dog"
+
+	lines := txt.Wrap(text, WrapOptions{MaxWidth: 20})
+	justified := txt.JustifyLines(lines, 20, TextJustifyInterWord, AlignLeft)
+
+	runes := []rune(text)
+	for i, line := range justified {
+		if line.Start != lines[i].Start || line.End != lines[i].End {
+			t.Errorf("line %d [%d:%d], want [%d:%d]", i, line.Start, line.End, lines[i].Start, lines[i].End)
+		}
+		// The padded Content differs from the source only in spaces.
+		source := strings.Fields(string(runes[line.Start:line.End]))
+		if got := strings.Fields(line.Content); !slices.Equal(got, source) {
+			t.Errorf("line %d words %q, want %q", i, got, source)
+		}
+	}
+	if justified[0].Content == lines[0].Content {
+		t.Errorf("line 0 %q was not justified", justified[0].Content)
+	}
+}
+
+func TestJustifyLines_InterCharacter(t *testing.T) {
+	txt := NewTerminal()
+
+	lines := []Line{
+		{Content: "abc", Width: 3, BreakType: BreakSoft},
+		{Content: "de", Width: 2},
+	}
+	got := txt.JustifyLines(lines, 5, TextJustifyInterCharacter, AlignLeft)
+
+	if got[0].Content != "a b c" || got[0].Width != 5 {
+		t.Errorf("line 0 = (%q, %v), want (%q, 5)", got[0].Content, got[0].Width, "a b c")
+	}
+	if got[1].Content != "de   " {
+		t.Errorf("last line = %q, want %q", got[1].Content, "de   ")
+	}
+}
+
 // ═══════════════════════════════════════════════════════════════
 //  Hanging Punctuation Tests
 // ═══════════════════════════════════════════════════════════════
@@ -273,6 +383,42 @@ func TestShouldHang_None(t *testing.T) {
 	}
 }
 
+func TestShouldHangAtLine(t *testing.T) {
+	txt := NewTerminal()
+	runes := []rune("“Go,” he said. (Then «left»)")
+
+	tests := []struct {
+		name       string
+		start, end int
+		mode       HangingPunctuation
+		wantStart  float64
+		wantEnd    float64
+	}{
+		{"First at line start", 0, 6, HangingPunctuationFirst, 1, 0},
+		{"Last skips trailing space", 0, 6, HangingPunctuationLast, 0, 1},
+		{"First and last", 0, 6, HangingPunctuationFirst | HangingPunctuationLast, 1, 1},
+		{"Stop inside a line", 6, 15, HangingPunctuationForceEnd, 0, 1},
+		{"Stop with allow-end", 6, 15, HangingPunctuationAllowEnd, 0, 1},
+		{"Stop needs end mode", 6, 15, HangingPunctuationLast, 0, 0},
+		{"Opening mid-text line", 15, 21, HangingPunctuationFirst, 1, 0},
+		{"Closing at text end", 21, 28, HangingPunctuationLast, 0, 1},
+		{"No punctuation", 6, 9, HangingPunctuationFirst | HangingPunctuationLast, 0, 0},
+		{"None", 0, 6, HangingPunctuationNone, 0, 0},
+		{"Empty range", 4, 4, HangingPunctuationLast, 0, 0},
+		{"Lone character hangs once", 0, 1, HangingPunctuationFirst | HangingPunctuationLast, 1, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end := txt.ShouldHangAtLine(runes, tt.start, tt.end, tt.mode)
+			if start != tt.wantStart || end != tt.wantEnd {
+				t.Errorf("ShouldHangAtLine(%q) = (%.0f, %.0f), want (%.0f, %.0f)",
+					string(runes[tt.start:tt.end]), start, end, tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+}
+
 func TestShouldHang_Combined(t *testing.T) {
 	txt := NewTerminal()
 
@@ -433,6 +579,145 @@ func TestExpandTabs_Spaces(t *testing.T) {
 	}
 }
 
+func TestExpandTabs_WideCharacters(t *testing.T) {
+	tests := []struct {
+		name    string
+		txt     *Text
+		text    string
+		tabSize TabSize
+		want    string
+	}{
+		{
+			name:    "CJK before tab",
+			txt:     NewTerminal(),
+			text:    "世界\tx",
+			tabSize: TabSize{Value: 8, Unit: TabSizeSpaces},
+			want:    "世界    x", // 4 cells + 4 spaces = column 8
+		},
+		{
+			name:    "CJK before tab, East Asian",
+			txt:     NewTerminalEastAsian(),
+			text:    "世界\tx",
+			tabSize: TabSize{Value: 8, Unit: TabSizeSpaces},
+			want:    "世界    x",
+		},
+		{
+			name:    "Ambiguous width, East Asian",
+			txt:     NewTerminalEastAsian(),
+			text:    "±±±\tx",
+			tabSize: TabSize{Value: 4, Unit: TabSizeSpaces},
+			want:    "±±±  x", // 6 cells + 2 spaces = column 8
+		},
+		{
+			name:    "Wide character straddles a stop",
+			txt:     NewTerminal(),
+			text:    "abc世\tx",
+			tabSize: TabSize{Value: 4, Unit: TabSizeSpaces},
+			want:    "abc世   x", // 5 cells + 3 spaces = column 8
+		},
+		{
+			name:    "Emoji ZWJ sequence",
+			txt:     NewTerminal(),
+			text:    "👨‍👩‍👧\tx",
+			tabSize: TabSize{Value: 4, Unit: TabSizeSpaces},
+			want:    "👨‍👩‍👧  x", // 2 cells + 2 spaces = column 4
+		},
+		{
+			name:    "Multiple tabs after CJK",
+			txt:     NewTerminal(),
+			text:    "世\t界\tx",
+			tabSize: TabSize{Value: 4, Unit: TabSizeSpaces},
+			want:    "世  界  x",
+		},
+		{
+			name:    "Length unit rounds to whole spaces",
+			txt:     NewTerminal(),
+			text:    "a\tx",
+			tabSize: TabSize{Value: 2.6, Unit: TabSizeLength},
+			want:    "a  x", // stop at 2.6, 1.6 cells rounds to 2 spaces
+		},
+		{
+			name:    "Length unit skips a stop closer than half a space",
+			txt:     NewTerminal(),
+			text:    "ab\tx",
+			tabSize: TabSize{Value: 2.4, Unit: TabSizeLength},
+			want:    "ab   x", // 0.4 to the stop at 2.4, so use 4.8: 2.8 rounds to 3
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.txt.ExpandTabs(tt.text, tt.tabSize)
+			if got != tt.want {
+				t.Errorf("ExpandTabs(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpandTabs_LeaderRune(t *testing.T) {
+	tests := []struct {
+		name    string
+		txt     *Text
+		text    string
+		tabSize TabSize
+		want    string
+	}{
+		{
+			name:    "Arrow leader",
+			txt:     NewTerminal(),
+			text:    "ab\tx",
+			tabSize: TabSize{Value: 4, LeaderRune: '→'},
+			want:    "ab→ x",
+		},
+		{
+			name:    "Arrow leader after CJK",
+			txt:     NewTerminal(),
+			text:    "世界\tx",
+			tabSize: TabSize{Value: 8, LeaderRune: '→'},
+			want:    "世界→   x",
+		},
+		{
+			name:    "Wide leader in East Asian terminal",
+			txt:     NewTerminalEastAsian(),
+			text:    "a\tx",
+			tabSize: TabSize{Value: 4, LeaderRune: '→'},
+			want:    "a→ x", // ambiguous arrow is 2 cells
+		},
+		{
+			name:    "Leader wider than gap",
+			txt:     NewTerminalEastAsian(),
+			text:    "abc\tx",
+			tabSize: TabSize{Value: 4, LeaderRune: '→'},
+			want:    "abc x",
+		},
+		{
+			name:    "Repeated dot leader",
+			txt:     NewTerminal(),
+			text:    "Intro\t1",
+			tabSize: TabSize{Value: 8, LeaderRune: '.', RepeatLeader: true},
+			want:    "Intro...1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// want places the leader exactly where the tab was.
+			got := tt.txt.ExpandTabs(tt.text, tt.tabSize)
+			if got != tt.want {
+				t.Errorf("ExpandTabs(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+
+			plain := tt.tabSize
+			plain.LeaderRune = 0
+			plainWidth := tt.txt.Width(tt.txt.ExpandTabs(tt.text, plain))
+			if w := tt.txt.Width(got); w != plainWidth {
+				t.Errorf("width with leader = %.1f, want %.1f (same as plain expansion)", w, plainWidth)
+			}
+		})
+	}
+}
+
 func TestExpandTabs_NoTabs(t *testing.T) {
 	txt := NewTerminal()
 
@@ -544,6 +829,70 @@ func TestWrapBalanced(t *testing.T) {
 	}
 }
 
+func TestWrapBalanced_NoOverflow(t *testing.T) {
+	txt := NewTerminal()
+
+	tests := []struct {
+		name     string
+		text     string
+		maxWidth float64
+		want     []string
+	}{
+		{
+			name:     "Balances two lines",
+			text:     "The quick brown fox jumps over",
+			maxWidth: 25,
+			want:     []string{"The quick brown ", "fox jumps over"},
+		},
+		{
+			name:     "Long word is broken",
+			text:     "a supercalifragilistic word",
+			maxWidth: 8,
+			want:     []string{"a ", "supercal", "ifragili", "stic ", "word"},
+		},
+		{
+			name:     "Single long word",
+			text:     "internationalization",
+			maxWidth: 6,
+			want:     []string{"intern", "ationa", "lizati", "on"},
+		},
+		{
+			name:     "CJK",
+			text:     "世界你好世界你好",
+			maxWidth: 10,
+			want:     []string{"世界你好", "世界你好"},
+		},
+		{
+			name:     "Fits on one line",
+			text:     "Hello",
+			maxWidth: 10,
+			want:     []string{"Hello"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines := txt.WrapBalanced(tt.text, tt.maxWidth)
+
+			runes := []rune(tt.text)
+			var got []string
+			for i, line := range lines {
+				got = append(got, line.Content)
+				if line.Width > tt.maxWidth {
+					t.Errorf("line %d %q width %.1f exceeds max %.1f", i, line.Content, line.Width, tt.maxWidth)
+				}
+				if string(runes[line.Start:line.End]) != line.Content {
+					t.Errorf("line %d range [%d:%d] = %q, content %q",
+						i, line.Start, line.End, string(runes[line.Start:line.End]), line.Content)
+				}
+			}
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("WrapBalanced() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestWrapPretty(t *testing.T) {
 	txt := NewTerminal()
 
@@ -592,10 +941,263 @@ func TestWrapPretty(t *testing.T) {
 	}
 }
 
+func TestWrapPretty_Orphans(t *testing.T) {
+	txt := NewTerminal()
+
+	tests := []struct {
+		name     string
+		text     string
+		maxWidth float64
+		want     []string
+	}{
+		{
+			name:     "Pulls several words",
+			text:     "aaaaaaaaaaaaa b c d e f",
+			maxWidth: 20,
+			want:     []string{"aaaaaaaaaaaaa ", "b c d e f"},
+		},
+		{
+			name:     "Single pull",
+			text:     "one two three four five six seven eight nine x",
+			maxWidth: 20,
+			want:     []string{"one two three four ", "five six seven ", "eight nine x"},
+		},
+		{
+			name:     "Pull would overflow",
+			text:     "a bbbbbbbbbbbbbbbb abcdefg",
+			maxWidth: 20,
+			want:     []string{"a bbbbbbbbbbbbbbbb ", "abcdefg"},
+		},
+		{
+			name:     "Previous line is one word",
+			text:     "abcdefghijklmnopqr st",
+			maxWidth: 20,
+			want:     []string{"abcdefghijklmnopqr ", "st"},
+		},
+		{
+			name:     "Last line long enough",
+			text:     "This is a test of pretty wrapping mode",
+			maxWidth: 20,
+			want:     []string{"This is a test of ", "pretty wrapping mode"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines := txt.WrapPretty(tt.text, tt.maxWidth)
+
+			runes := []rune(tt.text)
+			var got []string
+			for i, line := range lines {
+				got = append(got, line.Content)
+				if line.Width > tt.maxWidth {
+					t.Errorf("line %d %q width %.1f exceeds max %.1f", i, line.Content, line.Width, tt.maxWidth)
+				}
+				if line.Width != txt.Width(line.Content) {
+					t.Errorf("line %d width %.1f, content measures %.1f", i, line.Width, txt.Width(line.Content))
+				}
+				if string(runes[line.Start:line.End]) != line.Content {
+					t.Errorf("line %d range [%d:%d] = %q, content %q",
+						i, line.Start, line.End, string(runes[line.Start:line.End]), line.Content)
+				}
+			}
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("WrapPretty() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWrapStable(t *testing.T) {
+	txt := NewTerminal()
+
+	tests := []struct {
+		name     string
+		original string
+		edited   string
+		editAt   int // rune offset of the edit in original
+		maxWidth float64
+		// The line before the edited one may reflow as well.
+		prevReflows bool
+	}{
+		{
+			name:     "Insertion late in the text",
+			original: "The quick brown fox jumps over the lazy dog",
+			edited:   "The quick brown fox jumps over the very lazy dog",
+			editAt:   len([]rune("The quick brown fox jumps over the ")),
+			maxWidth: 15,
+		},
+		{
+			name:        "Shortened word moves up a line",
+			original:    "one two aaaa bbbbbbbb cc",
+			edited:      "one two aaaa bb cc",
+			editAt:      len([]rune("one two aaaa ")),
+			maxWidth:    10,
+			prevReflows: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := txt.WrapStable(tt.original, tt.maxWidth)
+			after := txt.WrapStable(tt.edited, tt.maxWidth)
+
+			edited := len(before) - 1
+			for i, line := range before {
+				if line.End > tt.editAt {
+					edited = i
+					break
+				}
+			}
+
+			// Lines more than one before the edited line are unchanged.
+			for i := 0; i < edited-1; i++ {
+				if after[i] != before[i] {
+					t.Errorf("line %d reflowed: %+v -> %+v", i, before[i], after[i])
+				}
+			}
+			if prev := edited - 1; prev >= 0 && (after[prev] != before[prev]) != tt.prevReflows {
+				t.Errorf("line %d before the edit: %+v -> %+v, reflow = %v, want %v",
+					prev, before[prev], after[prev], after[prev] != before[prev], tt.prevReflows)
+			}
+		})
+	}
+}
+
+func TestWrapMode(t *testing.T) {
+	txt := NewTerminal()
+	text := "This is a test of pretty wrapping mode"
+
+	tests := []struct {
+		name string
+		mode TextWrap
+		want []Line
+	}{
+		{"Wrap", TextWrapWrap, txt.Wrap(text, WrapOptions{MaxWidth: 20})},
+		{"Nowrap", TextWrapNowrap, []Line{{Content: text, Width: txt.Width(text), Start: 0, End: len([]rune(text))}}},
+		{"Balance", TextWrapBalance, txt.WrapBalanced(text, 20)},
+		{"Stable", TextWrapStable, txt.WrapStable(text, 20)},
+		{"Pretty", TextWrapPretty, txt.WrapPretty(text, 20)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := txt.WrapMode(text, 20, tt.mode)
+			if len(got) != len(tt.want) {
+				t.Fatalf("WrapMode() = %d lines %+v, want %d", len(got), got, len(tt.want))
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("line %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
 // ═══════════════════════════════════════════════════════════════
 //  Text Spacing Trim Tests
 // ═══════════════════════════════════════════════════════════════
 
+// paragraphLines builds placeholder lines for paragraphs of the given sizes,
+// marking the last line of every paragraph but the final one as BreakHard.
+func paragraphLines(sizes ...int) []Line {
+	var lines []Line
+	for p, n := range sizes {
+		for i := 0; i < n; i++ {
+			line := Line{Content: fmt.Sprintf("p%d-l%d", p, i)}
+			if i < n-1 {
+				line.BreakType = BreakSoft
+			} else if p < len(sizes)-1 {
+				line.BreakType = BreakHard
+			}
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+func TestFlowColumns(t *testing.T) {
+	txt := NewTerminal()
+
+	tests := []struct {
+		name       string
+		paragraphs []int
+		colHeight  int
+		widows     int
+		orphans    int
+		want       []int // lines per column
+	}{
+		{"No constraints", []int{4, 4}, 5, 1, 1, []int{5, 3}},
+		{"Orphans push paragraph forward", []int{4, 4}, 5, 1, 2, []int{4, 4}},
+		{"Widows pull lines forward", []int{2, 4}, 5, 2, 1, []int{4, 2}},
+		{"Constraints already satisfied", []int{3, 4}, 5, 2, 2, []int{5, 2}},
+		{"Long paragraph spans columns", []int{11}, 5, 2, 2, []int{5, 4, 2}},
+		{"Fits in one column", []int{2, 2}, 5, 2, 2, []int{4}},
+		{"Negative constraints", []int{4, 4}, 5, -3, -3, []int{5, 3}},
+		{"Negative orphans with large widows", []int{2, 4}, 5, 10, -10, []int{2, 4}},
+		{"Zero column height", []int{2, 1}, 0, 1, 1, []int{1, 1, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines := paragraphLines(tt.paragraphs...)
+			columns := txt.FlowColumns(lines, tt.colHeight, tt.widows, tt.orphans)
+
+			var got []int
+			var flowed []Line
+			for _, col := range columns {
+				got = append(got, len(col))
+				flowed = append(flowed, col...)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("column sizes = %v, want %v", got, tt.want)
+			}
+
+			// Every line must appear exactly once, in order.
+			if len(flowed) != len(lines) {
+				t.Fatalf("flowed %d lines, want %d", len(flowed), len(lines))
+			}
+			for i := range lines {
+				if flowed[i].Content != lines[i].Content {
+					t.Errorf("line %d = %q, want %q", i, flowed[i].Content, lines[i].Content)
+				}
+			}
+		})
+	}
+}
+
+func TestFlowColumns_WrappedParagraphs(t *testing.T) {
+	txt := NewTerminal()
+
+	// First paragraph wraps to 4 lines and the second to 3.
+	lines := txt.Wrap("one two three four\nfive six seven", WrapOptions{
+		MaxWidth:         5,
+		PreserveNewlines: true,
+	})
+
+	// With room for only one line of the second paragraph after the first,
+	// orphans=2 moves the whole paragraph to the next column.
+	columns := txt.FlowColumns(lines, 5, 1, 2)
+	if len(columns) != 2 {
+		t.Fatalf("got %d columns, want 2", len(columns))
+	}
+	if len(columns[0]) != 4 {
+		t.Errorf("first column has %d lines, want 4 (first paragraph only)", len(columns[0]))
+	}
+	if strings.TrimSpace(columns[1][0].Content) != "five" {
+		t.Errorf("second column starts with %q, want %q", columns[1][0].Content, "five")
+	}
+}
+
+func TestFlowColumns_Empty(t *testing.T) {
+	txt := NewTerminal()
+
+	if columns := txt.FlowColumns(nil, 5, 2, 2); columns != nil {
+		t.Errorf("expected nil for no lines, got %v", columns)
+	}
+}
+
 func TestTrimCJKSpacing_SpaceAll(t *testing.T) {
 	txt := NewTerminal()
 
@@ -698,6 +1300,60 @@ func TestTrimCJKSpacing_None(t *testing.T) {
 	}
 }
 
+func TestTrimCJKSpacing_Auto(t *testing.T) {
+	txt := NewTerminal()
+
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"Spaces inside corner brackets", "「 世界 」", "「世界」"},
+		{"Multiple spaces inside brackets", "（  世界  ）", "（世界）"},
+		{"Space before full stop", "世界 。", "世界。"},
+		{"Space before ideographic comma", "世界 、和平", "世界、和平"},
+		{"Space between ideographs kept", "世界 和平", "世界 和平"},
+		{"Leading space before CJK", " 世界", "世界"},
+		{"Trailing space after CJK", "世界。 \n 和平", "世界。\n和平"},
+		{"Latin spacing kept", " Hello world ", " Hello world "},
+		{"Space after closing bracket kept", "「世界」 和平", "「世界」 和平"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := txt.TrimCJKSpacing(tt.text, TextSpacingTrimAuto)
+			if got != tt.want {
+				t.Errorf("TrimCJKSpacing(%q, Auto) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTrimCJKSpacing_SpaceLast(t *testing.T) {
+	txt := NewTerminal()
+
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"Trailing space after ideograph", "世界  ", "世界"},
+		{"Trailing space before newline", "世界 \n和平 ", "世界\n和平"},
+		{"Trailing space after full stop", "世界。 ", "世界。"},
+		{"Trailing space after Latin kept", "Hello ", "Hello "},
+		{"Inner space kept", "世界 和平", "世界 和平"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := txt.TrimCJKSpacing(tt.text, TextSpacingTrimSpaceLast)
+			if got != tt.want {
+				t.Errorf("TrimCJKSpacing(%q, SpaceLast) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestIsCJKIdeograph(t *testing.T) {
 	tests := []struct {
 		name string
@@ -1008,6 +1664,166 @@ func TestApplyAutospace_IdeographNumeric(t *testing.T) {
 	}
 }
 
+func TestApplyAutospace_Symbols(t *testing.T) {
+	txt := NewTerminal()
+
+	tests := []struct {
+		name     string
+		text     string
+		flags    AutospaceFlags
+		expected string
+	}{
+		{
+			name:     "Dollar after ideographs",
+			text:     "価格$100",
+			flags:    AutospaceSymbols,
+			expected: "価格 $100",
+		},
+		{
+			name:     "Percent before ideographs",
+			text:     "50%割引",
+			flags:    AutospaceSymbols,
+			expected: "50% 割引",
+		},
+		{
+			name:     "Euro and yen",
+			text:     "合計€20と¥300",
+			flags:    AutospaceSymbols,
+			expected: "合計 €20と ¥300",
+		},
+		{
+			name:     "Degrees Celsius",
+			text:     "気温30℃です",
+			flags:    AutospaceSymbols,
+			expected: "気温30℃ です",
+		},
+		{
+			name:     "Fullwidth symbols unchanged",
+			text:     "価格＄100と50％",
+			flags:    AutospaceSymbols,
+			expected: "価格＄100と50％",
+		},
+		{
+			name:     "Existing space kept single",
+			text:     "価格 $100",
+			flags:    AutospaceSymbols,
+			expected: "価格 $100",
+		},
+		{
+			name:     "Not applied without flag",
+			text:     "価格$100",
+			flags:    AutospaceIdeographAlpha,
+			expected: "価格$100",
+		},
+		{
+			name:     "Combined with numeric",
+			text:     "価格$100です",
+			flags:    AutospaceAll,
+			expected: "価格 $100 です",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := txt.ApplyAutospace(tt.text, tt.flags)
+
+			if result != tt.expected {
+				t.Errorf("ApplyAutospace(%q) = %q, want %q", tt.text, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestApplyAutospace_Graphemes(t *testing.T) {
+	txt := NewTerminal()
+
+	tests := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{
+			name:     "Variation selector after ideograph",
+			text:     "世\uFE0F123",
+			expected: "世\uFE0F 123",
+		},
+		{
+			name:     "Ideographic variation selector",
+			text:     "葛\U000E0100abc",
+			expected: "葛\U000E0100 abc",
+		},
+		{
+			name:     "Combining diacritic before ideograph",
+			text:     "cafe\u0301中文",
+			expected: "cafe\u0301 中文",
+		},
+		{
+			name:     "Combining diacritic after ideograph",
+			text:     "中文e\u0301",
+			expected: "中文 e\u0301",
+		},
+		{
+			name:     "Digit with keycap",
+			text:     "第1\uFE0F\u20E3名",
+			expected: "第 1\uFE0F\u20E3 名",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := txt.ApplyAutospace(tt.text, AutospaceAll)
+
+			if result != tt.expected {
+				t.Errorf("ApplyAutospace(%q) = %q, want %q", tt.text, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestAutospacePositions(t *testing.T) {
+	txt := NewTerminal()
+
+	tests := []struct {
+		name  string
+		text  string
+		flags AutospaceFlags
+		want  []int
+	}{
+		{"Mixed scripts", "Hello世界123", AutospaceAll, []int{5, 7}},
+		{"Alpha only", "Hello世界123", AutospaceIdeographAlpha, []int{5}},
+		{"Numeric only", "Hello世界123", AutospaceIdeographNumeric, []int{7}},
+		{"Existing space", "Hello 世界", AutospaceAll, nil},
+		{"Combining mark", "cafe\u0301中文", AutospaceAll, []int{5}},
+		{"Punctuation only removes", "「 世界 」", AutospacePunctuation, nil},
+		{"None", "Hello世界", AutospaceNone, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := txt.AutospacePositions(tt.text, tt.flags)
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("AutospacePositions(%q) = %v, want %v", tt.text, got, tt.want)
+			}
+
+			// Inserting a space at each position reproduces ApplyAutospace
+			// when no spaces are removed.
+			if tt.flags&AutospacePunctuation == 0 {
+				runes := []rune(tt.text)
+				var b strings.Builder
+				for i, r := range runes {
+					if slices.Contains(got, i) {
+						b.WriteByte(' ')
+					}
+					b.WriteRune(r)
+				}
+				if want := txt.ApplyAutospace(tt.text, tt.flags); b.String() != want {
+					t.Errorf("inserted %q, ApplyAutospace %q", b.String(), want)
+				}
+			}
+		})
+	}
+}
+
 func TestApplyAutospace_Punctuation(t *testing.T) {
 	txt := NewTerminal()
 
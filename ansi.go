@@ -0,0 +1,147 @@
+package text
+
+import "strings"
+
+// ANSI Escape Sequences
+//
+// Terminal text often carries ECMA-48 escape sequences, most commonly SGR
+// (Select Graphic Rendition, ESC [ ... m) for colors and styles. They occupy
+// no cells on screen, so width-sensitive operations must skip them and
+// must never cut through one.
+//
+// Recognized sequences:
+//   - CSI: ESC [ parameters intermediates final (e.g. "\x1b[31m")
+//   - OSC: ESC ] ... terminated by BEL or ESC \ (e.g. hyperlinks)
+//   - Two-byte escapes: ESC followed by a byte in 0x30–0x7E (e.g. "\x1b7")
+
+const (
+	escapeByte = 0x1b
+	bellByte   = 0x07
+
+	// sgrReset turns off all SGR attributes.
+	sgrReset = "\x1b[0m"
+)
+
+// StripANSI removes escape sequences from s, leaving the text they style.
+// An unterminated sequence at the end of s is removed too.
+//
+// Example:
+//
+//	plain := text.StripANSI("\x1b[1;31mHi\x1b[0m") // "Hi"
+func StripANSI(s string) string {
+	if !strings.Contains(s, "\x1b") {
+		return s
+	}
+
+	var b strings.Builder
+	b.Grow(len(s))
+	for _, seg := range splitANSI(s) {
+		if !seg.escape {
+			b.WriteString(seg.text)
+		}
+	}
+	return b.String()
+}
+
+// WidthANSI measures the display width of s, skipping escape sequences,
+// which occupy no cells. Text is measured as if the sequences were absent,
+// so a combining mark separated from its base by a color change still
+// joins it.
+//
+// Example:
+//
+//	txt := text.NewTerminal()
+//	txt.Width("\x1b[1;31mHi\x1b[0m")     // 13.0: the escapes' runes are counted
+//	txt.WidthANSI("\x1b[1;31mHi\x1b[0m") // 2.0
+func (t *Text) WidthANSI(s string) float64 {
+	return t.Width(StripANSI(s))
+}
+
+// ansiSegment is either a run of plain text or a single escape sequence.
+type ansiSegment struct {
+	text   string
+	escape bool
+}
+
+// splitANSI splits s into plain text runs and escape sequences.
+// An unterminated sequence at the end of s is treated as an escape.
+func splitANSI(s string) []ansiSegment {
+	var segments []ansiSegment
+	plainStart := 0
+
+	for i := 0; i < len(s); {
+		if s[i] != escapeByte {
+			i++
+			continue
+		}
+
+		end := escapeEnd(s, i)
+		if plainStart < i {
+			segments = append(segments, ansiSegment{text: s[plainStart:i]})
+		}
+		segments = append(segments, ansiSegment{text: s[i:end], escape: true})
+		i = end
+		plainStart = end
+	}
+
+	if plainStart < len(s) {
+		segments = append(segments, ansiSegment{text: s[plainStart:]})
+	}
+	return segments
+}
+
+// escapeEnd returns the end offset of the escape sequence starting at s[i].
+func escapeEnd(s string, i int) int {
+	j := i + 1
+	if j >= len(s) {
+		return j
+	}
+
+	switch s[j] {
+	case '[': // CSI
+		j++
+		for j < len(s) && s[j] >= 0x20 && s[j] <= 0x3F {
+			j++ // parameter and intermediate bytes
+		}
+		if j < len(s) && s[j] >= 0x40 && s[j] <= 0x7E {
+			j++ // final byte
+		}
+		return j
+
+	case ']': // OSC, terminated by BEL or ST (ESC \)
+		for j++; j < len(s); j++ {
+			if s[j] == bellByte {
+				return j + 1
+			}
+			if s[j] == escapeByte && j+1 < len(s) && s[j+1] == '\\' {
+				return j + 2
+			}
+		}
+		return j
+
+	default:
+		if s[j] >= 0x30 && s[j] <= 0x7E {
+			return j + 1
+		}
+		return j
+	}
+}
+
+// isSGR reports whether seq is an SGR sequence (ESC [ ... m).
+func isSGR(seq string) bool {
+	return len(seq) >= 3 && seq[1] == '[' && seq[len(seq)-1] == 'm'
+}
+
+// isSGRReset reports whether seq is an SGR sequence that resets all
+// attributes ("\x1b[m", "\x1b[0m", "\x1b[0;0m").
+func isSGRReset(seq string) bool {
+	if !isSGR(seq) {
+		return false
+	}
+	for _, param := range strings.Split(seq[2:len(seq)-1], ";") {
+		if param != "" && strings.Trim(param, "0") != "" {
+			return false
+		}
+	}
+	return true
+}
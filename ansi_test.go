@@ -0,0 +1,109 @@
+package text
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitANSI(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want []ansiSegment
+	}{
+		{"Plain", "Hello", []ansiSegment{{text: "Hello"}}},
+		{"SGR", "\x1b[31mHi\x1b[0m", []ansiSegment{
+			{text: "\x1b[31m", escape: true},
+			{text: "Hi"},
+			{text: "\x1b[0m", escape: true},
+		}},
+		{"OSC hyperlink", "\x1b]8;;https://example.com\x1b\\link", []ansiSegment{
+			{text: "\x1b]8;;https://example.com\x1b\\", escape: true},
+			{text: "link"},
+		}},
+		{"Two-byte escape", "a\x1b7b", []ansiSegment{
+			{text: "a"},
+			{text: "\x1b7", escape: true},
+			{text: "b"},
+		}},
+		{"Unterminated CSI", "a\x1b[31", []ansiSegment{
+			{text: "a"},
+			{text: "\x1b[31", escape: true},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := splitANSI(tt.in); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("splitANSI(%q) = %+v, want %+v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStripANSI(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"Plain", "Hello", "Hello"},
+		{"SGR", "\x1b[1;31mHi\x1b[0m", "Hi"},
+		{"OSC hyperlink", "\x1b]8;;https://example.com\x1b\\link\x1b]8;;\x1b\\", "link"},
+		{"Cursor movement", "a\x1b[2Kb\x1b7c", "abc"},
+		{"Unterminated CSI", "a\x1b[31", "a"},
+		{"Wide text", "\x1b[32m世界\x1b[0m", "世界"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StripANSI(tt.in); got != tt.want {
+				t.Errorf("StripANSI(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWidthANSI(t *testing.T) {
+	txt := NewTerminal()
+
+	tests := []struct {
+		name string
+		in   string
+		want float64
+	}{
+		{"SGR", "\x1b[1;31mHi\x1b[0m", 2},
+		{"Plain", "Hello", 5},
+		{"Wide text", "\x1b[32m世界\x1b[0m", 4},
+		{"Mark split from base", "e\x1b[31m\u0301\x1b[0m", 1},
+		{"Only escapes", "\x1b[0m\x1b[2J", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := txt.WidthANSI(tt.in); got != tt.want {
+				t.Errorf("WidthANSI(%q) = %.1f, want %.1f", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsSGRReset(t *testing.T) {
+	tests := []struct {
+		seq  string
+		want bool
+	}{
+		{"\x1b[0m", true},
+		{"\x1b[m", true},
+		{"\x1b[0;00m", true},
+		{"\x1b[31m", false},
+		{"\x1b[0;31m", false},
+		{"\x1b[2J", false},
+	}
+
+	for _, tt := range tests {
+		if got := isSGRReset(tt.seq); got != tt.want {
+			t.Errorf("isSGRReset(%q) = %v, want %v", tt.seq, got, tt.want)
+		}
+	}
+}
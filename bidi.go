@@ -2,6 +2,7 @@ package text
 
 import (
 	"strings"
+	"unicode/utf8"
 
 	"github.com/SCKelemen/unicode/v6/uax9"
 )
@@ -41,6 +42,66 @@ func toUAX9Direction(d Direction) uax9.Direction {
 	}
 }
 
+// bidiLevels resolves the UAX #9 embedding level of each rune. DirectionAuto
+// takes the paragraph level from the first strong character. Removed
+// characters (explicit embedding controls) get the paragraph level so they
+// stay in place.
+func bidiLevels(runes []rune, dir uax9.Direction) (levels []int, paraLevel int) {
+	classes := make([]uax9.BidiClass, len(runes))
+	for i, r := range runes {
+		classes[i] = uax9.GetBidiClass(r)
+	}
+
+	switch dir {
+	case uax9.DirectionRTL:
+		paraLevel = 1
+	case uax9.DirectionAuto:
+		if firstStrongDirection(string(runes)) == uax9.DirectionRTL {
+			paraLevel = 1
+		}
+	}
+
+	levels = uax9.ComputeLevels(classes, paraLevel)
+	for i, level := range levels {
+		if level < 0 {
+			levels[i] = paraLevel
+		}
+	}
+	return levels, paraLevel
+}
+
+// visualOrder returns the logical indices of items in left-to-right display
+// order, reversing runs from the highest level down to the lowest odd level
+// (UAX #9 rule L2).
+func visualOrder(levels []int, paraLevel int) []int {
+	order := make([]int, len(levels))
+	for i := range order {
+		order[i] = i
+	}
+
+	maxLevel := paraLevel
+	for _, level := range levels {
+		maxLevel = max(maxLevel, level)
+	}
+
+	for level := maxLevel; level >= max(paraLevel, 1); level-- {
+		for i := 0; i < len(levels); {
+			if levels[order[i]] < level {
+				i++
+				continue
+			}
+			start := i
+			for i < len(levels) && levels[order[i]] >= level {
+				i++
+			}
+			for a, b := start, i-1; a < b; a, b = a+1, b-1 {
+				order[a], order[b] = order[b], order[a]
+			}
+		}
+	}
+	return order
+}
+
 // ═══════════════════════════════════════════════════════════════
 //  Paragraph-Level Reordering
 // ═══════════════════════════════════════════════════════════════
@@ -73,12 +134,118 @@ func (t *Text) ReorderParagraph(text string, baseDirection Direction) string {
 
 	for i, line := range lines {
 		// Each line is treated as a separate paragraph
-		reordered[i] = uax9.Reorder(line, dir)
+		reordered[i] = reorderMirrored(line, dir)
 	}
 
 	return strings.Join(reordered, "\n")
 }
 
+// ParagraphOptions controls how text is split into paragraphs for
+// direction detection.
+//
+// U+2029 PARAGRAPH SEPARATOR always starts a new paragraph. U+2028 LINE
+// SEPARATOR never does: it breaks a line inside a paragraph, so the text on
+// both sides shares one base direction.
+type ParagraphOptions struct {
+	// SplitNewlines also treats "\n", "\r", and "\r\n" as paragraph
+	// separators, as UAX #9 does for plain text.
+	SplitNewlines bool
+}
+
+// ParagraphDirection is one paragraph and its resolved base direction.
+type ParagraphDirection struct {
+	Text      string         // Paragraph text, including its trailing separator
+	Start     int            // Rune index where the paragraph starts
+	End       int            // Rune index after the separator (exclusive)
+	Direction uax9.Direction // Base direction from the first strong character
+}
+
+// ParagraphDirections splits text into paragraphs and determines the base
+// direction of each one independently (UAX #9 rules P1–P3).
+//
+// Each paragraph takes the direction of its first strong character, skipping
+// text inside isolates (LRI/RLI/FSI ... PDI); a paragraph with no strong
+// character is LTR. The separator is kept with the paragraph it ends.
+//
+// Example:
+//
+//	txt := text.NewTerminal()
+//	paras := txt.ParagraphDirections("Hello\u2029שלום", text.ParagraphOptions{})
+//	// paras[0].Direction == uax9.DirectionLTR
+//	// paras[1].Direction == uax9.DirectionRTL
+func (t *Text) ParagraphDirections(text string, opts ParagraphOptions) []ParagraphDirection {
+	var paras []ParagraphDirection
+	start, runeStart, runeIdx := 0, 0, 0
+
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		i += size
+		runeIdx++
+
+		if !isParagraphSeparator(r, opts) {
+			continue
+		}
+		if r == '\r' && i < len(text) && text[i] == '\n' {
+			i++
+			runeIdx++
+		}
+
+		paras = append(paras, ParagraphDirection{
+			Text:      text[start:i],
+			Start:     runeStart,
+			End:       runeIdx,
+			Direction: firstStrongDirection(text[start:i]),
+		})
+		start, runeStart = i, runeIdx
+	}
+
+	if start < len(text) {
+		paras = append(paras, ParagraphDirection{
+			Text:      text[start:],
+			Start:     runeStart,
+			End:       runeIdx,
+			Direction: firstStrongDirection(text[start:]),
+		})
+	}
+	return paras
+}
+
+// isParagraphSeparator reports whether r ends a paragraph under opts.
+func isParagraphSeparator(r rune, opts ParagraphOptions) bool {
+	switch r {
+	case '\u2029':
+		return true
+	case '\n', '\r':
+		return opts.SplitNewlines
+	}
+	return false
+}
+
+// firstStrongDirection applies UAX #9 rule P2 to a single paragraph: the
+// first L, R, or AL character outside an isolate decides the direction.
+func firstStrongDirection(paragraph string) uax9.Direction {
+	isolates := 0
+	for _, r := range paragraph {
+		switch uax9.GetBidiClass(r) {
+		case uax9.ClassLRI, uax9.ClassRLI, uax9.ClassFSI:
+			isolates++
+		case uax9.ClassPDI:
+			if isolates > 0 {
+				isolates--
+			}
+		case uax9.ClassL:
+			if isolates == 0 {
+				return uax9.DirectionLTR
+			}
+		case uax9.ClassR, uax9.ClassAL:
+			if isolates == 0 {
+				return uax9.DirectionRTL
+			}
+		}
+	}
+	return uax9.DirectionLTR
+}
+
 // ═══════════════════════════════════════════════════════════════
 //  Integration with Line Breaking
 // ═══════════════════════════════════════════════════════════════
@@ -104,54 +271,297 @@ func (t *Text) ReorderParagraph(text string, baseDirection Direction) string {
 //	line.Content = txt.ReorderLine(line.Content, text.DirectionAuto)
 func (t *Text) ReorderLine(text string, direction Direction) string {
 	dir := toUAX9Direction(direction)
-	return uax9.Reorder(text, dir)
+	return reorderMirrored(text, dir)
+}
+
+// ═══════════════════════════════════════════════════════════════
+//  Visual Runs
+// ═══════════════════════════════════════════════════════════════
+
+// Run is a maximal stretch of text at a single bidi embedding level.
+type Run struct {
+	Text  string // Run text in logical order, mirrored if IsRTL
+	Level int    // UAX #9 embedding level
+	IsRTL bool   // Level is odd: draw Text right to left
+	Start int    // Rune index of the run's first character
+	End   int    // Rune index just past the run
+}
+
+// ReorderRuns splits text into runs of equal embedding level and returns
+// them in visual (left-to-right display) order, using Config.BaseDirection
+// as the paragraph direction.
+//
+// Each run's Text stays in logical order so renderers keep combining marks
+// with their bases; RTL runs are drawn right to left and have their
+// brackets already mirrored (see MirrorChar), so "(" in an RTL run is ")".
+//
+// Example:
+//
+//	txt := text.NewTerminal()
+//	runs := txt.ReorderRuns("abc مرحبا 123")
+//	// runs[0]: {Text: "abc ", Level: 0}
+//	// runs[1]: {Text: "123", Level: 2}
+//	// runs[2]: {Text: "مرحبا ", Level: 1, IsRTL: true}
+func (t *Text) ReorderRuns(text string) []Run {
+	runes := []rune(text)
+	if len(runes) == 0 {
+		return nil
+	}
+
+	levels, paraLevel := bidiLevels(runes, t.config.BaseDirection)
+
+	// Logical runs of equal level
+	var runs []Run
+	for start := 0; start < len(runes); {
+		end := start + 1
+		for end < len(runes) && levels[end] == levels[start] {
+			end++
+		}
+		text := string(runes[start:end])
+		if levels[start]%2 == 1 {
+			text = strings.Map(MirrorChar, text)
+		}
+		runs = append(runs, Run{
+			Text:  text,
+			Level: levels[start],
+			IsRTL: levels[start]%2 == 1,
+			Start: start,
+			End:   end,
+		})
+		start = end
+	}
+
+	// Reordering whole runs by level gives the same order as reordering
+	// their characters, since each run reverses as a unit.
+	runLevels := make([]int, len(runs))
+	for i, run := range runs {
+		runLevels[i] = run.Level
+	}
+	visual := make([]Run, len(runs))
+	for i, logical := range visualOrder(runLevels, paraLevel) {
+		visual[i] = runs[logical]
+	}
+	return visual
 }
 
 // ═══════════════════════════════════════════════════════════════
 //  Bracket Mirroring
 // ═══════════════════════════════════════════════════════════════
 
-// mirrorBrackets maps opening brackets to their mirrored closing equivalents.
-var mirrorBrackets = map[rune]rune{
-	'(': ')',
-	')': '(',
-	'[': ']',
-	']': '[',
-	'{': '}',
-	'}': '{',
-	'<': '>',
-	'>': '<',
-	'«': '»',
-	'»': '«',
-	'‹': '›',
-	'›': '‹',
-	'⟨': '⟩',
-	'⟩': '⟨',
-	'⟪': '⟫',
-	'⟫': '⟪',
-	'⟬': '⟭',
-	'⟭': '⟬',
-	'⟮': '⟯',
-	'⟯': '⟮',
+// mirrorGlyphs holds the Bidi_Mirroring_Glyph pairs (BidiMirroring.txt)
+// for brackets, quotation marks and relational operators. Characters that
+// are Bidi_Mirrored without a mirror glyph, such as ∑, are left out.
+var mirrorGlyphs = map[rune]rune{
+	// Latin-1 brackets and quotation marks
+	'(': ')', ')': '(',
+	'<': '>', '>': '<',
+	'[': ']', ']': '[',
+	'{': '}', '}': '{',
+	'«': '»', '»': '«',
+	// General punctuation, super- and subscripts
+	'‹': '›', '›': '‹',
+	'⁅': '⁆', '⁆': '⁅',
+	'⁽': '⁾', '⁾': '⁽',
+	'₍': '₎', '₎': '₍',
+	// Mathematical operators
+	'∈': '∋', '∋': '∈',
+	'∉': '∌', '∌': '∉',
+	'∊': '∍', '∍': '∊',
+	'∕': '⧵', '⧵': '∕',
+	'∼': '∽', '∽': '∼',
+	'≃': '⋍', '⋍': '≃',
+	'≒': '≓', '≓': '≒',
+	'≔': '≕', '≕': '≔',
+	'≤': '≥', '≥': '≤',
+	'≦': '≧', '≧': '≦',
+	'≨': '≩', '≩': '≨',
+	'≪': '≫', '≫': '≪',
+	'≮': '≯', '≯': '≮',
+	'≰': '≱', '≱': '≰',
+	'≲': '≳', '≳': '≲',
+	'≴': '≵', '≵': '≴',
+	'≶': '≷', '≷': '≶',
+	'≸': '≹', '≹': '≸',
+	'≺': '≻', '≻': '≺',
+	'≼': '≽', '≽': '≼',
+	'≾': '≿', '≿': '≾',
+	'⊀': '⊁', '⊁': '⊀',
+	'⊂': '⊃', '⊃': '⊂',
+	'⊄': '⊅', '⊅': '⊄',
+	'⊆': '⊇', '⊇': '⊆',
+	'⊈': '⊉', '⊉': '⊈',
+	'⊊': '⊋', '⊋': '⊊',
+	'⊏': '⊐', '⊐': '⊏',
+	'⊑': '⊒', '⊒': '⊑',
+	'⊘': '⦸', '⦸': '⊘',
+	'⊢': '⊣', '⊣': '⊢',
+	'⊦': '⫞', '⫞': '⊦',
+	'⊨': '⫤', '⫤': '⊨',
+	'⊩': '⫣', '⫣': '⊩',
+	'⊫': '⫥', '⫥': '⊫',
+	'⊰': '⊱', '⊱': '⊰',
+	'⊲': '⊳', '⊳': '⊲',
+	'⊴': '⊵', '⊵': '⊴',
+	'⊶': '⊷', '⊷': '⊶',
+	'⋉': '⋊', '⋊': '⋉',
+	'⋋': '⋌', '⋌': '⋋',
+	'⋐': '⋑', '⋑': '⋐',
+	'⋖': '⋗', '⋗': '⋖',
+	'⋘': '⋙', '⋙': '⋘',
+	'⋚': '⋛', '⋛': '⋚',
+	'⋜': '⋝', '⋝': '⋜',
+	'⋞': '⋟', '⋟': '⋞',
+	'⋠': '⋡', '⋡': '⋠',
+	'⋢': '⋣', '⋣': '⋢',
+	'⋤': '⋥', '⋥': '⋤',
+	'⋦': '⋧', '⋧': '⋦',
+	'⋨': '⋩', '⋩': '⋨',
+	'⋪': '⋫', '⋫': '⋪',
+	'⋬': '⋭', '⋭': '⋬',
+	'⋰': '⋱', '⋱': '⋰',
+	'⋲': '⋺', '⋺': '⋲',
+	'⋳': '⋻', '⋻': '⋳',
+	'⋴': '⋼', '⋼': '⋴',
+	'⋶': '⋽', '⋽': '⋶',
+	'⋷': '⋾', '⋾': '⋷',
+	// Technical
+	'⌈': '⌉', '⌉': '⌈',
+	'⌊': '⌋', '⌋': '⌊',
+	'〈': '〉', '〉': '〈',
+	// Dingbat brackets
+	'❨': '❩', '❩': '❨',
+	'❪': '❫', '❫': '❪',
+	'❬': '❭', '❭': '❬',
+	'❮': '❯', '❯': '❮',
+	'❰': '❱', '❱': '❰',
+	'❲': '❳', '❳': '❲',
+	'❴': '❵', '❵': '❴',
+	'⟃': '⟄', '⟄': '⟃',
+	'⟅': '⟆', '⟆': '⟅',
+	'⟈': '⟉', '⟉': '⟈',
+	'⟋': '⟍', '⟍': '⟋',
+	'⟕': '⟖', '⟖': '⟕',
+	'⟝': '⟞', '⟞': '⟝',
+	'⟢': '⟣', '⟣': '⟢',
+	'⟤': '⟥', '⟥': '⟤',
+	'⟦': '⟧', '⟧': '⟦',
+	'⟨': '⟩', '⟩': '⟨',
+	'⟪': '⟫', '⟫': '⟪',
+	'⟬': '⟭', '⟭': '⟬',
+	'⟮': '⟯', '⟯': '⟮',
+	// Supplemental mathematical brackets and operators
+	'⦃': '⦄', '⦄': '⦃',
+	'⦅': '⦆', '⦆': '⦅',
+	'⦇': '⦈', '⦈': '⦇',
+	'⦉': '⦊', '⦊': '⦉',
+	'⦋': '⦌', '⦌': '⦋',
+	'⦍': '⦐', '⦐': '⦍',
+	'⦎': '⦏', '⦏': '⦎',
+	'⦑': '⦒', '⦒': '⦑',
+	'⦓': '⦔', '⦔': '⦓',
+	'⦕': '⦖', '⦖': '⦕',
+	'⦗': '⦘', '⦘': '⦗',
+	'⧀': '⧁', '⧁': '⧀',
+	'⧄': '⧅', '⧅': '⧄',
+	'⧏': '⧐', '⧐': '⧏',
+	'⧑': '⧒', '⧒': '⧑',
+	'⧔': '⧕', '⧕': '⧔',
+	'⧘': '⧙', '⧙': '⧘',
+	'⧚': '⧛', '⧛': '⧚',
+	'⧨': '⧩', '⧩': '⧨',
+	'⧸': '⧹', '⧹': '⧸',
+	'⧼': '⧽', '⧽': '⧼',
+	// Supplemental punctuation
+	'⸂': '⸃', '⸃': '⸂',
+	'⸄': '⸅', '⸅': '⸄',
+	'⸉': '⸊', '⸊': '⸉',
+	'⸌': '⸍', '⸍': '⸌',
+	'⸜': '⸝', '⸝': '⸜',
+	'⸠': '⸡', '⸡': '⸠',
+	'⸢': '⸣', '⸣': '⸢',
+	'⸤': '⸥', '⸥': '⸤',
+	'⸦': '⸧', '⸧': '⸦',
+	'⸨': '⸩', '⸩': '⸨',
 	// CJK brackets
-	'「': '」',
-	'」': '「',
-	'『': '』',
-	'』': '『',
-	'〈': '〉',
-	'〉': '〈',
-	'《': '》',
-	'》': '《',
-	'【': '】',
-	'】': '【',
-	'〔': '〕',
-	'〕': '〔',
-	'〖': '〗',
-	'〗': '〖',
-	'〘': '〙',
-	'〙': '〘',
-	'〚': '〛',
-	'〛': '〚',
+	'〈': '〉', '〉': '〈',
+	'《': '》', '》': '《',
+	'「': '」', '」': '「',
+	'『': '』', '』': '『',
+	'【': '】', '】': '【',
+	'〔': '〕', '〕': '〔',
+	'〖': '〗', '〗': '〖',
+	'〘': '〙', '〙': '〘',
+	'〚': '〛', '〛': '〚',
+	// Small and fullwidth forms
+	'﹙': '﹚', '﹚': '﹙',
+	'﹛': '﹜', '﹜': '﹛',
+	'﹝': '﹞', '﹞': '﹝',
+	'﹤': '﹥', '﹥': '﹤',
+	'（': '）', '）': '（',
+	'＜': '＞', '＞': '＜',
+	'［': '］', '］': '［',
+	'｛': '｝', '｝': '｛',
+	'｟': '｠', '｠': '｟',
+	'｢': '｣', '｣': '｢',
+}
+
+// MirrorChar returns the mirrored glyph of r for display in right-to-left
+// text (UAX #9 rule L4), such as ')' for '(' or '≥' for '≤'. Characters
+// without a mirror glyph are returned unchanged.
+//
+// Reorder, ReorderRuns and the other reordering methods already mirror
+// characters at odd (RTL) embedding levels.
+//
+// Example:
+//
+//	text.MirrorChar('(') // ')'
+//	text.MirrorChar('«') // '»'
+//	text.MirrorChar('a') // 'a'
+func MirrorChar(r rune) rune {
+	if mirrored, ok := mirrorGlyphs[r]; ok {
+		return mirrored
+	}
+	return r
+}
+
+// reorderMirrored reorders text for display like uax9.Reorder, and also
+// mirrors the characters that end up at odd (RTL) embedding levels.
+func reorderMirrored(text string, dir uax9.Direction) string {
+	if text == "" {
+		return text
+	}
+
+	runes := []rune(text)
+	classes := make([]uax9.BidiClass, len(runes))
+	for i, r := range runes {
+		classes[i] = uax9.GetBidiClass(r)
+	}
+
+	paraLevel := 0
+	if dir == uax9.DirectionRTL || (dir == uax9.DirectionAuto && firstStrongDirection(text) == uax9.DirectionRTL) {
+		paraLevel = 1
+	}
+
+	// Drop removed characters (explicit embedding controls) before
+	// reordering; runs on either side of them join up just the same.
+	kept := runes[:0:0]
+	var levels []int
+	for i, level := range uax9.ComputeLevels(classes, paraLevel) {
+		if level >= 0 {
+			kept = append(kept, runes[i])
+			levels = append(levels, level)
+		}
+	}
+
+	result := make([]rune, 0, len(kept))
+	for _, i := range visualOrder(levels, paraLevel) {
+		r := kept[i]
+		if levels[i]%2 == 1 {
+			r = MirrorChar(r)
+		}
+		result = append(result, r)
+	}
+	return string(result)
 }
 
 // MirrorBrackets mirrors brackets for RTL display.
@@ -172,9 +582,7 @@ var mirrorBrackets = map[rune]rune{
 func (t *Text) MirrorBrackets(text string) string {
 	runes := []rune(text)
 	for i, r := range runes {
-		if mirrored, ok := mirrorBrackets[r]; ok {
-			runes[i] = mirrored
-		}
+		runes[i] = MirrorChar(r)
 	}
 	return string(runes)
 }
@@ -2,6 +2,8 @@ package text
 
 import (
 	"testing"
+
+	"github.com/SCKelemen/unicode/v6/uax9"
 )
 
 // ═══════════════════════════════════════════════════════════════
@@ -106,6 +108,118 @@ func TestReorderParagraph_Empty(t *testing.T) {
 	}
 }
 
+// ═══════════════════════════════════════════════════════════════
+//  ParagraphDirections Tests
+// ═══════════════════════════════════════════════════════════════
+
+func TestParagraphDirections(t *testing.T) {
+	txt := NewTerminal()
+
+	type para struct {
+		text string
+		dir  uax9.Direction
+	}
+	tests := []struct {
+		name  string
+		input string
+		opts  ParagraphOptions
+		want  []para
+	}{
+		{
+			name:  "Paragraph separator starts a new paragraph",
+			input: "Hello\u2029שלום",
+			want: []para{
+				{"Hello\u2029", uax9.DirectionLTR},
+				{"שלום", uax9.DirectionRTL},
+			},
+		},
+		{
+			name:  "Line separator stays in the paragraph",
+			input: "שלום\u2028Hello",
+			want: []para{
+				{"שלום\u2028Hello", uax9.DirectionRTL},
+			},
+		},
+		{
+			name:  "Newlines ignored by default",
+			input: "Hello\nשלום",
+			want: []para{
+				{"Hello\nשלום", uax9.DirectionLTR},
+			},
+		},
+		{
+			name:  "Newlines split when configured",
+			input: "123\r\nمرحبا\nHi",
+			opts:  ParagraphOptions{SplitNewlines: true},
+			want: []para{
+				{"123\r\n", uax9.DirectionLTR},
+				{"مرحبا\n", uax9.DirectionRTL},
+				{"Hi", uax9.DirectionLTR},
+			},
+		},
+		{
+			name:  "Isolates are skipped",
+			input: "\u2067Hello\u2069 שלום",
+			want: []para{
+				{"\u2067Hello\u2069 שלום", uax9.DirectionRTL},
+			},
+		},
+		{
+			name:  "Trailing separator",
+			input: "שלום\u2029",
+			want: []para{
+				{"שלום\u2029", uax9.DirectionRTL},
+			},
+		},
+		{
+			name:  "Empty",
+			input: "",
+			want:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := txt.ParagraphDirections(tt.input, tt.opts)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d paragraphs %+v, want %d", len(got), got, len(tt.want))
+			}
+
+			runes := []rune(tt.input)
+			for i, p := range got {
+				if p.Text != tt.want[i].text || p.Direction != tt.want[i].dir {
+					t.Errorf("paragraph %d = %q %v, want %q %v", i, p.Text, p.Direction, tt.want[i].text, tt.want[i].dir)
+				}
+				if string(runes[p.Start:p.End]) != p.Text {
+					t.Errorf("paragraph %d range [%d:%d] = %q, want %q", i, p.Start, p.End, string(runes[p.Start:p.End]), p.Text)
+				}
+			}
+		})
+	}
+}
+
+func TestDetectDirection_Separators(t *testing.T) {
+	txt := NewTerminal()
+
+	tests := []struct {
+		name  string
+		input string
+		want  uax9.Direction
+	}{
+		{"Line separator", "\u2028שלום", uax9.DirectionRTL},
+		{"Paragraph separator", "123\u2029שלום", uax9.DirectionLTR},
+		{"Strong before separator", "שלום\u2029Hello", uax9.DirectionRTL},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := txt.DetectDirection(tt.input); got != tt.want {
+				t.Errorf("DetectDirection(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
 // ═══════════════════════════════════════════════════════════════
 //  ReorderLine Tests
 // ═══════════════════════════════════════════════════════════════
@@ -149,6 +263,66 @@ func TestReorderLine(t *testing.T) {
 	}
 }
 
+// ═══════════════════════════════════════════════════════════════
+//  ReorderRuns Tests
+// ═══════════════════════════════════════════════════════════════
+
+func TestReorderRuns(t *testing.T) {
+	tests := []struct {
+		name string
+		base uax9.Direction
+		text string
+		want []Run
+	}{
+		{
+			name: "Mixed LTR, Arabic and digits",
+			base: uax9.DirectionLTR,
+			text: "abc مرحبا 123",
+			want: []Run{
+				{Text: "abc ", Level: 0, Start: 0, End: 4},
+				{Text: "123", Level: 2, Start: 10, End: 13},
+				{Text: "مرحبا ", Level: 1, IsRTL: true, Start: 4, End: 10},
+			},
+		},
+		{
+			name: "RTL paragraph with Latin",
+			base: uax9.DirectionRTL,
+			text: "שלום abc",
+			want: []Run{
+				{Text: "abc", Level: 2, Start: 5, End: 8},
+				{Text: "שלום ", Level: 1, IsRTL: true, Start: 0, End: 5},
+			},
+		},
+		{
+			name: "Plain LTR",
+			base: uax9.DirectionLTR,
+			text: "Hello",
+			want: []Run{{Text: "Hello", Level: 0, Start: 0, End: 5}},
+		},
+		{
+			name: "Empty",
+			base: uax9.DirectionLTR,
+			text: "",
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			txt := New(Config{BaseDirection: tt.base})
+			got := txt.ReorderRuns(tt.text)
+			if len(got) != len(tt.want) {
+				t.Fatalf("ReorderRuns(%q) = %+v, want %+v", tt.text, got, tt.want)
+			}
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Errorf("run %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
 // ═══════════════════════════════════════════════════════════════
 //  MirrorBrackets Tests
 // ═══════════════════════════════════════════════════════════════
@@ -209,6 +383,70 @@ func TestMirrorBrackets(t *testing.T) {
 	}
 }
 
+func TestMirrorChar(t *testing.T) {
+	tests := []struct {
+		r    rune
+		want rune
+	}{
+		{'(', ')'},
+		{')', '('},
+		{'[', ']'},
+		{'<', '>'},
+		{'«', '»'},
+		{'≤', '≥'},
+		{'⊂', '⊃'},
+		{'「', '」'},
+		{'（', '）'},
+		{'a', 'a'},
+		{'∑', '∑'}, // Bidi_Mirrored, but has no mirror glyph
+		{'م', 'م'},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.r), func(t *testing.T) {
+			if got := MirrorChar(tt.r); got != tt.want {
+				t.Errorf("MirrorChar(%q) = %q, want %q", tt.r, got, tt.want)
+			}
+		})
+	}
+
+	// Every mirror glyph maps back to its source.
+	for r, m := range mirrorGlyphs {
+		if MirrorChar(m) != r {
+			t.Errorf("MirrorChar(MirrorChar(%q)) = %q", r, MirrorChar(m))
+		}
+	}
+}
+
+func TestReorder_MirrorsRTLBrackets(t *testing.T) {
+	tests := []struct {
+		name string
+		base uax9.Direction
+		text string
+		want string
+	}{
+		{"Latin in parentheses in Arabic", uax9.DirectionRTL, "مرحبا (abc)", "(abc) ابحرم"},
+		{"Arabic in parentheses", uax9.DirectionAuto, "مرحبا (سلام)", "(مالس) ابحرم"},
+		{"LTR parentheses untouched", uax9.DirectionLTR, "abc (def)", "abc (def)"},
+		{"Comparison in Hebrew", uax9.DirectionRTL, "א < ב", "ב > א"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			txt := New(Config{BaseDirection: tt.base})
+			if got := txt.Reorder(tt.text); got != tt.want {
+				t.Errorf("Reorder(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+
+	// ReorderRuns mirrors within RTL runs but keeps logical order.
+	runs := New(Config{BaseDirection: uax9.DirectionRTL}).ReorderRuns("מה (שלום)")
+	if len(runs) != 1 || runs[0].Text != "מה )שלום(" {
+		t.Errorf("ReorderRuns = %+v, want one RTL run %q", runs, "מה )שלום(")
+	}
+}
+
 // ═══════════════════════════════════════════════════════════════
 //  GetBidiClass Tests
 // ═══════════════════════════════════════════════════════════════
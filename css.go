@@ -1,12 +1,16 @@
 package text
 
 import (
+	"sort"
 	"strings"
 	"unicode"
+	"unicode/utf8"
 
 	"github.com/SCKelemen/unicode/v6/uax14"
 	"github.com/SCKelemen/unicode/v6/uax29"
 	"github.com/SCKelemen/units"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
 )
 
 // CSS Text Module Level 3/4 Implementation
@@ -48,7 +52,9 @@ const (
 	// WhiteSpacePreLine collapses white space sequences but preserves newlines.
 	WhiteSpacePreLine
 
-	// WhiteSpaceBreakSpaces preserves white space and allows breaking at spaces.
+	// WhiteSpaceBreakSpaces preserves white space like pre-wrap, but every
+	// preserved space takes up room and may be followed by a break, so runs
+	// of spaces (even at the end of a line) wrap instead of overflowing.
 	WhiteSpaceBreakSpaces
 )
 
@@ -76,12 +82,18 @@ const (
 	// TextTransformCapitalize capitalizes the first character of each word.
 	TextTransformCapitalize
 
-	// TextTransformFullWidth converts characters to their fullwidth forms.
-	// Used in East Asian typography.
+	// TextTransformFullWidth converts characters to their fullwidth forms,
+	// including halfwidth katakana. Used in East Asian typography.
 	TextTransformFullWidth
 
 	// TextTransformFullSizeKana converts small kana to full-size equivalents.
 	TextTransformFullSizeKana
+
+	// TextTransformHalfWidth converts fullwidth ASCII forms back to ASCII,
+	// and halfwidth katakana to fullwidth katakana, composing voiced sound
+	// marks ("ﾊﾟﾝ" becomes "パン"). This is the usual width normalization
+	// for East Asian text. Not a CSS value.
+	TextTransformHalfWidth
 )
 
 // ═══════════════════════════════════════════════════════════════
@@ -337,36 +349,65 @@ func (t *Text) ProcessWhiteSpace(text string, whiteSpace WhiteSpace) (processed
 	}
 }
 
-// collapseWhiteSpace collapses sequences of white space into single spaces.
-// If collapseNewlines is true, newlines are treated as spaces.
+// collapseWhiteSpace collapses sequences of white space into single spaces
+// and removes white space at the start and end of each line.
+//
+// If collapseNewlines is true, newlines are treated as spaces, so only the
+// ends of the whole text are trimmed. Otherwise (pre-line) each newline is
+// kept, "\r\n" and "\r" become "\n", and spaces on either side of a
+// newline are removed.
+//
+// No-break spaces are content, not white space to collapse, so they are
+// kept. A zero width space is not white space either: spaces on each side
+// of one are not adjacent and do not collapse together.
 func (t *Text) collapseWhiteSpace(text string, collapseNewlines bool) string {
 	var result strings.Builder
 	result.Grow(len(text))
 
-	inSpace := false
-	for _, r := range text {
-		isSpace := unicode.IsSpace(r)
+	// A run of spaces is written only once something follows it on the
+	// same line, which drops spaces at line starts and ends.
+	pendingSpace := false
+	lineStart := true
+	for i, r := range text {
 		isNewline := r == '\n' || r == '\r'
 
 		if isNewline && !collapseNewlines {
-			result.WriteRune('\n')
-			inSpace = false
+			if r == '\r' && i+1 < len(text) && text[i+1] == '\n' {
+				continue
+			}
+			result.WriteByte('\n')
+			pendingSpace = false
+			lineStart = true
 			continue
 		}
 
-		if isSpace {
-			if !inSpace {
-				result.WriteRune(' ')
-				inSpace = true
-			}
+		if isCollapsibleSpace(r) {
+			pendingSpace = true
 			continue
 		}
 
+		if pendingSpace && !lineStart {
+			result.WriteByte(' ')
+		}
 		result.WriteRune(r)
-		inSpace = false
+		pendingSpace = false
+		lineStart = false
 	}
 
-	return strings.TrimSpace(result.String())
+	return result.String()
+}
+
+// isCollapsibleSpace reports whether r is white space that white-space
+// processing may collapse or trim. NO-BREAK SPACE (U+00A0), FIGURE SPACE
+// (U+2007) and NARROW NO-BREAK SPACE (U+202F) are not: they hold their
+// neighbours together, as in "10\u00A0km", and must survive collapsing
+// so line breaking still sees them.
+func isCollapsibleSpace(r rune) bool {
+	switch r {
+	case 0x00A0, 0x2007, 0x202F:
+		return false
+	}
+	return unicode.IsSpace(r)
 }
 
 // ═══════════════════════════════════════════════════════════════
@@ -380,7 +421,7 @@ func (t *Text) Transform(text string, transform TextTransform) string {
 		return text
 
 	case TextTransformUppercase:
-		return strings.ToUpper(text)
+		return toUpperFull(text)
 
 	case TextTransformLowercase:
 		return strings.ToLower(text)
@@ -394,48 +435,189 @@ func (t *Text) Transform(text string, transform TextTransform) string {
 	case TextTransformFullSizeKana:
 		return t.toFullSizeKana(text)
 
+	case TextTransformHalfWidth:
+		return t.toHalfWidth(text)
+
 	default:
 		return text
 	}
 }
 
+// TransformLocale applies a text transformation using the case rules of a
+// language, given as a BCP 47 tag such as "tr", "az" or "de-CH".
+//
+// Uppercase, lowercase and capitalize use language-specific case mapping
+// (Unicode SpecialCasing), so Turkish and Azeri map "i" to "İ" and "I" to
+// "ı", and Lithuanian keeps the dot on accented "i". An empty or unparseable
+// tag falls back to the root locale. Other transformations behave exactly
+// like Transform.
+//
+// Example:
+//
+//	txt := text.NewTerminal()
+//	txt.TransformLocale("istanbul", text.TextTransformUppercase, "tr")
+//	// "İSTANBUL"
+//	txt.TransformLocale("straße", text.TextTransformUppercase, "de")
+//	// "STRASSE"
+func (t *Text) TransformLocale(text string, transform TextTransform, lang string) string {
+	tag, err := language.Parse(lang)
+	if err != nil {
+		tag = language.Und
+	}
+
+	switch transform {
+	case TextTransformUppercase:
+		return cases.Upper(tag).String(text)
+
+	case TextTransformLowercase:
+		return cases.Lower(tag).String(text)
+
+	case TextTransformCapitalize:
+		title := cases.Title(tag, cases.NoLower)
+		return capitalizeWith(text, func(r rune) string {
+			return title.String(string(r))
+		})
+
+	default:
+		return t.Transform(text, transform)
+	}
+}
+
+// TransformAndWrap applies a text transformation and wraps the result.
+//
+// Case mappings can change length and therefore width: German "ß" uppercases
+// to "SS", so a word can grow enough to wrap differently than its original
+// form. Wrapping happens after the transform, so line widths always reflect
+// the transformed text. Line Start/End are rune indices into the transformed
+// text, not the original.
+//
+// Example:
+//
+//	txt := text.NewTerminal()
+//	lines := txt.TransformAndWrap("die straße", text.TextTransformUppercase,
+//	    text.WrapOptions{MaxWidth: 10})
+//	// "DIE " / "STRASSE" ("straße" is 6 cells, "STRASSE" is 7)
+func (t *Text) TransformAndWrap(text string, transform TextTransform, opts WrapOptions) []Line {
+	return t.Wrap(t.Transform(text, transform), opts)
+}
+
+// specialUpper holds the unconditional one-to-many uppercase mappings from
+// Unicode SpecialCasing.txt that strings.ToUpper does not apply.
+var specialUpper = map[rune]string{
+	'ß':      "SS",
+	'ŉ':      "ʼN",
+	'\uFB00': "FF",
+	'\uFB01': "FI",
+	'\uFB02': "FL",
+	'\uFB03': "FFI",
+	'\uFB04': "FFL",
+	'\uFB05': "ST",
+	'\uFB06': "ST",
+}
+
+// toUpperFull uppercases text using full case mapping, so characters
+// such as "ß" expand to "SS" as CSS text-transform: uppercase requires.
+func toUpperFull(text string) string {
+	var result strings.Builder
+	result.Grow(len(text))
+
+	for _, r := range text {
+		if upper, ok := specialUpper[r]; ok {
+			result.WriteString(upper)
+		} else {
+			result.WriteRune(unicode.ToUpper(r))
+		}
+	}
+
+	return result.String()
+}
+
 // capitalize capitalizes the first letter of each word.
+//
+// A word is a run of non-space characters, so letters after an internal
+// apostrophe or hyphen stay as they are: "o'brien" → "O'brien", "don't" →
+// "Don't", "e-mail" → "E-mail". Leading punctuation and invisible format
+// characters such as a soft hyphen (U+00AD) are skipped to find the first
+// letter ("(hello)" → "(Hello)"), but a word starting with a digit is left
+// alone ("1st" stays "1st"). The letter is mapped to titlecase, as CSS
+// specifies.
 func (t *Text) capitalize(text string) string {
-	// Use UAX #29 word boundaries for proper capitalization
-	words := uax29.Words(text)
+	return capitalizeWith(text, func(r rune) string {
+		return string(unicode.ToTitle(r))
+	})
+}
+
+// capitalizeWith implements capitalize, mapping the first letter of each
+// word with title.
+func capitalizeWith(text string, title func(rune) string) string {
 	var result strings.Builder
 	result.Grow(len(text))
 
-	for _, word := range words {
-		if len(word) == 0 {
+	atWordStart := true
+	for _, r := range text {
+		switch {
+		case unicode.IsSpace(r):
+			atWordStart = true
+		case atWordStart && unicode.IsLetter(r):
+			result.WriteString(title(r))
+			atWordStart = false
 			continue
+		case unicode.IsNumber(r):
+			atWordStart = false
 		}
-
-		// Check if this is a word (not punctuation or whitespace)
-		firstRune := []rune(word)[0]
-		if unicode.IsLetter(firstRune) {
-			runes := []rune(word)
-			runes[0] = unicode.ToUpper(runes[0])
-			result.WriteString(string(runes))
-		} else {
-			result.WriteString(word)
-		}
+		result.WriteRune(r)
 	}
 
 	return result.String()
 }
 
-// toFullWidth converts ASCII characters to their fullwidth forms.
+// toFullWidth converts ASCII characters to their fullwidth forms, and
+// halfwidth katakana to fullwidth katakana.
 func (t *Text) toFullWidth(text string) string {
 	var result strings.Builder
 	result.Grow(len(text) * 2) // Fullwidth characters are larger in bytes
 
-	for _, r := range text {
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		i += size
+
 		// ASCII range: U+0021-U+007E -> Fullwidth: U+FF01-U+FF5E
 		if r >= 0x21 && r <= 0x7E {
 			result.WriteRune(r - 0x21 + 0xFF01)
 		} else if r == 0x20 { // Space -> Fullwidth space
 			result.WriteRune(0x3000)
+		} else if _, ok := halfwidthKatakana[r]; ok {
+			kana, n := widenKatakana(text[i-size:])
+			result.WriteRune(kana)
+			i += n - size
+		} else {
+			result.WriteRune(r)
+		}
+	}
+
+	return result.String()
+}
+
+// toHalfWidth converts fullwidth ASCII forms back to ASCII, and halfwidth
+// katakana to fullwidth katakana: the usual width normalization for East
+// Asian text, where Latin is narrow and kana is wide.
+func (t *Text) toHalfWidth(text string) string {
+	var result strings.Builder
+	result.Grow(len(text))
+
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		i += size
+
+		// Fullwidth: U+FF01-U+FF5E -> ASCII range: U+0021-U+007E
+		if r >= 0xFF01 && r <= 0xFF5E {
+			result.WriteRune(r - 0xFF01 + 0x21)
+		} else if r == 0x3000 { // Fullwidth space -> Space
+			result.WriteByte(' ')
+		} else if _, ok := halfwidthKatakana[r]; ok {
+			kana, n := widenKatakana(text[i-size:])
+			result.WriteRune(kana)
+			i += n - size
 		} else {
 			result.WriteRune(r)
 		}
@@ -444,6 +626,56 @@ func (t *Text) toFullWidth(text string) string {
 	return result.String()
 }
 
+// widenKatakana converts the halfwidth katakana at the start of s to
+// fullwidth, composing a following halfwidth voiced (U+FF9E) or semi-voiced
+// (U+FF9F) sound mark into the precomposed kana where one exists: "ﾊﾟ"
+// becomes "パ". It returns the kana and the number of bytes consumed.
+func widenKatakana(s string) (rune, int) {
+	r, size := utf8.DecodeRuneInString(s)
+	kana := halfwidthKatakana[r]
+
+	mark, markSize := utf8.DecodeRuneInString(s[size:])
+	voiced, ok := dakutenKatakana[kana]
+	if mark == 0xFF9F {
+		voiced, ok = handakutenKatakana[kana]
+	} else if mark != 0xFF9E {
+		ok = false
+	}
+	if ok {
+		return voiced, size + markSize
+	}
+	return kana, size
+}
+
+// halfwidthKatakana maps halfwidth katakana and punctuation (U+FF61-U+FF9F)
+// to their fullwidth forms. The sound marks map to their spacing forms.
+var halfwidthKatakana = map[rune]rune{
+	'｡': '。', '｢': '「', '｣': '」', '､': '、', '･': '・', 'ｦ': 'ヲ',
+	'ｧ': 'ァ', 'ｨ': 'ィ', 'ｩ': 'ゥ', 'ｪ': 'ェ', 'ｫ': 'ォ', 'ｬ': 'ャ',
+	'ｭ': 'ュ', 'ｮ': 'ョ', 'ｯ': 'ッ', 'ｰ': 'ー', 'ｱ': 'ア', 'ｲ': 'イ',
+	'ｳ': 'ウ', 'ｴ': 'エ', 'ｵ': 'オ', 'ｶ': 'カ', 'ｷ': 'キ', 'ｸ': 'ク',
+	'ｹ': 'ケ', 'ｺ': 'コ', 'ｻ': 'サ', 'ｼ': 'シ', 'ｽ': 'ス', 'ｾ': 'セ',
+	'ｿ': 'ソ', 'ﾀ': 'タ', 'ﾁ': 'チ', 'ﾂ': 'ツ', 'ﾃ': 'テ', 'ﾄ': 'ト',
+	'ﾅ': 'ナ', 'ﾆ': 'ニ', 'ﾇ': 'ヌ', 'ﾈ': 'ネ', 'ﾉ': 'ノ', 'ﾊ': 'ハ',
+	'ﾋ': 'ヒ', 'ﾌ': 'フ', 'ﾍ': 'ヘ', 'ﾎ': 'ホ', 'ﾏ': 'マ', 'ﾐ': 'ミ',
+	'ﾑ': 'ム', 'ﾒ': 'メ', 'ﾓ': 'モ', 'ﾔ': 'ヤ', 'ﾕ': 'ユ', 'ﾖ': 'ヨ',
+	'ﾗ': 'ラ', 'ﾘ': 'リ', 'ﾙ': 'ル', 'ﾚ': 'レ', 'ﾛ': 'ロ', 'ﾜ': 'ワ',
+	'ﾝ': 'ン', 'ﾞ': '\u309B', 'ﾟ': '\u309C',
+}
+
+// dakutenKatakana maps fullwidth katakana to their voiced forms.
+var dakutenKatakana = map[rune]rune{
+	'ヲ': 'ヺ', 'ウ': 'ヴ', 'カ': 'ガ', 'キ': 'ギ', 'ク': 'グ', 'ケ': 'ゲ',
+	'コ': 'ゴ', 'サ': 'ザ', 'シ': 'ジ', 'ス': 'ズ', 'セ': 'ゼ', 'ソ': 'ゾ',
+	'タ': 'ダ', 'チ': 'ヂ', 'ツ': 'ヅ', 'テ': 'デ', 'ト': 'ド', 'ハ': 'バ',
+	'ヒ': 'ビ', 'フ': 'ブ', 'ヘ': 'ベ', 'ホ': 'ボ', 'ワ': 'ヷ',
+}
+
+// handakutenKatakana maps fullwidth katakana to their semi-voiced forms.
+var handakutenKatakana = map[rune]rune{
+	'ハ': 'パ', 'ヒ': 'ピ', 'フ': 'プ', 'ヘ': 'ペ', 'ホ': 'ポ',
+}
+
 // toFullSizeKana converts small kana to full-size equivalents.
 func (t *Text) toFullSizeKana(text string) string {
 	// Map of small kana to full-size kana
@@ -480,21 +712,76 @@ func (t *Text) Words(text string) []string {
 }
 
 // WordCount returns the number of words in the text.
+//
+// A word is a UAX #29 word segment starting with a letter or number, so
+// "state-of-the-art" counts as four words. Use WordCountWith to join
+// hyphenated or apostrophe-separated words.
 func (t *Text) WordCount(text string) int {
-	words := uax29.Words(text)
+	return t.WordCountWith(text, WordCountOptions{})
+}
+
+// WordCountOptions configures what WordCountWith treats as one word.
+type WordCountOptions struct {
+	// JoinHyphens counts words joined by a hyphen (U+002D, U+2010 or U+2011)
+	// as one word: "state-of-the-art" is 1 word instead of 4.
+	JoinHyphens bool
+
+	// JoinApostrophes counts words joined by an apostrophe (U+0027 or
+	// U+2019) as one word. UAX #29 already keeps "don't" together between
+	// letters; this also joins cases it splits, such as "90's".
+	JoinApostrophes bool
+}
+
+// WordCountWith returns the number of words in the text using opts.
+//
+// A hyphen or apostrophe only joins when it sits directly between two
+// words; a leading, trailing or spaced hyphen ("well - known") still
+// separates them.
+//
+// Example:
+//
+//	txt := text.NewTerminal()
+//	txt.WordCountWith("a state-of-the-art tool", text.WordCountOptions{JoinHyphens: true})
+//	// 3
+func (t *Text) WordCountWith(text string, opts WordCountOptions) int {
+	segments := uax29.Words(text)
 	count := 0
-	for _, word := range words {
-		// Only count actual words (not whitespace or punctuation)
-		if len(word) > 0 {
-			firstRune := []rune(word)[0]
-			if unicode.IsLetter(firstRune) || unicode.IsNumber(firstRune) {
-				count++
-			}
+	for i, segment := range segments {
+		if !isWordSegment(segment) {
+			continue
 		}
+		// A word joined to the word two segments back continues it.
+		if i >= 2 && isWordSegment(segments[i-2]) && joinsWords(segments[i-1], opts) {
+			continue
+		}
+		count++
 	}
 	return count
 }
 
+// isWordSegment reports whether a UAX #29 segment is a word, meaning it
+// starts with a letter or number rather than whitespace or punctuation.
+func isWordSegment(segment string) bool {
+	r, _ := utf8.DecodeRuneInString(segment)
+	return unicode.IsLetter(r) || unicode.IsNumber(r)
+}
+
+// joinsWords reports whether segment is a single joiner that opts allows
+// between two words.
+func joinsWords(segment string, opts WordCountOptions) bool {
+	r, size := utf8.DecodeRuneInString(segment)
+	if size != len(segment) {
+		return false
+	}
+	switch r {
+	case '-', '\u2010', '\u2011':
+		return opts.JoinHyphens
+	case '\'', '\u2019':
+		return opts.JoinApostrophes
+	}
+	return false
+}
+
 // ═══════════════════════════════════════════════════════════════
 //  Sentence Boundary Support
 // ═══════════════════════════════════════════════════════════════
@@ -509,6 +796,60 @@ func (t *Text) SentenceCount(text string) int {
 	return len(uax29.Sentences(text))
 }
 
+// ═══════════════════════════════════════════════════════════════
+//  Length Resolution
+// ═══════════════════════════════════════════════════════════════
+
+// ResolveLength converts a CSS length to MeasureFunc units.
+//
+// Font-relative lengths resolve against the configuration: em and rem
+// against Config.FontSize, ex against Config.XHeight, cap against the font
+// metrics' cap height, ch against the width of "0" and ic against the width
+// of "水". A font-relative length that cannot be resolved (no FontSize for
+// em), and any other length, is taken as plain units, so units.Px(2) and
+// units.Ch(40) on a terminal are 2 and 40 cells.
+//
+// Example:
+//
+//	txt := text.New(text.Config{MeasureFunc: measure, FontSize: 16})
+//	txt.ResolveLength(units.Em(0.1)) // 1.6
+func (t *Text) ResolveLength(l units.Length) float64 {
+	if !l.IsFontRelative() {
+		return l.Raw()
+	}
+
+	resolved, err := l.Resolve(t.lengthContext())
+	if err != nil {
+		return l.Raw()
+	}
+	return resolved.Value
+}
+
+// lengthContext returns the context for resolving font-relative lengths.
+func (t *Text) lengthContext() *units.Context {
+	fontSize := t.config.FontSize
+	ctx := &units.Context{
+		FontSize:     fontSize,
+		RootFontSize: fontSize,
+		XHeight:      t.config.XHeight,
+		ChWidth:      t.Width("0"),
+		IcWidth:      t.Width("水"),
+	}
+
+	if fm := t.fontMetrics; fm != nil && fm.UnitsPerEm() > 0 && fontSize > 0 {
+		scale := fontSize / fm.UnitsPerEm()
+		ctx.CapHeight = fm.CapHeight() * scale
+		if ctx.XHeight == 0 {
+			ctx.XHeight = fm.XHeight() * scale
+		}
+	}
+	if ctx.XHeight == 0 {
+		ctx.XHeight = fontSize / 2
+	}
+
+	return ctx
+}
+
 // ═══════════════════════════════════════════════════════════════
 //  Advanced Wrapping with CSS Text Properties
 // ═══════════════════════════════════════════════════════════════
@@ -517,23 +858,78 @@ func (t *Text) SentenceCount(text string) int {
 type CSSWrapOptions struct {
 	MaxWidth units.Length
 	Style    CSSTextStyle
+
+	// Hyphenator supplies hyphenation points when Style.Hyphens is
+	// HyphensAuto. Words may then break inside at those points, and a
+	// hyphen is appended to lines that end there. When nil (and no
+	// HyphenationRegistry is set), hyphens: auto behaves like hyphens: manual.
+	Hyphenator *HyphenationDictionary
+
+	// HyphenationRegistry selects a dictionary per word by language, so
+	// mixed-language text is hyphenated with each language's patterns and
+	// minLeft/minRight. Words whose language has no entry use Hyphenator.
+	HyphenationRegistry *HyphenationRegistry
+
+	// Lang is the BCP 47 language tag of the text (like the HTML lang
+	// attribute), used to look up HyphenationRegistry.
+	Lang string
+
+	// LanguageOf optionally returns the language tag of a single word,
+	// for text that mixes languages. An empty result falls back to Lang.
+	//
+	// WrapCSS does not detect languages itself, not even from a word's
+	// script: without LanguageOf every word uses Lang. Script-based
+	// selection can be supplied here, for example:
+	//
+	//	LanguageOf: func(word string) string {
+	//	    r, _ := utf8.DecodeRuneInString(word)
+	//	    if uax24.IsCyrillic(r) {
+	//	        return "ru"
+	//	    }
+	//	    return ""
+	//	}
+	LanguageOf func(word string) string
+}
+
+// hyphenatorFor returns the dictionary used to hyphenate word, or nil.
+func (opts CSSWrapOptions) hyphenatorFor(word string) *HyphenationDictionary {
+	if opts.HyphenationRegistry != nil {
+		lang := opts.Lang
+		if opts.LanguageOf != nil {
+			if l := opts.LanguageOf(word); l != "" {
+				lang = l
+			}
+		}
+		if dict, ok := opts.HyphenationRegistry.Lookup(lang); ok {
+			return dict
+		}
+	}
+	return opts.Hyphenator
 }
 
 // WrapCSS wraps text according to CSS text properties.
 // This is a more sophisticated version of Wrap that handles white-space,
 // word-break, line-break, and other CSS properties.
+//
+// With white-space values that preserve newlines (pre, pre-wrap, pre-line
+// and break-spaces), each newline forces a break: the line before it ends
+// with BreakHard and the newline itself is not part of any line.
 func (t *Text) WrapCSS(text string, opts CSSWrapOptions) []Line {
 	// Process white space first
 	processed, allowWrap := t.ProcessWhiteSpace(text, opts.Style.WhiteSpace)
+	preserveNewlines := opts.Style.WhiteSpace != WhiteSpaceNormal &&
+		opts.Style.WhiteSpace != WhiteSpaceNoWrap
 
 	if !allowWrap {
-		// No wrapping allowed
-		return []Line{{
-			Content: processed,
-			Width:   t.Width(processed),
-			Start:   0,
-			End:     len([]rune(processed)),
-		}}
+		// No wrapping allowed: one line per preserved newline
+		return wrapParagraphs(processed, preserveNewlines, func(part string, baseRuneOffset int) []Line {
+			return []Line{{
+				Content: part,
+				Width:   t.cssLineWidth(part, opts.Style),
+				Start:   baseRuneOffset,
+				End:     baseRuneOffset + len([]rune(part)),
+			}}
+		})
 	}
 
 	// Apply text transformation
@@ -555,15 +951,337 @@ func (t *Text) WrapCSS(text string, opts CSSWrapOptions) []Line {
 		hyphenMode = uax14.HyphensAuto
 	}
 
-	// Find line break opportunities using UAX #14
-	breakPoints := uax14.FindLineBreakOpportunities(processed, hyphenMode)
+	return wrapParagraphs(processed, preserveNewlines, func(part string, baseRuneOffset int) []Line {
+		if part == "" {
+			return nil
+		}
+
+		// Find line break opportunities using UAX #14
+		breakPoints := uax14.FindLineBreakOpportunities(part, hyphenMode)
+		breakPoints = t.applyLineBreak(part, breakPoints, opts.Style.LineBreak)
+		if opts.Style.LineBreak != LineBreakAnywhere {
+			breakPoints = t.applyWordBreak(part, breakPoints, opts.Style.WordBreak)
+		}
+
+		// break-spaces allows a break after every preserved space
+		if opts.Style.WhiteSpace == WhiteSpaceBreakSpaces {
+			breakPoints = addSpaceBreaks(part, breakPoints)
+		}
 
-	// Build lines using break opportunities
-	return t.buildLinesFromBreakPoints(processed, breakPoints, opts)
+		// Add hyphenation opportunities inside words
+		var hyphenBreaks map[int]bool
+		if opts.Style.Hyphens == HyphensAuto && (opts.Hyphenator != nil || opts.HyphenationRegistry != nil) {
+			breakPoints, hyphenBreaks = addHyphenationBreaks(part, breakPoints, func(word string) []int {
+				if dict := opts.hyphenatorFor(word); dict != nil {
+					return dict.Hyphenate(word)
+				}
+				return nil
+			})
+		}
+
+		// Build lines using break opportunities
+		lines := t.buildLinesFromBreakPoints(part, breakPoints, hyphenBreaks, opts)
+		for i := range lines {
+			lines[i].Start += baseRuneOffset
+			lines[i].End += baseRuneOffset
+		}
+		return lines
+	})
+}
+
+// WrapAccessible wraps text with extra letter and word spacing, as used by
+// legibility modes such as dyslexia-friendly rendering.
+//
+// The spacing is included when deciding whether a line fits, so spaced text
+// breaks earlier than unspaced text, and each Line.Width includes it. White
+// space is collapsed as in CSS white-space: normal, and words that cannot
+// fit on a line of their own are broken (overflow-wrap: break-word).
+//
+// Example:
+//
+//	txt := text.NewTerminal()
+//	lines := txt.WrapAccessible("The quick brown fox", 20, 0.5, 1)
+func (t *Text) WrapAccessible(text string, maxWidth float64, letterSpacing, wordSpacing float64) []Line {
+	style := DefaultCSSTextStyle()
+	style.LetterSpacing = units.Px(letterSpacing)
+	style.WordSpacing = units.Px(wordSpacing)
+	style.OverflowWrap = OverflowWrapBreakWord
+
+	return t.WrapCSS(text, CSSWrapOptions{
+		MaxWidth: units.Px(maxWidth),
+		Style:    style,
+	})
+}
+
+// addHyphenationBreaks merges the hyphenation points of every word in text
+// into breakPoints (byte offsets), using hyphenate to find each word's
+// points (rune indices, as returned by Hyphenate). It returns the merged
+// break points and the set of offsets that were added as hyphenation
+// points, where a line break must show a hyphen.
+func addHyphenationBreaks(text string, breakPoints []int, hyphenate func(word string) []int) ([]int, map[int]bool) {
+	seen := make(map[int]bool, len(breakPoints))
+	for _, bp := range breakPoints {
+		seen[bp] = true
+	}
+
+	hyphenBreaks := make(map[int]bool)
+	addWord := func(start, end int) {
+		word := text[start:end]
+		toBytes := runeToByteCursor(word)
+		for _, p := range hyphenate(word) {
+			offset := start + toBytes(p)
+			if offset <= start || offset >= end || seen[offset] {
+				continue
+			}
+			breakPoints = append(breakPoints, offset)
+			seen[offset] = true
+			hyphenBreaks[offset] = true
+		}
+	}
+
+	// Words are maximal runs of letters.
+	wordStart := -1
+	for i, r := range text {
+		if unicode.IsLetter(r) {
+			if wordStart < 0 {
+				wordStart = i
+			}
+			continue
+		}
+		if wordStart >= 0 {
+			addWord(wordStart, i)
+			wordStart = -1
+		}
+	}
+	if wordStart >= 0 {
+		addWord(wordStart, len(text))
+	}
+
+	sort.Ints(breakPoints)
+	return breakPoints, hyphenBreaks
+}
+
+// addSpaceBreaks merges a break point (byte offset) after every preserved
+// space in text into breakPoints, as white-space: break-spaces requires.
+// UAX #14 alone only allows a break after a whole run of spaces. No-break
+// spaces and newlines are not spaces here.
+func addSpaceBreaks(text string, breakPoints []int) []int {
+	seen := make(map[int]bool, len(breakPoints))
+	for _, bp := range breakPoints {
+		seen[bp] = true
+	}
+
+	added := false
+	for i, r := range text {
+		if r == '\n' || r == '\r' || !isCollapsibleSpace(r) {
+			continue
+		}
+		offset := i + utf8.RuneLen(r)
+		if !seen[offset] {
+			breakPoints = append(breakPoints, offset)
+			seen[offset] = true
+			added = true
+		}
+	}
+
+	if added {
+		sort.Ints(breakPoints)
+	}
+	return breakPoints
+}
+
+// applyLineBreak adjusts UAX #14 break points (byte offsets) for the CSS
+// line-break property, following the strictness levels of CSS Text §5.3.
+// UAX #14 alone matches strict for Japanese small kana, so auto leaves the
+// break set unchanged.
+//
+//   - strict forbids breaks before small kana and the prolonged sound mark
+//     (UAX #14 class CJ), and before the CJK hyphens 〜 and ゠.
+//   - normal allows breaks before those characters after CJK text.
+//   - loose additionally allows breaks before iteration marks, centered
+//     punctuation, and postfixes (%, ℃), after prefixes ($, ¥), and
+//     between inseparable characters (…, ‥), all in CJK context.
+//   - anywhere allows a break at every grapheme cluster boundary.
+func (t *Text) applyLineBreak(text string, breakPoints []int, lineBreak LineBreak) []int {
+	if lineBreak == LineBreakAuto || len(text) == 0 {
+		return breakPoints
+	}
+
+	allowed := make(map[int]bool, len(breakPoints))
+	for _, bp := range breakPoints {
+		allowed[bp] = true
+	}
+
+	if lineBreak == LineBreakAnywhere {
+		offset := 0
+		for _, g := range uax29.Graphemes(text) {
+			allowed[offset] = true
+			offset += len(g)
+		}
+	} else {
+		prev, size := utf8.DecodeRuneInString(text)
+		for i := size; i < len(text); i += size {
+			var next rune
+			next, size = utf8.DecodeRuneInString(text[i:])
+
+			cjkBefore := IsIdeographic(prev)
+			switch {
+			case isConditionalJapaneseStarter(next), next == '〜', next == '゠':
+				if lineBreak == LineBreakStrict {
+					delete(allowed, i)
+				} else if cjkBefore {
+					allowed[i] = true
+				}
+
+			case lineBreak != LineBreakLoose:
+				// Remaining relaxations apply to loose only
+
+			case isIterationMark(next), isCenteredPunctuation(next), isPostfixSymbol(next):
+				if cjkBefore {
+					allowed[i] = true
+				}
+
+			case isInseparable(prev) && isInseparable(next):
+				allowed[i] = true
+
+			case isPrefixSymbol(prev) && IsIdeographic(next):
+				allowed[i] = true
+			}
+			prev = next
+		}
+	}
+
+	adjusted := make([]int, 0, len(allowed))
+	for bp := range allowed {
+		adjusted = append(adjusted, bp)
+	}
+	sort.Ints(adjusted)
+	return adjusted
+}
+
+// isConditionalJapaneseStarter reports whether r is a small kana or the
+// prolonged sound mark (UAX #14 line breaking class CJ).
+func isConditionalJapaneseStarter(r rune) bool {
+	switch r {
+	case 'ぁ', 'ぃ', 'ぅ', 'ぇ', 'ぉ', 'っ', 'ゃ', 'ゅ', 'ょ', 'ゎ', 'ゕ', 'ゖ',
+		'ァ', 'ィ', 'ゥ', 'ェ', 'ォ', 'ッ', 'ャ', 'ュ', 'ョ', 'ヮ', 'ヵ', 'ヶ', 'ー':
+		return true
+	}
+	return (r >= 0x31F0 && r <= 0x31FF) || // Katakana Phonetic Extensions
+		(r >= 0xFF67 && r <= 0xFF70) // Halfwidth small katakana and prolonged sound mark
+}
+
+// isIterationMark reports whether r is a CJK or kana iteration mark.
+func isIterationMark(r rune) bool {
+	switch r {
+	case '々', '〻', 'ゝ', 'ゞ', 'ヽ', 'ヾ':
+		return true
+	}
+	return false
+}
+
+// isCenteredPunctuation reports whether r is centered punctuation that
+// line-break: loose lets start a line.
+func isCenteredPunctuation(r rune) bool {
+	switch r {
+	case '・', '：', '；', '･', '‼', '⁇', '⁈', '⁉', '！', '？':
+		return true
+	}
+	return false
+}
+
+// isInseparable reports whether r is an inseparable character (UAX #14
+// class IN) such as the ellipsis.
+func isInseparable(r rune) bool {
+	switch r {
+	case '․', '‥', '…', '⋯', '︙':
+		return true
+	}
+	return false
+}
+
+// isPostfixSymbol reports whether r is a postfix symbol such as % or ℃.
+func isPostfixSymbol(r rune) bool {
+	switch r {
+	case '%', '¢', '°', '‰', '′', '″', '℃', '％', '￠':
+		return true
+	}
+	return false
+}
+
+// isPrefixSymbol reports whether r is a prefix symbol such as $ or ¥.
+func isPrefixSymbol(r rune) bool {
+	switch r {
+	case '$', '£', '¥', '€', '№', '＄', '￡', '￥':
+		return true
+	}
+	return false
+}
+
+// applyWordBreak adjusts UAX #14 break points (byte offsets) for the CSS
+// word-break property.
+//
+//   - break-all adds a break at every grapheme boundary between two letters
+//     or digits, so long words and URL-like tokens can wrap anywhere.
+//   - keep-all removes breaks between two ideographic characters (CJK,
+//     kana, Hangul), so CJK runs only break at spaces and punctuation.
+func (t *Text) applyWordBreak(text string, breakPoints []int, wordBreak WordBreak) []int {
+	switch wordBreak {
+	case WordBreakBreakAll:
+		seen := make(map[int]bool, len(breakPoints))
+		for _, bp := range breakPoints {
+			seen[bp] = true
+		}
+
+		offset := 0
+		prevLast := rune(-1)
+		for _, g := range uax29.Graphemes(text) {
+			first, _ := utf8.DecodeRuneInString(g)
+			if offset > 0 && !seen[offset] && isLetterOrDigit(prevLast) && isLetterOrDigit(first) {
+				breakPoints = append(breakPoints, offset)
+				seen[offset] = true
+			}
+			prevLast, _ = utf8.DecodeLastRuneInString(g)
+			offset += len(g)
+		}
+		sort.Ints(breakPoints)
+		return breakPoints
+
+	case WordBreakKeepAll:
+		kept := breakPoints[:0:0]
+		for _, bp := range breakPoints {
+			if bp > 0 && bp < len(text) {
+				before, _ := utf8.DecodeLastRuneInString(text[:bp])
+				after, _ := utf8.DecodeRuneInString(text[bp:])
+				if IsIdeographic(before) && IsIdeographic(after) {
+					continue
+				}
+			}
+			kept = append(kept, bp)
+		}
+		return kept
+
+	default:
+		return breakPoints
+	}
+}
+
+// isLetterOrDigit reports whether r is a letter or number.
+func isLetterOrDigit(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsNumber(r)
 }
 
 // buildLinesFromBreakPoints creates lines from UAX #14 break points.
-func (t *Text) buildLinesFromBreakPoints(text string, breakPoints []int, opts CSSWrapOptions) []Line {
+//
+// When opts.Style.OverflowWrap is break-word or anywhere, a segment that
+// does not fit even at the start of an empty line is split at grapheme
+// cluster boundaries (an emergency break). Only such overflowing segments
+// are split; other words still move to the next line whole.
+//
+// Break points in hyphenBreaks are hyphenation opportunities: a line that
+// ends at one gets a trailing hyphen, and the hyphen's width counts
+// against MaxWidth.
+func (t *Text) buildLinesFromBreakPoints(text string, breakPoints []int, hyphenBreaks map[int]bool, opts CSSWrapOptions) []Line {
 	if len(breakPoints) == 0 {
 		return []Line{{
 			Content: text,
@@ -574,61 +1292,81 @@ func (t *Text) buildLinesFromBreakPoints(text string, breakPoints []int, opts CS
 	}
 
 	var lines []Line
-	maxWidth := opts.MaxWidth.Raw()
+	maxWidth := t.ResolveLength(opts.MaxWidth)
+	emergencyBreaks := opts.Style.OverflowWrap == OverflowWrapBreakWord ||
+		opts.Style.OverflowWrap == OverflowWrapAnywhere
+
+	hyphen := "-"
+	hyphenWidth := t.Width(hyphen)
 
 	// Accumulate segments until line is full
 	currentLine := ""
 	currentWidth := 0.0
 	lineStartIdx := 0
 
-	for i := 1; i < len(breakPoints); i++ {
-		segment := text[breakPoints[i-1]:breakPoints[i]]
+	// commit ends the current line, appending a hyphen if it breaks at a
+	// hyphenation point.
+	commit := func(hyphenated bool) {
+		currentRuneLen := len([]rune(currentLine))
+		content, width := currentLine, currentWidth
+		if hyphenated {
+			content += hyphen
+			width += hyphenWidth
+		}
+		lines = append(lines, Line{
+			Content:   content,
+			Width:     width,
+			Start:     lineStartIdx,
+			End:       lineStartIdx + currentRuneLen,
+			BreakType: BreakSoft,
+		})
+		lineStartIdx += currentRuneLen
+	}
 
-		// Calculate what the line would be if we add this segment
-		testLine := currentLine + segment
-		testWidth := t.Width(testLine)
+	// startLine begins a new line with segment, splitting it first if it
+	// overflows on its own and emergency breaks are allowed.
+	startLine := func(segment string) {
+		currentLine = segment
+		currentWidth = t.cssLineWidth(segment, opts.Style)
+		if !emergencyBreaks || t.cssLineWidth(strings.TrimRightFunc(segment, unicode.IsSpace), opts.Style) <= maxWidth {
+			return
+		}
 
-		// Apply letter spacing
-		if !opts.Style.LetterSpacing.IsZero() {
-			graphemes := t.Graphemes(testLine)
-			testWidth += float64(len(graphemes)-1) * opts.Style.LetterSpacing.Raw()
+		pieces := t.splitAtGraphemes(segment, maxWidth, opts.Style)
+		for _, piece := range pieces[:len(pieces)-1] {
+			currentLine = piece
+			currentWidth = t.cssLineWidth(piece, opts.Style)
+			commit(false)
 		}
+		currentLine = pieces[len(pieces)-1]
+		currentWidth = t.cssLineWidth(currentLine, opts.Style)
+	}
 
-		// Apply word spacing
-		if !opts.Style.WordSpacing.IsZero() {
-			spaceCount := strings.Count(testLine, " ")
-			testWidth += float64(spaceCount) * opts.Style.WordSpacing.Raw()
+	for i := 1; i < len(breakPoints); i++ {
+		segment := text[breakPoints[i-1]:breakPoints[i]]
+
+		if currentLine == "" {
+			startLine(segment)
+			continue
 		}
 
+		// Calculate what the line would be if we add this segment
+		testLine := currentLine + segment
+		testWidth := t.cssLineWidth(testLine, opts.Style)
+
 		// Apply hanging punctuation - reduces effective width
 		effectiveWidth := t.calculateEffectiveWidth(testLine, testWidth, opts.Style.HangingPunctuation)
 
-		// Check if adding this segment would exceed maxWidth
-		if effectiveWidth > maxWidth && currentLine != "" {
-			currentRuneLen := len([]rune(currentLine))
-
-			// Line is full, commit current line
-			lines = append(lines, Line{
-				Content: currentLine,
-				Width:   currentWidth,
-				Start:   lineStartIdx,
-				End:     lineStartIdx + currentRuneLen,
-			})
-			lineStartIdx += currentRuneLen
-
-			// Start new line with this segment
-			currentLine = segment
-			currentWidth = t.Width(segment)
+		// A line ending at a hyphenation point must leave room for the hyphen
+		if hyphenBreaks[breakPoints[i]] {
+			effectiveWidth += hyphenWidth
+		}
 
-			// Apply spacing for new line
-			if !opts.Style.LetterSpacing.IsZero() {
-				graphemes := t.Graphemes(currentLine)
-				currentWidth += float64(len(graphemes)-1) * opts.Style.LetterSpacing.Raw()
-			}
-			if !opts.Style.WordSpacing.IsZero() {
-				spaceCount := strings.Count(currentLine, " ")
-				currentWidth += float64(spaceCount) * opts.Style.WordSpacing.Raw()
-			}
+		// Check if adding this segment would exceed maxWidth
+		if effectiveWidth > maxWidth {
+			// Line is full, commit current line and start a new one
+			commit(hyphenBreaks[breakPoints[i-1]])
+			startLine(segment)
 		} else {
 			// Add segment to current line
 			currentLine = testLine
@@ -649,33 +1387,58 @@ func (t *Text) buildLinesFromBreakPoints(text string, breakPoints []int, opts CS
 	return lines
 }
 
-// calculateEffectiveWidth returns the effective width of text accounting for hanging punctuation.
-// Hanging punctuation reduces the effective width because it hangs outside the line box.
-func (t *Text) calculateEffectiveWidth(text string, baseWidth float64, mode HangingPunctuation) float64 {
-	if mode == HangingPunctuationNone || len(text) == 0 {
-		return baseWidth
+// cssLineWidth measures a line including letter-spacing and word-spacing.
+func (t *Text) cssLineWidth(line string, style CSSTextStyle) float64 {
+	width := t.Width(line)
+
+	// Apply letter spacing
+	if !style.LetterSpacing.IsZero() {
+		graphemes := t.Graphemes(line)
+		width += float64(len(graphemes)-1) * t.ResolveLength(style.LetterSpacing)
 	}
 
-	runes := []rune(text)
-	effectiveWidth := baseWidth
+	// Apply word spacing
+	if !style.WordSpacing.IsZero() {
+		spaceCount := strings.Count(line, " ")
+		width += float64(spaceCount) * t.ResolveLength(style.WordSpacing)
+	}
+
+	return width
+}
 
-	// Check first character
-	if len(runes) > 0 {
-		shouldHang, hangWidth := t.ShouldHang(text, 0, mode)
-		if shouldHang {
-			effectiveWidth -= hangWidth
+// splitAtGraphemes splits s greedily into pieces no wider than maxWidth,
+// breaking only at grapheme cluster boundaries. A single grapheme wider
+// than maxWidth becomes its own piece, and trailing white space stays on
+// the last piece.
+func (t *Text) splitAtGraphemes(s string, maxWidth float64, style CSSTextStyle) []string {
+	var pieces []string
+	current := ""
+
+	for _, g := range t.Graphemes(s) {
+		// Trailing white space hangs, as it does in ordinary wrapping.
+		if current != "" && strings.TrimSpace(g) != "" && t.cssLineWidth(current+g, style) > maxWidth {
+			pieces = append(pieces, current)
+			current = ""
 		}
+		current += g
+	}
+	if current != "" {
+		pieces = append(pieces, current)
 	}
 
-	// Check last character
-	if len(runes) > 0 {
-		shouldHang, hangWidth := t.ShouldHang(text, len(runes)-1, mode)
-		if shouldHang {
-			effectiveWidth -= hangWidth
-		}
+	return pieces
+}
+
+// calculateEffectiveWidth returns the effective width of a line accounting for hanging punctuation.
+// Hanging punctuation reduces the effective width because it hangs outside the line box.
+func (t *Text) calculateEffectiveWidth(line string, baseWidth float64, mode HangingPunctuation) float64 {
+	if mode == HangingPunctuationNone || len(line) == 0 {
+		return baseWidth
 	}
 
-	return effectiveWidth
+	runes := []rune(line)
+	startHang, endHang := t.ShouldHangAtLine(runes, 0, len(runes), mode)
+	return baseWidth - startHang - endHang
 }
 
 // ═══════════════════════════════════════════════════════════════
@@ -758,20 +1521,8 @@ func (t *Text) ApplyTextOverflow(text string, maxWidth float64, style CSSTextSty
 
 // clipAtWidth clips text at the exact width without any indicator.
 func (t *Text) clipAtWidth(text string, maxWidth float64) string {
-	graphemes := t.Graphemes(text)
-	result := ""
-	width := 0.0
-
-	for _, g := range graphemes {
-		gWidth := t.Width(g)
-		if width+gWidth > maxWidth {
-			break
-		}
-		result += g
-		width += gWidth
-	}
-
-	return result
+	head, _, _ := t.ClipToWidth(text, maxWidth)
+	return head
 }
 
 // ═══════════════════════════════════════════════════════════════
@@ -803,6 +1554,16 @@ func (t *Text) AlignLines(lines []Line, width float64, style CSSTextStyle) []Lin
 	result := make([]Line, len(lines))
 	copy(result, lines)
 
+	// Resolve auto direction once so every line of the block agrees
+	direction := style.Direction
+	if direction == DirectionAuto {
+		var block strings.Builder
+		for _, line := range lines {
+			block.WriteString(line.Content)
+		}
+		direction = t.resolveDirection(block.String(), direction)
+	}
+
 	for i := range result {
 		isLastLine := (i == len(result)-1)
 
@@ -810,18 +1571,93 @@ func (t *Text) AlignLines(lines []Line, width float64, style CSSTextStyle) []Lin
 		align := style.TextAlign
 		if isLastLine {
 			// CSS-like behavior: when text-align is justify and text-align-last is
-			// left/default, last line should not be justified.
+			// left/default, last line should not be justified but start-aligned,
+			// which is the right edge in RTL.
 			if style.TextAlign == AlignJustify && style.TextAlignLast == AlignLeft {
-				align = AlignLeft
+				align = AlignStart
 			} else if style.TextAlignLast != AlignLeft {
 				align = style.TextAlignLast
 			}
 		}
 
 		// Apply alignment with direction support
-		result[i].Content = t.AlignWithDirection(result[i].Content, width, align, style.Direction, style.TextAlign)
+		result[i].Content = t.AlignWithDirection(result[i].Content, width, align, direction, style.TextAlign)
 		result[i].Width = width
 	}
 
 	return result
 }
+
+// ═══════════════════════════════════════════════════════════════
+//  Cell Rendering
+// ═══════════════════════════════════════════════════════════════
+
+// RenderCell renders text into a fixed-size cell of maxLines lines, each
+// exactly width units wide.
+//
+// It composes the CSS pipeline in order: white-space processing, text
+// transform, and word breaking (via WrapCSS), then line clamping, overflow
+// handling for lines that still do not fit, and alignment (via AlignLines).
+// When the text needs more than maxLines lines, the last visible line ends
+// with style.TextOverflowEllipsisString ("..." if empty). When it needs
+// fewer, the result is padded with blank lines. If maxLines <= 0, all lines
+// are returned without clamping or padding.
+//
+// Example:
+//
+//	txt := text.NewTerminal()
+//	style := text.DefaultCSSTextStyle()
+//	style.TextAlign = text.AlignCenter
+//	cell := txt.RenderCell("The quick brown fox jumps", 10, 2, style)
+//	// []string{"The quick ", "brown f..."}
+func (t *Text) RenderCell(text string, width float64, maxLines int, style CSSTextStyle) []string {
+	lines := t.WrapCSS(text, CSSWrapOptions{
+		MaxWidth: units.Px(width),
+		Style:    style,
+	})
+
+	clamped := maxLines > 0 && len(lines) > maxLines
+	if clamped {
+		lines = lines[:maxLines]
+	}
+
+	for i := range lines {
+		content := strings.TrimRightFunc(lines[i].Content, unicode.IsSpace)
+		if clamped && i == len(lines)-1 {
+			content = t.clampWithEllipsis(content, width, style)
+		} else {
+			content = t.ApplyTextOverflow(content, width, style)
+		}
+		lines[i].Content = content
+		lines[i].Width = t.Width(content)
+	}
+
+	lines = t.AlignLines(lines, width, style)
+
+	cell := make([]string, 0, max(maxLines, len(lines)))
+	for _, line := range lines {
+		// Fill any remainder left by rounding in centered alignment
+		cell = append(cell, line.Content+t.makePadding(width-t.Width(line.Content)))
+	}
+	for len(cell) < maxLines {
+		cell = append(cell, t.makePadding(width))
+	}
+
+	return cell
+}
+
+// clampWithEllipsis ends the last visible line of a clamped block with an
+// ellipsis, clipping the line if needed so the result fits in width.
+func (t *Text) clampWithEllipsis(content string, width float64, style CSSTextStyle) string {
+	ellipsis := style.TextOverflowEllipsisString
+	if ellipsis == "" {
+		ellipsis = "..."
+	}
+
+	ellipsisWidth := t.Width(ellipsis)
+	if ellipsisWidth >= width {
+		return t.clipAtWidth(ellipsis, width)
+	}
+
+	return t.clipAtWidth(content, width-ellipsisWidth) + ellipsis
+}
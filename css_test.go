@@ -1,8 +1,13 @@
 package text
 
 import (
+	"fmt"
+	"reflect"
+	"slices"
 	"strings"
 	"testing"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/SCKelemen/units"
 )
@@ -63,6 +68,55 @@ func TestProcessWhiteSpace(t *testing.T) {
 			want:       "Hello world\nNext line",
 			allowWrap:  true,
 		},
+		{
+			name:       "Normal keeps no-break spaces",
+			input:      "  10\u00A0\u00A0km   away\u202F!  ",
+			whiteSpace: WhiteSpaceNormal,
+			want:       "10\u00A0\u00A0km away\u202F!",
+			allowWrap:  true,
+		},
+		{
+			name:       "Normal does not trim no-break spaces",
+			input:      "\u00A0indented ",
+			whiteSpace: WhiteSpaceNormal,
+			want:       "\u00A0indented",
+			allowWrap:  true,
+		},
+		{
+			name:       "Normal collapses other breaking spaces",
+			input:      "a\t\u2003 \u3000b",
+			whiteSpace: WhiteSpaceNormal,
+			want:       "a b",
+			allowWrap:  true,
+		},
+		{
+			name:       "Normal does not collapse across zero width spaces",
+			input:      "a \u200B b",
+			whiteSpace: WhiteSpaceNormal,
+			want:       "a \u200B b",
+			allowWrap:  true,
+		},
+		{
+			name:       "PreWrap keeps leading and trailing spaces",
+			input:      "  indented  ",
+			whiteSpace: WhiteSpacePreWrap,
+			want:       "  indented  ",
+			allowWrap:  true,
+		},
+		{
+			name:       "PreLine removes spaces around newlines",
+			input:      "  one  \r\n  two\r\n",
+			whiteSpace: WhiteSpacePreLine,
+			want:       "one\ntwo\n",
+			allowWrap:  true,
+		},
+		{
+			name:       "PreLine keeps no-break spaces at line starts",
+			input:      "one\n\u00A0two",
+			whiteSpace: WhiteSpacePreLine,
+			want:       "one\n\u00A0two",
+			allowWrap:  true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -103,6 +157,18 @@ func TestTransform(t *testing.T) {
 			transform: TextTransformUppercase,
 			want:      "HELLO WORLD",
 		},
+		{
+			name:      "Uppercase sharp s expands",
+			input:     "Straße",
+			transform: TextTransformUppercase,
+			want:      "STRASSE",
+		},
+		{
+			name:      "Uppercase ligature expands",
+			input:     "\uFB01ne",
+			transform: TextTransformUppercase,
+			want:      "FINE",
+		},
 		{
 			name:      "Lowercase",
 			input:     "Hello World",
@@ -145,10 +211,159 @@ func TestTransform(t *testing.T) {
 	}
 }
 
+func TestTransform_Width(t *testing.T) {
+	txt := NewTerminal()
+
+	tests := []struct {
+		name      string
+		input     string
+		transform TextTransform
+		want      string
+	}{
+		{"Half: fullwidth Latin", "Ｈｅｌｌｏ", TextTransformHalfWidth, "Hello"},
+		{"Half: fullwidth space and punctuation", "Ｈｉ　ｔｈｅｒｅ！", TextTransformHalfWidth, "Hi there!"},
+		{"Half: handakuten composes", "ﾊﾟﾝ", TextTransformHalfWidth, "パン"},
+		{"Half: dakuten composes", "ｶﾞｷﾞ", TextTransformHalfWidth, "ガギ"},
+		{"Half: vu", "ｳﾞｧ", TextTransformHalfWidth, "ヴァ"},
+		{"Half: mark that cannot compose", "ｱﾞ", TextTransformHalfWidth, "ア\u309B"},
+		{"Half: lone mark", "ﾟ", TextTransformHalfWidth, "\u309C"},
+		{"Half: punctuation", "｢ｱｲ｣､｡", TextTransformHalfWidth, "「アイ」、。"},
+		{"Half: mixed", "ﾃﾞｰﾀ１２３", TextTransformHalfWidth, "データ123"},
+		{"Half: untouched", "日本語 text", TextTransformHalfWidth, "日本語 text"},
+		{"Full: halfwidth katakana", "ﾊﾟﾝ", TextTransformFullWidth, "パン"},
+		{"Full: mixed", "ﾃﾞｰﾀ 123", TextTransformFullWidth, "データ　１２３"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := txt.Transform(tt.input, tt.transform)
+			if got != tt.want {
+				t.Errorf("Transform(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+
+	// Half width undoes full width for ASCII.
+	ascii := "Hello, World! 123"
+	if got := txt.Transform(txt.Transform(ascii, TextTransformFullWidth), TextTransformHalfWidth); got != ascii {
+		t.Errorf("round trip = %q, want %q", got, ascii)
+	}
+}
+
+func TestTransformLocale(t *testing.T) {
+	txt := NewTerminal()
+
+	tests := []struct {
+		name      string
+		input     string
+		transform TextTransform
+		lang      string
+		want      string
+	}{
+		{"Turkish uppercase dotted i", "istanbul", TextTransformUppercase, "tr", "İSTANBUL"},
+		{"Turkish lowercase dotless I", "IŞIK", TextTransformLowercase, "tr", "ışık"},
+		{"Turkish capitalize", "istanbul izmir", TextTransformCapitalize, "tr", "İstanbul İzmir"},
+		{"Default uppercase", "istanbul", TextTransformUppercase, "en", "ISTANBUL"},
+		{"German sharp s", "straße", TextTransformUppercase, "de", "STRASSE"},
+		{"Capitalize keeps rest", "o'brien mcDonald", TextTransformCapitalize, "en", "O'brien McDonald"},
+		{"Invalid tag falls back", "straße", TextTransformUppercase, "not a tag!", "STRASSE"},
+		{"Non-case transform", "ab", TextTransformFullWidth, "tr", "ａｂ"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := txt.TransformLocale(tt.input, tt.transform, tt.lang)
+			if got != tt.want {
+				t.Errorf("TransformLocale(%q, %q) = %q, want %q", tt.input, tt.lang, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTransform_CapitalizeWords(t *testing.T) {
+	txt := NewTerminal()
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"o'brien", "O'brien"},
+		{"don't stop", "Don't Stop"},
+		{"e-mail me", "E-mail Me"},
+		{"l'été", "L'été"},
+		{"(hello) [world]", "(Hello) [World]"},
+		{"«bonjour» monde", "«Bonjour» Monde"},
+		{"1st place", "1st Place"},
+		{"hello\tworld\nagain", "Hello\tWorld\nAgain"},
+		{"ǆungla", "ǅungla"}, // Titlecase, not uppercase
+		{"世界 hello", "世界 Hello"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got := txt.Transform(tt.input, TextTransformCapitalize)
+			if got != tt.want {
+				t.Errorf("Transform(%q, capitalize) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
 // ═══════════════════════════════════════════════════════════════
 //  Word and Sentence Boundary Tests
 // ═══════════════════════════════════════════════════════════════
 
+func TestTransform_SoftHyphen(t *testing.T) {
+	txt := NewTerminal()
+
+	tests := []struct {
+		name      string
+		input     string
+		transform TextTransform
+		want      string
+	}{
+		{"Capitalize", "ex\u00ADample", TextTransformCapitalize, "Ex\u00ADample"},
+		{"Capitalize leading soft hyphen", "\u00ADexample word", TextTransformCapitalize, "\u00ADExample Word"},
+		{"Capitalize several", "ex\u00ADam\u00ADple te\u00ADst", TextTransformCapitalize, "Ex\u00ADam\u00ADple Te\u00ADst"},
+		{"Uppercase", "ex\u00ADample", TextTransformUppercase, "EX\u00ADAMPLE"},
+		{"Uppercase with expansion", "Stra\u00ADße", TextTransformUppercase, "STRA\u00ADSSE"},
+		{"Lowercase", "EX\u00ADAMPLE", TextTransformLowercase, "ex\u00ADample"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := txt.Transform(tt.input, tt.transform)
+			if got != tt.want {
+				t.Errorf("Transform(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTransformAndWrap_SharpS(t *testing.T) {
+	txt := NewTerminal()
+
+	// "straße" is 6 cells and fits next to "die " in 10 cells;
+	// "STRASSE" is 7 cells and must move to its own line.
+	input := "die straße"
+	opts := WrapOptions{MaxWidth: 10}
+
+	if lines := txt.Wrap(input, opts); len(lines) != 1 {
+		t.Fatalf("original text should fit on one line, got %d", len(lines))
+	}
+
+	lines := txt.TransformAndWrap(input, TextTransformUppercase, opts)
+	if len(lines) != 2 {
+		t.Fatalf("TransformAndWrap returned %d lines, want 2: %+v", len(lines), lines)
+	}
+	if lines[0].Content != "DIE " || lines[1].Content != "STRASSE" {
+		t.Errorf("lines = %q / %q, want %q / %q", lines[0].Content, lines[1].Content, "DIE ", "STRASSE")
+	}
+	if lines[1].Width != 7 {
+		t.Errorf("second line width = %.1f, want 7.0 (expanded width)", lines[1].Width)
+	}
+}
+
 func TestWords(t *testing.T) {
 	txt := NewTerminal()
 
@@ -189,6 +404,41 @@ func TestWords(t *testing.T) {
 	}
 }
 
+func TestWordCountWith(t *testing.T) {
+	txt := NewTerminal()
+
+	both := WordCountOptions{JoinHyphens: true, JoinApostrophes: true}
+
+	tests := []struct {
+		name  string
+		input string
+		opts  WordCountOptions
+		want  int
+	}{
+		{"Hyphenated default", "state-of-the-art", WordCountOptions{}, 4},
+		{"Hyphenated joined", "state-of-the-art", WordCountOptions{JoinHyphens: true}, 1},
+		{"Hyphenated in sentence", "a state-of-the-art tool", WordCountOptions{JoinHyphens: true}, 3},
+		{"Non-breaking hyphen", "well\u2011known", WordCountOptions{JoinHyphens: true}, 1},
+		{"Spaced hyphen separates", "well - known", WordCountOptions{JoinHyphens: true}, 2},
+		{"Trailing hyphen", "pre- and post-war", WordCountOptions{JoinHyphens: true}, 3},
+		{"Contraction", "don't", WordCountOptions{}, 1},
+		{"Contraction joined", "don't", both, 1},
+		{"Apostrophe after digits", "90's", WordCountOptions{}, 2},
+		{"Apostrophe after digits joined", "90's", WordCountOptions{JoinApostrophes: true}, 1},
+		{"Curly apostrophe", "rock\u2019n\u2019roll", both, 1},
+		{"Decimal number", "pi is 3.14", both, 3},
+		{"Apostrophe option ignores hyphens", "e-mail", WordCountOptions{JoinApostrophes: true}, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := txt.WordCountWith(tt.input, tt.opts); got != tt.want {
+				t.Errorf("WordCountWith(%q) = %d, want %d", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestSentences(t *testing.T) {
 	txt := NewTerminal()
 
@@ -365,6 +615,701 @@ func TestWrapCSS(t *testing.T) {
 	})
 }
 
+func TestWrapCSS_NoBreakSpaces(t *testing.T) {
+	txt := NewTerminal()
+
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{"No-break space", "go 10\u00A0km now", []string{"go ", "10\u00A0km ", "now"}},
+		{"Word joiner", "go 10\u2060km now", []string{"go ", "10\u2060km ", "now"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines := txt.WrapCSS(tt.input, CSSWrapOptions{MaxWidth: units.Px(6), Style: DefaultCSSTextStyle()})
+			got := make([]string, len(lines))
+			for i, line := range lines {
+				got[i] = line.Content
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("WrapCSS(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWrapCSS_BreakSpaces(t *testing.T) {
+	txt := NewTerminal()
+
+	tests := []struct {
+		name       string
+		whiteSpace WhiteSpace
+		want       []string
+	}{
+		// pre-wrap only breaks after a whole run of spaces
+		{"PreWrap", WhiteSpacePreWrap, []string{"aa   ", "bb  "}},
+		// break-spaces breaks between spaces, so trailing spaces wrap too
+		{"BreakSpaces", WhiteSpaceBreakSpaces, []string{"aa  ", " bb ", " "}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			style := DefaultCSSTextStyle()
+			style.WhiteSpace = tt.whiteSpace
+			lines := txt.WrapCSS("aa   bb  ", CSSWrapOptions{MaxWidth: units.Px(4), Style: style})
+			got := make([]string, len(lines))
+			for i, line := range lines {
+				got[i] = line.Content
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("WrapCSS() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWrapCSS_PreservedNewlines(t *testing.T) {
+	txt := NewTerminal()
+
+	tests := []struct {
+		name       string
+		whiteSpace WhiteSpace
+		text       string
+		want       []Line
+	}{
+		{"Normal", WhiteSpaceNormal, "Hello    world\nNext", []Line{
+			{Content: "Hello ", Width: 6, Start: 0, End: 6, BreakType: BreakSoft},
+			{Content: "world ", Width: 6, Start: 6, End: 12, BreakType: BreakSoft},
+			{Content: "Next", Width: 4, Start: 12, End: 16},
+		}},
+		{"PreWrap", WhiteSpacePreWrap, "Hello    world\nNext", []Line{
+			{Content: "Hello    ", Width: 9, Start: 0, End: 9, BreakType: BreakSoft},
+			{Content: "world", Width: 5, Start: 9, End: 14, BreakType: BreakHard},
+			{Content: "Next", Width: 4, Start: 15, End: 19},
+		}},
+		{"Pre", WhiteSpacePre, "Hello    world\nNext", []Line{
+			{Content: "Hello    world", Width: 14, Start: 0, End: 14, BreakType: BreakHard},
+			{Content: "Next", Width: 4, Start: 15, End: 19},
+		}},
+		{"PreLine", WhiteSpacePreLine, "Hello    world\nNext", []Line{
+			{Content: "Hello ", Width: 6, Start: 0, End: 6, BreakType: BreakSoft},
+			{Content: "world", Width: 5, Start: 6, End: 11, BreakType: BreakHard},
+			{Content: "Next", Width: 4, Start: 12, End: 16},
+		}},
+		{"PreWrap CRLF", WhiteSpacePreWrap, "Hello    world\r\nNext", []Line{
+			{Content: "Hello    ", Width: 9, Start: 0, End: 9, BreakType: BreakSoft},
+			{Content: "world", Width: 5, Start: 9, End: 14, BreakType: BreakHard},
+			{Content: "Next", Width: 4, Start: 16, End: 20},
+		}},
+		{"Pre CRLF", WhiteSpacePre, "a\r\nb", []Line{
+			{Content: "a", Width: 1, Start: 0, End: 1, BreakType: BreakHard},
+			{Content: "b", Width: 1, Start: 3, End: 4},
+		}},
+		{"Pre lone CR", WhiteSpacePre, "a\rb", []Line{
+			{Content: "a", Width: 1, Start: 0, End: 1, BreakType: BreakHard},
+			{Content: "b", Width: 1, Start: 2, End: 3},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			style := DefaultCSSTextStyle()
+			style.WhiteSpace = tt.whiteSpace
+			got := txt.WrapCSS(tt.text, CSSWrapOptions{MaxWidth: units.Px(8), Style: style})
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("WrapCSS() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWrapCSS_Concatenation(t *testing.T) {
+	txt := NewTerminal()
+	sentence := "The quick brown fox jumps over the lazy dog, then naps under an " +
+		"extraordinarily comfortable tree while 世界の人々 watch."
+
+	styles := map[string]func(*CSSTextStyle){
+		"normal":         func(s *CSSTextStyle) {},
+		"letter-spacing": func(s *CSSTextStyle) { s.LetterSpacing = units.Px(0.5) },
+		"word-spacing":   func(s *CSSTextStyle) { s.WordSpacing = units.Px(2) },
+		"break-all":      func(s *CSSTextStyle) { s.WordBreak = WordBreakBreakAll },
+		"keep-all":       func(s *CSSTextStyle) { s.WordBreak = WordBreakKeepAll },
+		"break-word":     func(s *CSSTextStyle) { s.OverflowWrap = OverflowWrapBreakWord },
+		"hanging":        func(s *CSSTextStyle) { s.HangingPunctuation = HangingPunctuationAllowEnd },
+		"uppercase":      func(s *CSSTextStyle) { s.TextTransform = TextTransformUppercase },
+	}
+
+	for name, apply := range styles {
+		for _, width := range []float64{8, 13, 20, 40} {
+			t.Run(fmt.Sprintf("%s/%g", name, width), func(t *testing.T) {
+				style := DefaultCSSTextStyle()
+				apply(&style)
+				lines := txt.WrapCSS(sentence, CSSWrapOptions{MaxWidth: units.Px(width), Style: style})
+
+				processed, _ := txt.ProcessWhiteSpace(sentence, style.WhiteSpace)
+				processed = txt.Transform(processed, style.TextTransform)
+
+				var joined strings.Builder
+				next := 0
+				for _, line := range lines {
+					joined.WriteString(line.Content)
+					if line.Start != next {
+						t.Errorf("line %q starts at %d, want %d", line.Content, line.Start, next)
+					}
+					next = line.End
+				}
+				if joined.String() != processed {
+					t.Errorf("joined lines = %q, want %q", joined.String(), processed)
+				}
+				if len(lines) < 2 {
+					t.Errorf("got %d lines, want the sentence wrapped", len(lines))
+				}
+				// Segments accumulate: lines hold several words, not one each.
+				if words := len(strings.Fields(processed)); width >= 20 && len(lines) >= words {
+					t.Errorf("got %d lines for %d words, want words sharing lines", len(lines), words)
+				}
+				for i, line := range lines {
+					if strings.TrimSpace(line.Content) == "" {
+						t.Errorf("line %d is blank", i)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestWrapCSS_LongTokensKeepAllText(t *testing.T) {
+	txt := NewTerminal()
+	input := "https://example.com/a/very/long/path " + strings.Repeat("x", 25) + " " +
+		strings.Repeat("y", 30) + " ok " + strings.Repeat("長", 12) + " end"
+
+	wraps := map[string]OverflowWrap{
+		"normal":     OverflowWrapNormal,
+		"break-word": OverflowWrapBreakWord,
+		"anywhere":   OverflowWrapAnywhere,
+	}
+
+	for name, wrap := range wraps {
+		for _, width := range []float64{1, 5, 10, 24} {
+			t.Run(fmt.Sprintf("%s/%g", name, width), func(t *testing.T) {
+				style := DefaultCSSTextStyle()
+				style.OverflowWrap = wrap
+				lines := txt.WrapCSS(input, CSSWrapOptions{MaxWidth: units.Px(width), Style: style})
+
+				runes := 0
+				for _, line := range lines {
+					runes += utf8.RuneCountInString(line.Content)
+				}
+				if want := utf8.RuneCountInString(input); runes != want {
+					t.Errorf("lines hold %d runes, want %d", runes, want)
+				}
+				if wrap != OverflowWrapNormal {
+					for _, line := range lines {
+						visible := strings.TrimRight(line.Content, " ")
+						if w := txt.Width(visible); w > width && txt.GraphemeCount(visible) > 1 {
+							t.Errorf("line %q is %.0f wide, want <= %.0f", line.Content, w, width)
+						}
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestWrapCSS_OverflowWrap(t *testing.T) {
+	txt := NewTerminal()
+	token := strings.Repeat("abcdefghij", 4) // 40 cells, no break opportunities
+
+	wrap := func(text string, ow OverflowWrap) []Line {
+		return txt.WrapCSS(text, CSSWrapOptions{
+			MaxWidth: units.Ch(10),
+			Style:    CSSTextStyle{WhiteSpace: WhiteSpaceNormal, OverflowWrap: ow},
+		})
+	}
+
+	t.Run("Normal overflows", func(t *testing.T) {
+		lines := wrap(token, OverflowWrapNormal)
+		if len(lines) != 1 || lines[0].Width != 40 {
+			t.Errorf("expected one 40-cell line, got %+v", lines)
+		}
+	})
+
+	for _, ow := range []OverflowWrap{OverflowWrapBreakWord, OverflowWrapAnywhere} {
+		lines := wrap(token, ow)
+		if len(lines) != 4 {
+			t.Fatalf("overflow-wrap %d: got %d lines, want 4", ow, len(lines))
+		}
+		for i, line := range lines {
+			if line.Content != token[i*10:(i+1)*10] || line.Width != 10 {
+				t.Errorf("overflow-wrap %d: line %d = %+v", ow, i, line)
+			}
+			if line.Start != i*10 || line.End != (i+1)*10 {
+				t.Errorf("overflow-wrap %d: line %d range [%d:%d], want [%d:%d]",
+					ow, i, line.Start, line.End, i*10, (i+1)*10)
+			}
+		}
+	}
+
+	t.Run("Only the overflowing word is broken", func(t *testing.T) {
+		lines := wrap("see "+token+" ok", OverflowWrapBreakWord)
+		var got []string
+		for _, line := range lines {
+			got = append(got, line.Content)
+		}
+		// The token moves to its own line before being split.
+		want := []string{"see ", "abcdefghij", "abcdefghij", "abcdefghij", "abcdefghij ", "ok"}
+		if strings.Join(got, "|") != strings.Join(want, "|") {
+			t.Errorf("got %q, want %q", got, want)
+		}
+	})
+
+	t.Run("Grapheme clusters are not split", func(t *testing.T) {
+		lines := wrap(strings.Repeat("👨‍👩‍👧", 8), OverflowWrapAnywhere)
+		for i, line := range lines {
+			if line.Width > 10 || strings.Count(line.Content, "👨‍👩‍👧")*2 != int(line.Width) {
+				t.Errorf("line %d %q splits a ZWJ sequence or overflows", i, line.Content)
+			}
+		}
+	})
+}
+
+func TestWrapAccessible(t *testing.T) {
+	txt := NewTerminal()
+	text := "The quick brown fox jumps over the lazy dog"
+
+	plain := txt.WrapAccessible(text, 20, 0, 0)
+	spaced := txt.WrapAccessible(text, 20, 0.5, 1)
+
+	if len(spaced) <= len(plain) {
+		t.Errorf("spaced text wrapped to %d lines, want more than %d", len(spaced), len(plain))
+	}
+
+	style := DefaultCSSTextStyle()
+	style.LetterSpacing = units.Px(0.5)
+	style.WordSpacing = units.Px(1)
+	for i, line := range spaced {
+		if line.Width > 20 {
+			t.Errorf("line %d %q width %.1f exceeds 20", i, line.Content, line.Width)
+		}
+		if want := txt.cssLineWidth(line.Content, style); line.Width != want {
+			t.Errorf("line %d width %.1f does not include spacing, want %.1f", i, line.Width, want)
+		}
+	}
+
+	t.Run("Word that only overflows when spaced is broken", func(t *testing.T) {
+		lines := txt.WrapAccessible("abcdefgh", 10, 0.5, 0)
+		for i, line := range lines {
+			if line.Width > 10 {
+				t.Errorf("line %d %q width %.1f exceeds 10", i, line.Content, line.Width)
+			}
+		}
+		if len(lines) != 2 {
+			t.Errorf("got %d lines, want 2", len(lines))
+		}
+	})
+}
+
+func TestResolveLength(t *testing.T) {
+	// Every rune is 10 pixels wide, "水" included.
+	measure := func(r rune) float64 { return 10 }
+	px := New(Config{MeasureFunc: measure, FontSize: 20})
+	withXHeight := New(Config{MeasureFunc: measure, FontSize: 20, XHeight: 9})
+	withFont := px.WithFontMetrics(fakeFontMetrics{ascent: 800, descent: 200, capHeight: 700, xHeight: 450, unitsPerEm: 1000})
+
+	tests := []struct {
+		name   string
+		txt    *Text
+		length units.Length
+		want   float64
+	}{
+		{"em", px, units.Em(0.1), 2},
+		{"rem", px, units.Rem(2), 40},
+		{"ex from font size", px, units.Ex(1), 10},
+		{"ex from config", withXHeight, units.Ex(2), 18},
+		{"ex from font metrics", withFont, units.Ex(2), 18},
+		{"cap from font metrics", withFont, units.Cap(1), 14},
+		{"ch", px, units.Ch(3), 30},
+		{"ic", px, units.Ic(1), 10},
+		{"px", px, units.Px(7), 7},
+		{"em without font size", NewTerminal(), units.Em(2), 2},
+		{"ch on terminal", NewTerminal(), units.Ch(40), 40},
+	}
+
+	const epsilon = 1e-9
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.txt.ResolveLength(tt.length); got < tt.want-epsilon || got > tt.want+epsilon {
+				t.Errorf("ResolveLength(%v) = %g, want %g", tt.length, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWrapCSS_FontRelativeSpacing(t *testing.T) {
+	txt := New(Config{MeasureFunc: func(r rune) float64 { return 10 }, FontSize: 20})
+
+	style := DefaultCSSTextStyle()
+	style.LetterSpacing = units.Em(0.1) // 2px per gap, not 0.1px
+
+	// "abc def" is 70px plus 6 gaps of 2px = 82px, which overflows 75px.
+	lines := txt.WrapCSS("abc def", CSSWrapOptions{MaxWidth: units.Px(75), Style: style})
+	if len(lines) != 2 || lines[0].Content != "abc " || lines[0].Width != 46 {
+		t.Errorf("WrapCSS = %+v, want \"abc \" (46px) and \"def\"", lines)
+	}
+
+	// MaxWidth may be font-relative too: 5em = 100px fits the whole line.
+	lines = txt.WrapCSS("abc def", CSSWrapOptions{MaxWidth: units.Em(5), Style: style})
+	if len(lines) != 1 || lines[0].Width != 82 {
+		t.Errorf("WrapCSS with 5em = %+v, want one 82px line", lines)
+	}
+
+	style.WordSpacing = units.Em(0.5) // 10px per space
+	if got := txt.cssLineWidth("abc def", style); got != 92 {
+		t.Errorf("cssLineWidth with word-spacing = %g, want 92", got)
+	}
+}
+
+func TestWrapCSS_HangingPunctuation(t *testing.T) {
+	txt := NewTerminal()
+
+	wrap := func(text string, mode HangingPunctuation) []string {
+		lines := txt.WrapCSS(text, CSSWrapOptions{
+			MaxWidth: units.Ch(6),
+			Style:    CSSTextStyle{WhiteSpace: WhiteSpaceNormal, HangingPunctuation: mode},
+		})
+		var got []string
+		for _, line := range lines {
+			got = append(got, line.Content)
+		}
+		return got
+	}
+
+	tests := []struct {
+		name string
+		text string
+		mode HangingPunctuation
+		want []string
+	}{
+		{
+			name: "Closing quote hangs at end of line 2",
+			text: "aaaaa bb cc” dd",
+			mode: HangingPunctuationLast,
+			want: []string{"aaaaa ", "bb cc” ", "dd"},
+		},
+		{
+			name: "Without hanging",
+			text: "aaaaa bb cc” dd",
+			mode: HangingPunctuationNone,
+			want: []string{"aaaaa ", "bb ", "cc” dd"},
+		},
+		{
+			name: "Comma hangs at end of line 2",
+			text: "aaaaa bb cc, dd",
+			mode: HangingPunctuationForceEnd,
+			want: []string{"aaaaa ", "bb cc, ", "dd"},
+		},
+		{
+			name: "Opening quote hangs at start of line 2",
+			text: "aaaaa “bb ccc",
+			mode: HangingPunctuationFirst,
+			want: []string{"aaaaa ", "“bb ccc"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := wrap(tt.text, tt.mode); strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWrapCSS_LineBreak(t *testing.T) {
+	txt := NewTerminal()
+
+	firstLine := func(text string, lb LineBreak) string {
+		style := DefaultCSSTextStyle()
+		style.LineBreak = lb
+		lines := txt.WrapCSS(text, CSSWrapOptions{MaxWidth: units.Ch(2), Style: style})
+		if len(lines) == 0 {
+			t.Fatalf("WrapCSS(%q) returned no lines", text)
+		}
+		return lines[0].Content
+	}
+
+	tests := []struct {
+		name string
+		text string
+		lb   LineBreak
+		want string
+	}{
+		{"Small kana breaks under loose", "ちょっと", LineBreakLoose, "ち"},
+		{"Small kana breaks under normal", "ちょっと", LineBreakNormal, "ち"},
+		{"Small kana stays under strict", "ちょっと", LineBreakStrict, "ちょっ"},
+		{"Prolonged sound mark stays under strict", "データ", LineBreakStrict, "デー"},
+		{"Prolonged sound mark breaks under loose", "データ", LineBreakLoose, "デ"},
+		{"Iteration mark breaks under loose", "人々", LineBreakLoose, "人"},
+		{"Iteration mark stays under normal", "人々", LineBreakNormal, "人々"},
+		{"Centered punctuation breaks under loose", "日・本", LineBreakLoose, "日"},
+		{"Anywhere breaks inside words", "word", LineBreakAnywhere, "wo"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := firstLine(tt.text, tt.lb); got != tt.want {
+				t.Errorf("first line = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWrapCSS_HyphensAuto(t *testing.T) {
+	txt := NewTerminal()
+	text := "Automatic hyphenation improves the appearance of justified paragraphs."
+
+	wrap := func(hyphens Hyphens, dict *HyphenationDictionary) []Line {
+		style := DefaultCSSTextStyle()
+		style.Hyphens = hyphens
+		return txt.WrapCSS(text, CSSWrapOptions{
+			MaxWidth:   units.Ch(5),
+			Style:      style,
+			Hyphenator: dict,
+		})
+	}
+
+	t.Run("Breaks inside words with a visible hyphen", func(t *testing.T) {
+		dict := NewEnglishHyphenation()
+		lines := wrap(HyphensAuto, dict)
+
+		runes := []rune(text)
+		hyphenated := 0
+		for i, line := range lines {
+			source := string(runes[line.Start:line.End])
+			if line.Content != source && line.Content != source+"-" {
+				t.Errorf("line %d content %q does not match source %q", i, line.Content, source)
+			}
+			if line.Content == source+"-" {
+				hyphenated++
+				// The break must be one of the dictionary's points for the
+				// word it splits.
+				start, end := line.End, line.End
+				for start > 0 && unicode.IsLetter(runes[start-1]) {
+					start--
+				}
+				for end < len(runes) && unicode.IsLetter(runes[end]) {
+					end++
+				}
+				word := string(runes[start:end])
+				if !slices.Contains(dict.Hyphenate(word), line.End-start) {
+					t.Errorf("line %d breaks %q at %d, not a hyphenation point %v",
+						i, word, line.End-start, dict.Hyphenate(word))
+				}
+			}
+			// Words without hyphenation points may still overflow, but a
+			// hyphenated line must fit including its hyphen.
+			if strings.HasSuffix(line.Content, "-") && line.Width > 5 {
+				t.Errorf("line %d %q is %.1f wide, exceeds 5", i, line.Content, line.Width)
+			}
+			if line.Width != txt.Width(line.Content) {
+				t.Errorf("line %d width %.1f, want %.1f", i, line.Width, txt.Width(line.Content))
+			}
+		}
+		if hyphenated == 0 {
+			t.Errorf("expected at least one hyphenated line, got %d lines", len(lines))
+		}
+	})
+
+	t.Run("Liang patterns split hy-phen-a-tion", func(t *testing.T) {
+		// The TeXbook's example patterns (Appendix H), which hyphenate
+		// "hyphenation" as hy-phen-ation, plus a5tion for the final split.
+		patterns := map[string]string{}
+		for _, p := range []string{".hy3ph", "he2n", "hena4", "hen5at", "1na", "n2at", "1tio", "2io", "a5tion"} {
+			patterns[p] = p
+		}
+		dict := NewHyphenationDictionary(patterns, 2, 3)
+
+		var got []string
+		for _, line := range wrap(HyphensAuto, dict) {
+			got = append(got, line.Content)
+		}
+		joined := strings.Join(got, "|")
+		if !strings.Contains(joined, "hy-|phen-|a-|tion ") {
+			t.Errorf("expected hy-phen-a-tion across lines, got %q", got)
+		}
+	})
+
+	t.Run("Per-language dictionaries", func(t *testing.T) {
+		style := DefaultCSSTextStyle()
+		style.Hyphens = HyphensAuto
+		registry := NewHyphenationRegistry()
+		registry.Register("en", NewEnglishHyphenation()) // minRight 3
+		registry.Register("de", NewGermanHyphenation())  // minRight 2
+		opts := CSSWrapOptions{
+			MaxWidth:            units.Ch(4),
+			Style:               style,
+			HyphenationRegistry: registry,
+			Lang:                "en-US",
+			LanguageOf: func(word string) string {
+				if word == "Sonne" {
+					return "de"
+				}
+				return ""
+			},
+		}
+
+		var got []string
+		for _, line := range txt.WrapCSS("table Sonne", opts) {
+			got = append(got, line.Content)
+		}
+		want := []string{"ta-", "ble ", "Son-", "ne"}
+		if strings.Join(got, "|") != strings.Join(want, "|") {
+			t.Errorf("got %q, want %q", got, want)
+		}
+
+		// With English rules for both words, "Son-ne" would leave only two
+		// letters after the hyphen, so "Sonne" is not hyphenated.
+		opts.LanguageOf = nil
+		got = got[:0]
+		for _, line := range txt.WrapCSS("table Sonne", opts) {
+			got = append(got, line.Content)
+		}
+		if got[len(got)-1] != "Sonne" {
+			t.Errorf("English rules: got %q, want Sonne unhyphenated", got)
+		}
+	})
+
+	t.Run("No hyphenation without a dictionary or with manual", func(t *testing.T) {
+		for _, lines := range [][]Line{
+			wrap(HyphensAuto, nil),
+			wrap(HyphensManual, NewEnglishHyphenation()),
+		} {
+			for _, line := range lines {
+				if strings.HasSuffix(line.Content, "-") {
+					t.Errorf("unexpected hyphenated line %q", line.Content)
+				}
+			}
+		}
+	})
+}
+
+func TestIntrinsicSizingWithStyle_OverflowWrap(t *testing.T) {
+	txt := NewTerminal()
+	text := "see " + strings.Repeat("abcdefghij", 4)
+
+	style := DefaultCSSTextStyle()
+	if got := txt.IntrinsicSizingWithStyle(text, style).MinContent; got != 40 {
+		t.Errorf("normal MinContent = %.1f, want 40.0", got)
+	}
+
+	style.OverflowWrap = OverflowWrapBreakWord
+	if got := txt.IntrinsicSizingWithStyle(text, style).MinContent; got != 40 {
+		t.Errorf("break-word MinContent = %.1f, want 40.0 (emergency breaks ignored)", got)
+	}
+
+	style.OverflowWrap = OverflowWrapAnywhere
+	if got := txt.IntrinsicSizingWithStyle(text, style).MinContent; got != 1 {
+		t.Errorf("anywhere MinContent = %.1f, want 1.0 (widest grapheme)", got)
+	}
+	if got := txt.IntrinsicSizingWithStyle("世界", style).MinContent; got != 2 {
+		t.Errorf("anywhere MinContent for CJK = %.1f, want 2.0", got)
+	}
+}
+
+func TestIntrinsicSizingWithStyle_WordBreak(t *testing.T) {
+	txt := NewTerminal()
+
+	tests := []struct {
+		name      string
+		text      string
+		wordBreak WordBreak
+		want      float64
+	}{
+		{"Normal Latin", "see abcdefghij", WordBreakNormal, 10},
+		{"Break-all Latin", "see abcdefghij", WordBreakBreakAll, 1},
+		{"Normal CJK", "你好世界", WordBreakNormal, 2},
+		{"Break-all CJK", "你好世界", WordBreakBreakAll, 2},
+		{"Keep-all CJK", "你好世界 你好", WordBreakKeepAll, 8},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			style := DefaultCSSTextStyle()
+			style.WordBreak = tt.wordBreak
+			if got := txt.IntrinsicSizingWithStyle(tt.text, style).MinContent; got != tt.want {
+				t.Errorf("MinContent(%q) = %.1f, want %.1f", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWrapCSS_WordBreak(t *testing.T) {
+	txt := NewTerminal()
+
+	wrap := func(text string, width float64, wb WordBreak) []string {
+		lines := txt.WrapCSS(text, CSSWrapOptions{
+			MaxWidth: units.Ch(width),
+			Style:    CSSTextStyle{WhiteSpace: WhiteSpaceNormal, WordBreak: wb},
+		})
+		var contents []string
+		for _, line := range lines {
+			contents = append(contents, line.Content)
+		}
+		return contents
+	}
+
+	t.Run("KeepAll keeps Japanese runs together", func(t *testing.T) {
+		text := "今日は 良い天気です"
+
+		normal := wrap(text, 8, WordBreakNormal)
+		if len(normal) != 3 {
+			t.Fatalf("normal: got %q, want 3 lines breaking inside the CJK run", normal)
+		}
+
+		got := wrap(text, 8, WordBreakKeepAll)
+		want := []string{"今日は ", "良い天気です"}
+		if strings.Join(got, "|") != strings.Join(want, "|") {
+			t.Errorf("keep-all: got %q, want %q", got, want)
+		}
+	})
+
+	t.Run("KeepAll still breaks after punctuation", func(t *testing.T) {
+		got := wrap("日本語です。次の文です。", 10, WordBreakKeepAll)
+		want := []string{"日本語です。", "次の文です。"}
+		if strings.Join(got, "|") != strings.Join(want, "|") {
+			t.Errorf("keep-all: got %q, want %q", got, want)
+		}
+	})
+
+	t.Run("BreakAll wraps a long URL-like token", func(t *testing.T) {
+		text := "see https://example.com/averyveryverylongpathsegment"
+
+		normal := wrap(text, 10, WordBreakNormal)
+		overflow := false
+		for _, line := range normal {
+			if txt.Width(line) > 10 {
+				overflow = true
+			}
+		}
+		if !overflow {
+			t.Fatalf("normal: expected the path segment to overflow, got %q", normal)
+		}
+
+		got := wrap(text, 10, WordBreakBreakAll)
+		if strings.Join(got, "") != text {
+			t.Errorf("break-all lost text: %q", got)
+		}
+		for _, line := range got {
+			if w := txt.Width(line); w > 10 {
+				t.Errorf("break-all line %q width %.1f exceeds 10", line, w)
+			}
+		}
+	})
+}
+
 func TestApplyTextOverflow(t *testing.T) {
 	txt := NewTerminal()
 
@@ -743,6 +1688,33 @@ func TestAlignLines_JustifyDefault(t *testing.T) {
 	}
 }
 
+func TestAlignLines_JustifyRTL(t *testing.T) {
+	txt := NewTerminal()
+
+	lines := []Line{
+		{Content: "שלום עולם יפה", Width: 13},
+		{Content: "תודה", Width: 4},
+	}
+
+	for _, dir := range []Direction{DirectionRTL, DirectionAuto} {
+		style := CSSTextStyle{
+			TextAlign:     AlignJustify,
+			TextAlignLast: AlignLeft, // Default
+			Direction:     dir,
+		}
+
+		aligned := txt.AlignLines(lines, 16.0, style)
+
+		if got, want := aligned[0].Content, "שלום  עולם   יפה"; got != want {
+			t.Errorf("direction %d: first line = %q, want %q", dir, got, want)
+		}
+		// The last line is start-aligned, which is the right edge in RTL
+		if got, want := aligned[1].Content, "            תודה"; got != want {
+			t.Errorf("direction %d: last line = %q, want %q", dir, got, want)
+		}
+	}
+}
+
 // ═══════════════════════════════════════════════════════════════
 //  Hanging Punctuation Tests
 // ═══════════════════════════════════════════════════════════════
@@ -923,3 +1895,84 @@ func TestHangingPunctuation_Combined(t *testing.T) {
 		t.Errorf("Expected 1 line with combined hanging, got %d", len(lines))
 	}
 }
+
+func TestRenderCell(t *testing.T) {
+	txt := NewTerminal()
+
+	tests := []struct {
+		name     string
+		text     string
+		width    float64
+		maxLines int
+		style    func(*CSSTextStyle)
+		want     []string
+	}{
+		{
+			name:     "Overflow is clamped with ellipsis",
+			text:     "The quick brown fox jumps over the lazy dog",
+			width:    10,
+			maxLines: 2,
+			want:     []string{"The quick ", "brown f..."},
+		},
+		{
+			name:     "Underflow is blank padded",
+			text:     "Hi",
+			width:    6,
+			maxLines: 3,
+			want:     []string{"Hi    ", "      ", "      "},
+		},
+		{
+			name:     "Right aligned",
+			text:     "Hello world",
+			width:    8,
+			maxLines: 2,
+			style:    func(s *CSSTextStyle) { s.TextAlign = AlignRight },
+			want:     []string{"   Hello", "   world"},
+		},
+		{
+			name:     "Centered with odd padding",
+			text:     "abc",
+			width:    6,
+			maxLines: 1,
+			style:    func(s *CSSTextStyle) { s.TextAlign = AlignCenter },
+			want:     []string{" abc  "},
+		},
+		{
+			name:     "Transform and custom ellipsis",
+			text:     "alpha beta gamma",
+			width:    6,
+			maxLines: 1,
+			style: func(s *CSSTextStyle) {
+				s.TextTransform = TextTransformUppercase
+				s.TextOverflowEllipsisString = "…"
+			},
+			want: []string{"ALPHA…"},
+		},
+		{
+			name:     "Unbreakable word is clipped",
+			text:     "Supercalifragilistic",
+			width:    8,
+			maxLines: 1,
+			want:     []string{"Supercal"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			style := DefaultCSSTextStyle()
+			if tt.style != nil {
+				tt.style(&style)
+			}
+
+			got := txt.RenderCell(tt.text, tt.width, tt.maxLines, style)
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("RenderCell(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+			for i, line := range got {
+				if w := txt.Width(line); w != tt.width {
+					t.Errorf("line %d %q width %.1f, want %.1f", i, line, w, tt.width)
+				}
+			}
+		})
+	}
+}
@@ -2,8 +2,12 @@ package text
 
 import (
 	"strings"
+	"unicode"
+	"unicode/utf8"
 
+	"github.com/SCKelemen/unicode/v6/uax14"
 	"github.com/SCKelemen/unicode/v6/uax29"
+	"github.com/SCKelemen/units"
 )
 
 // Dictionary Support for Text Segmentation
@@ -33,9 +37,12 @@ type DictionaryProvider interface {
 	IsAbbreviation(word string) bool
 
 	// GetHyphenationPoints returns hyphenation points for a word.
-	// Returns slice of byte indices where hyphenation is allowed.
+	// Returns slice of rune indices where hyphenation is allowed: a point i
+	// means the word may break before its i-th rune. Points are never byte
+	// offsets, even for non-ASCII words.
 	//
 	// Example: "example" -> []int{2, 4} (ex-am-ple)
+	// Example: "cafétéria" -> break before "t" is 4, though it is byte 5
 	GetHyphenationPoints(word string) []int
 
 	// IsCompoundWord returns true if the word is a compound that shouldn't be broken.
@@ -180,6 +187,101 @@ func (d *EnglishDictionary) AddAbbreviations(abbrevs []string) {
 	}
 }
 
+// ═══════════════════════════════════════════════════════════════
+//  Built-in German and French Dictionaries
+// ═══════════════════════════════════════════════════════════════
+
+// AbbreviationDictionary provides the abbreviations of a single language.
+//
+// Abbreviations are stored without periods and lowercased, so "z.B.",
+// "zB." and "Z.B." all match. It has no hyphenation points or compound
+// words.
+type AbbreviationDictionary struct {
+	abbreviations map[string]bool
+}
+
+// NewAbbreviationDictionary creates a dictionary from a list of
+// abbreviations, written with or without their periods.
+//
+// Example:
+//
+//	dict := text.NewAbbreviationDictionary([]string{"Sr.", "Sra.", "p.ej."})
+func NewAbbreviationDictionary(abbrevs []string) *AbbreviationDictionary {
+	d := &AbbreviationDictionary{abbreviations: make(map[string]bool, len(abbrevs))}
+	d.AddAbbreviations(abbrevs)
+	return d
+}
+
+// NewGermanDictionary creates a dictionary with common German abbreviations.
+//
+// Example:
+//
+//	dict := text.NewGermanDictionary()
+//	sentences := txt.SentencesWithDictionary("Das kostet z.B. 5 Euro. Ende.", dict)
+//	// ["Das kostet z.B. 5 Euro. ", "Ende."]
+func NewGermanDictionary() *AbbreviationDictionary {
+	return NewAbbreviationDictionary([]string{
+		// Titles
+		"Dr.", "Prof.", "Hr.", "Fr.", "Dipl.", "Ing.", "St.",
+
+		// Common abbreviations
+		"z.B.", "u.a.", "usw.", "bzw.", "ca.", "d.h.", "evtl.", "ggf.",
+		"inkl.", "exkl.", "vgl.", "s.o.", "s.u.", "u.U.", "z.T.", "bspw.",
+		"etc.", "o.Ä.", "sog.", "zzgl.", "allg.",
+
+		// References and numbers
+		"Nr.", "Abs.", "Abb.", "Bd.", "Kap.", "S.", "Tel.", "Str.",
+		"Mio.", "Mrd.", "Jh.", "Jhd.",
+	})
+}
+
+// NewFrenchDictionary creates a dictionary with common French abbreviations.
+func NewFrenchDictionary() *AbbreviationDictionary {
+	return NewAbbreviationDictionary([]string{
+		// Titles
+		"M.", "MM.", "Mme", "Mmes", "Mlle", "Mlles", "Dr.", "Pr.", "Me",
+
+		// Common abbreviations
+		"cf.", "etc.", "p.ex.", "c.-à-d.", "env.", "éd.", "vol.", "fig.",
+		"p.", "pp.", "chap.", "coll.", "max.", "min.",
+
+		// Addresses
+		"av.", "bd.", "apt.", "ch.",
+	})
+}
+
+// IsAbbreviation implements DictionaryProvider.
+func (d *AbbreviationDictionary) IsAbbreviation(word string) bool {
+	return d.abbreviations[normalizeAbbreviation(word)]
+}
+
+// GetHyphenationPoints always returns nil.
+func (d *AbbreviationDictionary) GetHyphenationPoints(word string) []int {
+	return nil
+}
+
+// IsCompoundWord always returns false.
+func (d *AbbreviationDictionary) IsCompoundWord(word string) bool {
+	return false
+}
+
+// AddAbbreviation adds a custom abbreviation to the dictionary.
+func (d *AbbreviationDictionary) AddAbbreviation(abbrev string) {
+	d.abbreviations[normalizeAbbreviation(abbrev)] = true
+}
+
+// AddAbbreviations adds multiple custom abbreviations.
+func (d *AbbreviationDictionary) AddAbbreviations(abbrevs []string) {
+	for _, abbrev := range abbrevs {
+		d.AddAbbreviation(abbrev)
+	}
+}
+
+// normalizeAbbreviation removes all periods and lowercases word.
+func normalizeAbbreviation(word string) string {
+	return strings.ToLower(strings.ReplaceAll(word, ".", ""))
+}
+
 // ═══════════════════════════════════════════════════════════════
 //  Empty Dictionary (no filtering)
 // ═══════════════════════════════════════════════════════════════
@@ -226,29 +328,16 @@ func (t *Text) SentencesWithDictionary(text string, dict DictionaryProvider) []s
 		return rawSentences
 	}
 
-	// Post-process to merge sentences split on abbreviations
+	// Post-process to merge sentences split on abbreviations, decimal
+	// numbers and ellipses
 	var filtered []string
 	var current string
 
 	for i, sent := range rawSentences {
 		current += sent
 
-		// Check if this sentence ends with an abbreviation
-		endsWithAbbrev := false
-		trimmed := strings.TrimSpace(sent)
-		if strings.HasSuffix(trimmed, ".") {
-			// Get the last word before the period
-			words := strings.Fields(trimmed)
-			if len(words) > 0 {
-				lastWord := words[len(words)-1]
-				if dict.IsAbbreviation(lastWord) {
-					endsWithAbbrev = true
-				}
-			}
-		}
-
-		// If doesn't end with abbreviation, or is last sentence, finalize it
-		if !endsWithAbbrev || i == len(rawSentences)-1 {
+		// If it ends a sentence, or is last sentence, finalize it
+		if i == len(rawSentences)-1 || !continuesSentence(sent, rawSentences[i+1], dict) {
 			filtered = append(filtered, current)
 			current = ""
 		}
@@ -257,11 +346,148 @@ func (t *Text) SentencesWithDictionary(text string, dict DictionaryProvider) []s
 	return filtered
 }
 
+// continuesSentence reports whether the sentence break between sent and
+// next should be suppressed:
+//   - sent ends with a known abbreviation ("Dr.")
+//   - the period sits between two digits ("3.14", "v1.2.3")
+//   - sent ends with an ellipsis ("...", "..", "…"), which pauses rather
+//     than terminates
+func continuesSentence(sent, next string, dict DictionaryProvider) bool {
+	trimmed := strings.TrimSpace(sent)
+
+	if strings.HasSuffix(trimmed, "…") || strings.HasSuffix(trimmed, "..") {
+		return true
+	}
+
+	if !strings.HasSuffix(trimmed, ".") {
+		return false
+	}
+
+	// A period directly followed by a digit is a decimal or version separator
+	if trimmed == sent && len(trimmed) >= 2 && isASCIIDigit(trimmed[len(trimmed)-2]) &&
+		next != "" && isASCIIDigit(next[0]) {
+		return true
+	}
+
+	// Get the last word before the period
+	words := strings.Fields(trimmed)
+	return len(words) > 0 && dict.IsAbbreviation(words[len(words)-1])
+}
+
+// isASCIIDigit reports whether b is an ASCII digit.
+func isASCIIDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
+
 // SentenceCountWithDictionary returns the number of sentences using dictionary support.
 func (t *Text) SentenceCountWithDictionary(text string, dict DictionaryProvider) int {
 	return len(t.SentencesWithDictionary(text, dict))
 }
 
+// ═══════════════════════════════════════════════════════════════
+//  Dictionary-Aware Wrapping
+// ═══════════════════════════════════════════════════════════════
+
+// WrapWithDictionary wraps text like Wrap, using dict to adjust the break
+// opportunities inside words:
+//   - a word for which IsCompoundWord returns true ("TypeScript") is never
+//     broken or hyphenated, and moves to the next line whole
+//   - other words may also break at their GetHyphenationPoints, and a line
+//     ending at such a point gets a trailing hyphen that counts against
+//     MaxWidth
+//
+// A word is a run of non-space characters with leading and trailing
+// punctuation removed, so "(TypeScript)," is checked as "TypeScript". With
+// BreakWords, a word that does not fit even on a line of its own is still
+// split at grapheme boundaries, compound or not. A nil dict wraps like Wrap.
+//
+// Example:
+//
+//	txt := text.NewTerminal()
+//	dict := text.NewEnglishDictionaryWithHyphenation()
+//	lines := txt.WrapWithDictionary("Use TypeScript today", text.WrapOptions{MaxWidth: 10}, dict)
+//	// "Use ", "TypeScript ", "today" — never "Use Type-" / "Script"
+func (t *Text) WrapWithDictionary(text string, opts WrapOptions, dict DictionaryProvider) []Line {
+	if dict == nil || opts.MaxWidth <= 0 {
+		return t.Wrap(text, opts)
+	}
+
+	cssOpts := CSSWrapOptions{
+		MaxWidth: units.Px(opts.MaxWidth),
+		Style:    DefaultCSSTextStyle(),
+	}
+	if opts.BreakWords {
+		cssOpts.Style.OverflowWrap = OverflowWrapBreakWord
+	}
+
+	return wrapParagraphs(text, opts.PreserveNewlines, func(part string, baseRuneOffset int) []Line {
+		if part == "" {
+			return nil
+		}
+
+		breakPoints := uax14.FindLineBreakOpportunities(part, t.config.HyphenationMode)
+		breakPoints, hyphenBreaks := addHyphenationBreaks(part, breakPoints, dict.GetHyphenationPoints)
+		breakPoints = removeCompoundBreaks(part, breakPoints, dict)
+
+		lines := t.buildLinesFromBreakPoints(part, breakPoints, hyphenBreaks, cssOpts)
+		for i := range lines {
+			lines[i].Start += baseRuneOffset
+			lines[i].End += baseRuneOffset
+		}
+		return lines
+	})
+}
+
+// removeCompoundBreaks drops the break points (byte offsets) that fall
+// strictly inside a word dict reports as a compound.
+func removeCompoundBreaks(text string, breakPoints []int, dict DictionaryProvider) []int {
+	var compounds [][2]int
+	isPunct := func(r rune) bool { return unicode.IsPunct(r) || unicode.IsSymbol(r) }
+
+	for start := 0; start < len(text); {
+		end := strings.IndexFunc(text[start:], unicode.IsSpace)
+		if end < 0 {
+			end = len(text)
+		} else {
+			end += start
+		}
+		if end > start {
+			word := strings.TrimFunc(text[start:end], isPunct)
+			if word != "" && dict.IsCompoundWord(word) {
+				wordStart := start + strings.Index(text[start:end], word)
+				compounds = append(compounds, [2]int{wordStart, wordStart + len(word)})
+			}
+		}
+
+		// Skip the white space after the word
+		start = end
+		for start < len(text) {
+			r, size := utf8.DecodeRuneInString(text[start:])
+			if !unicode.IsSpace(r) {
+				break
+			}
+			start += size
+		}
+	}
+
+	if len(compounds) == 0 {
+		return breakPoints
+	}
+
+	kept := breakPoints[:0]
+	c := 0
+	for _, bp := range breakPoints {
+		for c < len(compounds) && compounds[c][1] <= bp {
+			c++
+		}
+		if c < len(compounds) && bp > compounds[c][0] && bp < compounds[c][1] {
+			continue
+		}
+		kept = append(kept, bp)
+	}
+	return kept
+}
+
 // ═══════════════════════════════════════════════════════════════
 //  Dictionary-Aware Text Configuration
 // ═══════════════════════════════════════════════════════════════
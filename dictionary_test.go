@@ -1,6 +1,7 @@
 package text
 
 import (
+	"reflect"
 	"testing"
 )
 
@@ -152,6 +153,157 @@ func TestSentencesWithDictionary(t *testing.T) {
 	}
 }
 
+func TestSentencesWithDictionary_NumbersAndEllipses(t *testing.T) {
+	txt := NewTerminal()
+	dict := NewEnglishDictionary()
+
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{
+			name:  "Decimal",
+			input: "The value is 3.14 meters. It is long.",
+			want:  []string{"The value is 3.14 meters. ", "It is long."},
+		},
+		{
+			name:  "Version string",
+			input: "Install v1.2.3 now. Then restart.",
+			want:  []string{"Install v1.2.3 now. ", "Then restart."},
+		},
+		{
+			name:  "Version at sentence end",
+			input: "Upgrade to 1.2.3. Then restart.",
+			want:  []string{"Upgrade to 1.2.3. ", "Then restart."},
+		},
+		{
+			name:  "Ellipsis before lowercase",
+			input: "He paused... then spoke.",
+			want:  []string{"He paused... then spoke."},
+		},
+		{
+			name:  "Ellipsis before uppercase",
+			input: "He paused... Then he spoke. Done.",
+			want:  []string{"He paused... Then he spoke. ", "Done."},
+		},
+		{
+			name:  "Two periods",
+			input: "Wait.. What? Yes.",
+			want:  []string{"Wait.. What? ", "Yes."},
+		},
+		{
+			name:  "Ellipsis character",
+			input: "Well… Maybe. Fine.",
+			want:  []string{"Well… Maybe. ", "Fine."},
+		},
+		{
+			name:  "Number ending a sentence",
+			input: "It costs 3. Then 4.",
+			want:  []string{"It costs 3. ", "Then 4."},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := txt.SentencesWithDictionary(tt.input, dict)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SentencesWithDictionary(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSentencesWithDictionary_Languages(t *testing.T) {
+	txt := NewTerminal()
+
+	tests := []struct {
+		name  string
+		dict  DictionaryProvider
+		input string
+		want  []string
+	}{
+		{
+			name:  "German z.B.",
+			dict:  NewGermanDictionary(),
+			input: "Das kostet z.B. 5 Euro. Ende.",
+			want:  []string{"Das kostet z.B. 5 Euro. ", "Ende."},
+		},
+		{
+			name:  "German Nr. and usw.",
+			dict:  NewGermanDictionary(),
+			input: "Siehe Nr. 7 und Dr. Weber usw. Danach weiter.",
+			want:  []string{"Siehe Nr. 7 und Dr. Weber usw. Danach weiter."},
+		},
+		{
+			name:  "German u.a.",
+			dict:  NewGermanDictionary(),
+			input: "Es kamen u.a. Anna und Ben. Alle lachten.",
+			want:  []string{"Es kamen u.a. Anna und Ben. ", "Alle lachten."},
+		},
+		{
+			name:  "French M.",
+			dict:  NewFrenchDictionary(),
+			input: "M. Dupont est arrivé. Il pleut.",
+			want:  []string{"M. Dupont est arrivé. ", "Il pleut."},
+		},
+		{
+			name:  "French cf. and p.ex.",
+			dict:  NewFrenchDictionary(),
+			input: "Voir cf. Annexe B, p.ex. Tableau 2. Fin.",
+			want:  []string{"Voir cf. Annexe B, p.ex. Tableau 2. ", "Fin."},
+		},
+		{
+			name:  "English dictionary does not know z.B.",
+			dict:  NewEnglishDictionary(),
+			input: "Das kostet z.B. 5 Euro. Ende.",
+			want:  []string{"Das kostet z.B. ", "5 Euro. ", "Ende."},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := txt.SentencesWithDictionary(tt.input, tt.dict)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SentencesWithDictionary(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAbbreviationDictionary(t *testing.T) {
+	de := NewGermanDictionary()
+	fr := NewFrenchDictionary()
+
+	tests := []struct {
+		name string
+		dict *AbbreviationDictionary
+		word string
+		want bool
+	}{
+		{"German z.B.", de, "z.B.", true},
+		{"German uppercase", de, "Z.B.", true},
+		{"German usw.", de, "usw.", true},
+		{"German word", de, "Haus.", false},
+		{"French Mme without period", fr, "Mme", true},
+		{"French p.ex.", fr, "p.ex.", true},
+		{"French word", fr, "maison.", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.dict.IsAbbreviation(tt.word); got != tt.want {
+				t.Errorf("IsAbbreviation(%q) = %v, want %v", tt.word, got, tt.want)
+			}
+		})
+	}
+
+	de.AddAbbreviation("Fa.")
+	if !de.IsAbbreviation("fa.") {
+		t.Error("AddAbbreviation(\"Fa.\") not recognized")
+	}
+}
+
 func TestSentencesWithDictionary_CustomAbbreviations(t *testing.T) {
 	txt := NewTerminal()
 	dict := NewEnglishDictionary()
@@ -327,3 +479,102 @@ func BenchmarkIsAbbreviation(b *testing.B) {
 		dict.IsAbbreviation("Hello")
 	}
 }
+
+// ═══════════════════════════════════════════════════════════════
+//  Dictionary-Aware Wrapping Tests
+// ═══════════════════════════════════════════════════════════════
+
+// hyphenatingDictionary hyphenates like EnglishDictionaryWithHyphenation
+// but knows no compound words.
+type hyphenatingDictionary struct {
+	*EnglishDictionaryWithHyphenation
+}
+
+func (hyphenatingDictionary) IsCompoundWord(word string) bool {
+	return false
+}
+
+func TestWrapWithDictionary(t *testing.T) {
+	txt := NewTerminal()
+	english := NewEnglishDictionaryWithHyphenation()
+	plain := hyphenatingDictionary{english}
+
+	tests := []struct {
+		name  string
+		input string
+		opts  WrapOptions
+		dict  DictionaryProvider
+		want  []string
+	}{
+		{
+			name:  "Compound kept whole",
+			input: "Use TypeScript today",
+			opts:  WrapOptions{MaxWidth: 12},
+			dict:  english,
+			want:  []string{"Use ", "TypeScript ", "today"},
+		},
+		{
+			name:  "Same word hyphenated when not a compound",
+			input: "Use TypeScript today",
+			opts:  WrapOptions{MaxWidth: 12},
+			dict:  plain,
+			want:  []string{"Use TypeSc-", "ript today"},
+		},
+		{
+			name:  "Other words still hyphenate",
+			input: "TypeScript today",
+			opts:  WrapOptions{MaxWidth: 14},
+			dict:  english,
+			want:  []string{"TypeScript to-", "day"},
+		},
+		{
+			name:  "Compound inside punctuation",
+			input: "Try (TypeScript), ok",
+			opts:  WrapOptions{MaxWidth: 12},
+			dict:  english,
+			want:  []string{"Try ", "(TypeScript), ", "ok"},
+		},
+		{
+			name:  "Preserve newlines",
+			input: "Use\nTypeScript",
+			opts:  WrapOptions{MaxWidth: 8, PreserveNewlines: true},
+			dict:  english,
+			want:  []string{"Use", "TypeScript"},
+		},
+		{
+			name:  "Nil dictionary wraps like Wrap",
+			input: "Use TypeScript today",
+			opts:  WrapOptions{MaxWidth: 12},
+			dict:  nil,
+			want:  []string{"Use ", "TypeScript ", "today"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines := txt.WrapWithDictionary(tt.input, tt.opts, tt.dict)
+			var got []string
+			for _, line := range lines {
+				got = append(got, line.Content)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("WrapWithDictionary(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWrapWithDictionary_RuneIndices(t *testing.T) {
+	txt := NewTerminal()
+	input := "Café\nUse TypeScript"
+
+	lines := txt.WrapWithDictionary(input, WrapOptions{MaxWidth: 12, PreserveNewlines: true}, NewEnglishDictionaryWithHyphenation())
+	want := []Line{
+		{Content: "Café", Width: 4, Start: 0, End: 4, BreakType: BreakHard},
+		{Content: "Use ", Width: 4, Start: 5, End: 9, BreakType: BreakSoft},
+		{Content: "TypeScript", Width: 10, Start: 9, End: 19},
+	}
+	if !reflect.DeepEqual(lines, want) {
+		t.Errorf("WrapWithDictionary(%q) = %+v, want %+v", input, lines, want)
+	}
+}
@@ -1,8 +1,10 @@
 package text
 
 import (
+	"math"
 	"net/url"
 	"strings"
+	"unicode/utf8"
 )
 
 // Elision Convenience Functions
@@ -134,13 +136,16 @@ func (t *Text) ElidePath(path string, maxWidth float64) string {
 
 // ElideURL intelligently shortens URLs.
 //
-// Preserves the domain and important parts of the path.
+// The scheme, host, and final path segment are always kept. When the URL is
+// too wide, the query and fragment are dropped first, then the middle path
+// segments collapse into "…". If even the host and final segment do not
+// fit, or rawURL has no host, the URL is truncated in the middle instead.
 //
 // Example:
 //
 //	txt := text.NewTerminal()
 //	short := txt.ElideURL("https://example.com/very/long/path/to/resource", 35)
-//	// Returns: "https://example.com/.../resource"
+//	// Returns: "https://example.com/…/resource"
 func (t *Text) ElideURL(rawURL string, maxWidth float64) string {
 	if t.Width(rawURL) <= maxWidth {
 		return rawURL
@@ -148,7 +153,7 @@ func (t *Text) ElideURL(rawURL string, maxWidth float64) string {
 
 	parsed, err := url.Parse(rawURL)
 	if err != nil || parsed.Host == "" {
-		return t.Elide(rawURL, maxWidth)
+		return t.ElideWith(rawURL, maxWidth, "…")
 	}
 
 	prefix := parsed.Host
@@ -156,39 +161,335 @@ func (t *Text) ElideURL(rawURL string, maxWidth float64) string {
 		prefix = parsed.Scheme + "://" + parsed.Host
 	}
 
-	tail := ""
-	if parsed.Path != "" && parsed.Path != "/" {
-		trimmed := strings.Trim(parsed.Path, "/")
-		if trimmed != "" {
-			parts := strings.Split(trimmed, "/")
-			tail = "/" + parts[len(parts)-1]
+	// The path as written, without the query and fragment. EscapedPath
+	// would percent-encode non-ASCII segments.
+	path := ""
+	rest, _, _ := strings.Cut(rawURL, "#")
+	rest, _, _ = strings.Cut(rest, "?")
+	if _, authorityAndPath, ok := strings.Cut(rest, "//"); ok {
+		if i := strings.IndexByte(authorityAndPath, '/'); i >= 0 {
+			path = authorityAndPath[i:]
 		}
 	}
+	if withoutQuery := prefix + path; t.Width(withoutQuery) <= maxWidth {
+		return withoutQuery
+	}
+
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) > 1 {
+		tail := "/" + segments[len(segments)-1]
+		if strings.HasSuffix(path, "/") {
+			tail += "/"
+		}
+		if collapsed := prefix + "/…" + tail; t.Width(collapsed) <= maxWidth {
+			return collapsed
+		}
+	}
+
+	return t.ElideWith(rawURL, maxWidth, "…")
+}
+
+// ElideEmail shortens an email address while keeping the "@" and domain.
+//
+// The local part is elided first. If the address still does not fit with a
+// single character of the local part left, the domain is shortened too,
+// keeping its top-level domain. Text without an "@" is truncated in the
+// middle.
+//
+// Example:
+//
+//	txt := text.NewTerminal()
+//	txt.ElideEmail("verylongusername@somedomain.example.com", 30)
+//	// Returns: "verylo…@somedomain.example.com"
+//	txt.ElideEmail("verylongusername@somedomain.example.com", 12)
+//	// Returns: "v…@some….com"
+func (t *Text) ElideEmail(address string, maxWidth float64) string {
+	if t.Width(address) <= maxWidth {
+		return address
+	}
+
+	at := strings.LastIndex(address, "@")
+	if at <= 0 || at == len(address)-1 {
+		return t.ElideWith(address, maxWidth, "…")
+	}
+	local, domain := address[:at], address[at+1:]
+
+	if short, ok := t.elideEndKeeping(local, maxWidth-t.Width("@"+domain)); ok {
+		return short + "@" + domain
+	}
+
+	minLocal := local
+	if graphemes := t.Graphemes(local); len(graphemes) > 1 {
+		minLocal = graphemes[0] + "…"
+	}
+	if dot := strings.LastIndex(domain, "."); dot > 0 {
+		tld := domain[dot:]
+		avail := maxWidth - t.Width(minLocal+"@"+tld)
+		if short, ok := t.elideEndKeeping(domain[:dot], avail); ok {
+			return minLocal + "@" + short + tld
+		}
+	}
+
+	return t.ElideWith(address, maxWidth, "…")
+}
+
+// elideEndKeeping elides s at the end with "…" to fit maxWidth, keeping at
+// least its first grapheme. It reports false if that is not possible.
+func (t *Text) elideEndKeeping(s string, maxWidth float64) (string, bool) {
+	if t.Width(s) <= maxWidth {
+		return s, true
+	}
+
+	graphemes := t.Graphemes(s)
+	if len(graphemes) < 2 {
+		return "", false
+	}
+
+	short := t.ElideEndWith(s, maxWidth, "…")
+	if !strings.HasPrefix(short, graphemes[0]) || t.Width(short) > maxWidth {
+		return "", false
+	}
+	return short, true
+}
+
+// ═══════════════════════════════════════════════════════════════
+//  List Elision
+// ═══════════════════════════════════════════════════════════════
+
+// ElideList fits items into totalWidth when joined with sep, for breadcrumbs
+// and tag bars.
+//
+// The width left after separators is shared out in proportion to how much
+// each item would need to shrink, and items are elided at the end with "…".
+// Every item keeps at least its first grapheme. If the items cannot fit even
+// at that minimum, trailing items are dropped and replaced by a single "…"
+// item. The result is meant to be joined with sep.
+//
+// Example:
+//
+//	txt := text.NewTerminal()
+//	items := txt.ElideList([]string{"home", "documents", "projects", "report.pdf"}, 30, " > ")
+//	fmt.Println(strings.Join(items, " > "))
+//	// home > docum… > proj… > repor…
+func (t *Text) ElideList(items []string, totalWidth float64, sep string) []string {
+	if len(items) == 0 {
+		return nil
+	}
+
+	sepWidth := t.Width(sep)
+	widths := make([]float64, len(items))
+	minWidths := make([]float64, len(items))
+	total := sepWidth * float64(len(items)-1)
+	for i, item := range items {
+		widths[i] = t.Width(item)
+		minWidths[i] = widths[i]
+		if graphemes := t.Graphemes(item); len(graphemes) > 1 {
+			minWidths[i] = min(widths[i], t.Width(graphemes[0]+"…"))
+		}
+		total += widths[i]
+	}
+
+	if total <= totalWidth {
+		return append([]string(nil), items...)
+	}
+
+	// Keep as many leading items as fit at their minimum width, followed
+	// by an omission marker when some are dropped.
+	keep, used := 0, 0.0
+	for keep < len(items) {
+		need := used + minWidths[keep]
+		if keep > 0 {
+			need += sepWidth
+		}
+		if keep < len(items)-1 {
+			need += sepWidth + t.Width("…")
+		}
+		if need > totalWidth {
+			break
+		}
+		used += minWidths[keep]
+		if keep > 0 {
+			used += sepWidth
+		}
+		keep++
+	}
+
+	budget := totalWidth
+	if keep < len(items) {
+		budget -= t.Width("…")
+		if keep > 0 {
+			budget -= sepWidth
+		}
+	}
+	budget -= sepWidth * float64(max(keep-1, 0))
+
+	var minTotal, slackTotal float64
+	for i := 0; i < keep; i++ {
+		minTotal += minWidths[i]
+		slackTotal += widths[i] - minWidths[i]
+	}
+	extra := budget - minTotal
+
+	out := make([]string, 0, keep+1)
+	leftover := budget
+	for i := 0; i < keep; i++ {
+		alloc := widths[i]
+		if slack := widths[i] - minWidths[i]; extra < slackTotal && slack > 0 {
+			alloc = minWidths[i] + extra*slack/slackTotal
+		}
+		out = append(out, t.ElideEndWith(items[i], alloc, "…"))
+		leftover -= t.Width(out[i])
+	}
+
+	// Elision stops at grapheme boundaries, so hand the unused width back
+	// to the items in order.
+	for i := 0; i < keep && leftover > 0; i++ {
+		if out[i] == items[i] {
+			continue
+		}
+		before := t.Width(out[i])
+		out[i] = t.ElideEndWith(items[i], before+leftover, "…")
+		leftover -= t.Width(out[i]) - before
+	}
+	if keep < len(items) && t.Width("…") <= totalWidth {
+		out = append(out, "…")
+	}
+	return out
+}
+
+// ═══════════════════════════════════════════════════════════════
+//  Distinctive Elision
+// ═══════════════════════════════════════════════════════════════
+
+// ElideDistinctive shortens text while keeping the part that distinguishes
+// it from its peers visible.
+//
+// Middle truncation of similar strings (paths, branch names, log keys) can
+// hide exactly the part that differs. ElideDistinctive finds the prefix and
+// suffix that text shares with all peers, widens the differing part to
+// whole words, and spends the remaining width on the shared context around
+// it, eliding the start of the shared prefix and the end of the shared
+// suffix as needed.
+//
+// Elided sides are marked with "…", like ElideURL and ElideEmail. If there
+// are no peers or nothing differs, it elides the middle as ElideWith does.
+// If even the distinctive part does not fit, that part alone is elided.
+//
+// Example:
+//
+//	txt := text.NewTerminal()
+//	peers := []string{
+//	    "/srv/builds/project/alpha/logs/output.txt",
+//	    "/srv/builds/project/beta/logs/output.txt",
+//	}
+//	txt.ElideDistinctive("/srv/builds/project/gamma/logs/output.txt", peers, 24)
+//	// Returns: "…/project/gamma/logs/ou…"
+func (t *Text) ElideDistinctive(text string, peers []string, maxWidth float64) string {
+	if t.Width(text) <= maxWidth {
+		return text
+	}
+
+	all := append([]string{text}, peers...)
+	prefix := t.CommonGraphemePrefix(all...)
+	suffix := t.CommonGraphemeSuffix(all...)
+	if len(prefix)+len(suffix) > len(text) {
+		suffix = suffix[len(prefix)+len(suffix)-len(text):]
+	}
+	if len(peers) == 0 || len(prefix)+len(suffix) == len(text) {
+		return t.ElideWith(text, maxWidth, "…")
+	}
 
-	// If path has no useful tail, keep a hint that query/fragment content exists.
-	if tail == "" && parsed.RawQuery != "" {
-		tail = "/?"
+	// Widen the distinctive part to whole words so "alpha" is not shown
+	// as "alph" just because a peer also ends in "a".
+	prefixGraphemes := t.Graphemes(prefix)
+	for len(prefixGraphemes) > 0 && isWordGrapheme(prefixGraphemes[len(prefixGraphemes)-1]) {
+		prefixGraphemes = prefixGraphemes[:len(prefixGraphemes)-1]
+	}
+	suffixGraphemes := t.Graphemes(suffix)
+	for len(suffixGraphemes) > 0 && isWordGrapheme(suffixGraphemes[0]) {
+		suffixGraphemes = suffixGraphemes[1:]
 	}
-	if tail == "" && parsed.Fragment != "" {
-		tail = "/#"
+	prefix = strings.Join(prefixGraphemes, "")
+	suffix = strings.Join(suffixGraphemes, "")
+	distinct := text[len(prefix) : len(text)-len(suffix)]
+
+	ellipsis := "…"
+	ellipsisWidth := t.Width(ellipsis)
+	distinctWidth := t.Width(distinct)
+
+	// Each elided side needs room for the ellipsis plus one grapheme.
+	if distinctWidth+2*(ellipsisWidth+1) > maxWidth {
+		if distinctWidth+2*ellipsisWidth <= maxWidth {
+			return ellipsis + distinct + ellipsis
+		}
+		return t.ElideWith(distinct, maxWidth, "…")
 	}
 
-	withTail := prefix + "/..." + tail
-	if t.Width(withTail) <= maxWidth {
-		return withTail
+	// Split the remaining width between the two sides; a side that needs
+	// less than its half gives the rest to the other.
+	remaining := maxWidth - distinctWidth
+	prefixWidth, suffixWidth := t.Width(prefix), t.Width(suffix)
+	suffixBudget := math.Min(suffixWidth, math.Floor(remaining/2))
+	prefixBudget := math.Min(prefixWidth, remaining-suffixBudget)
+	suffixBudget = math.Min(suffixWidth, remaining-prefixBudget)
+
+	return t.Truncate(prefix, TruncateOptions{MaxWidth: prefixBudget, Strategy: TruncateStart, Ellipsis: ellipsis}) +
+		distinct +
+		t.Truncate(suffix, TruncateOptions{MaxWidth: suffixBudget, Strategy: TruncateEnd, Ellipsis: ellipsis})
+}
+
+// CommonGraphemePrefix returns the longest prefix shared by all strings,
+// ending on a grapheme cluster boundary in each of them.
+//
+// Example:
+//
+//	txt.CommonGraphemePrefix("café", "cafe\u0301") // "caf"
+func (t *Text) CommonGraphemePrefix(strs ...string) string {
+	if len(strs) == 0 {
+		return ""
+	}
+
+	common := t.Graphemes(strs[0])
+	for _, s := range strs[1:] {
+		graphemes := t.Graphemes(s)
+		n := 0
+		for n < len(common) && n < len(graphemes) && common[n] == graphemes[n] {
+			n++
+		}
+		common = common[:n]
 	}
+	return strings.Join(common, "")
+}
 
-	withoutTail := prefix + "/..."
-	if t.Width(withoutTail) <= maxWidth {
-		return withoutTail
+// CommonGraphemeSuffix returns the longest suffix shared by all strings,
+// starting on a grapheme cluster boundary in each of them.
+//
+// Example:
+//
+//	txt.CommonGraphemeSuffix("main.go", "text.go") // ".go"
+func (t *Text) CommonGraphemeSuffix(strs ...string) string {
+	if len(strs) == 0 {
+		return ""
 	}
 
-	hostOnly := parsed.Host + "/..."
-	if t.Width(hostOnly) <= maxWidth {
-		return hostOnly
+	common := t.Graphemes(strs[0])
+	for _, s := range strs[1:] {
+		graphemes := t.Graphemes(s)
+		n := 0
+		for n < len(common) && n < len(graphemes) &&
+			common[len(common)-1-n] == graphemes[len(graphemes)-1-n] {
+			n++
+		}
+		common = common[len(common)-n:]
 	}
+	return strings.Join(common, "")
+}
 
-	return t.Elide(rawURL, maxWidth)
+// isWordGrapheme reports whether a grapheme cluster starts with a letter
+// or digit.
+func isWordGrapheme(g string) bool {
+	r, _ := utf8.DecodeRuneInString(g)
+	return isLetterOrDigit(r)
 }
 
 // ═══════════════════════════════════════════════════════════════
@@ -230,6 +531,29 @@ func (t *Text) ElideStartWith(text string, maxWidth float64, ellipsis string) st
 	})
 }
 
+// ElideStartCount shortens text at the start like ElideStartWith, keeping
+// the most recent tail of a long line (as in a scrolling log view), and
+// also reports how many leading runes were dropped. A rune index i in the
+// kept tail is then rune i+droppedRunes in text, so clicks on the elided
+// line map back to the source. Grapheme clusters are never split.
+//
+// Example:
+//
+//	txt := text.NewTerminal()
+//	tail, dropped := txt.ElideStartCount("2024-01-01 server started 服务器", 12, "…")
+//	// tail = "…rted 服务器", dropped = 21 ("2024-01-01 server sta")
+func (t *Text) ElideStartCount(text string, maxWidth float64, ellipsis string) (string, int) {
+	elided := t.ElideStartWith(text, maxWidth, ellipsis)
+	if elided == text {
+		return text, 0
+	}
+	if ellipsis == "" {
+		ellipsis = "..." // Truncate's default
+	}
+	kept := strings.TrimPrefix(elided, ellipsis)
+	return elided, utf8.RuneCountInString(text) - utf8.RuneCountInString(kept)
+}
+
 // ═══════════════════════════════════════════════════════════════
 //  Unicode-Aware Ellipsis
 // ═══════════════════════════════════════════════════════════════
@@ -310,7 +634,7 @@ func (t *Text) ElideForContext(text string, maxWidth float64, context ElideConte
 		return t.ElideURL(text, maxWidth)
 
 	case ElideContextEmail:
-		return t.Elide(text, maxWidth)
+		return t.ElideEmail(text, maxWidth)
 
 	case ElideContextDescription:
 		return t.ElideEnd(text, maxWidth)
@@ -1,6 +1,7 @@
 package text
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -116,18 +117,79 @@ func TestElideStart(t *testing.T) {
 func TestElideURL(t *testing.T) {
 	txt := NewTerminal()
 
-	t.Run("Preserves host and final path segment", func(t *testing.T) {
-		input := "https://example.com/very/long/path/to/resource"
-		got := txt.ElideURL(input, 35)
-		want := "https://example.com/.../resource"
+	tests := []struct {
+		name     string
+		url      string
+		maxWidth float64
+		want     string
+	}{
+		{
+			name:     "Preserves host and final path segment",
+			url:      "https://example.com/very/long/path/to/resource",
+			maxWidth: 35,
+			want:     "https://example.com/…/resource",
+		},
+		{
+			name:     "Fits unchanged",
+			url:      "https://example.com/a/b",
+			maxWidth: 35,
+			want:     "https://example.com/a/b",
+		},
+		{
+			name:     "Drops query first",
+			url:      "https://example.com/docs/page?utm_source=newsletter&id=42",
+			maxWidth: 30,
+			want:     "https://example.com/docs/page",
+		},
+		{
+			name:     "Drops query then collapses path",
+			url:      "https://example.com/a/long/path/page.html?x=1#top",
+			maxWidth: 32,
+			want:     "https://example.com/…/page.html",
+		},
+		{
+			name:     "Unicode path segments",
+			url:      "https://example.com/文档/很长的路径/目录/文件名",
+			maxWidth: 30,
+			want:     "https://example.com/…/文件名",
+		},
+		{
+			name:     "Unicode path without query",
+			url:      "https://example.com/文档/页面?id=42",
+			maxWidth: 32,
+			want:     "https://example.com/文档/页面",
+		},
+		{
+			name:     "Keeps trailing slash",
+			url:      "https://example.com/very/long/path/dir/",
+			maxWidth: 30,
+			want:     "https://example.com/…/dir/",
+		},
+		{
+			name:     "No path",
+			url:      "https://example.com?session=abcdef0123456789",
+			maxWidth: 25,
+			want:     "https://example.com",
+		},
+		{
+			name:     "Host and filename too wide",
+			url:      "https://example.com/very/long/path/to/resource",
+			maxWidth: 20,
+			want:     "https://e…/resource",
+		},
+	}
 
-		if got != want {
-			t.Fatalf("ElideURL() = %q, want %q", got, want)
-		}
-		if txt.Width(got) > 35 {
-			t.Fatalf("ElideURL() width %.1f exceeds maxWidth 35", txt.Width(got))
-		}
-	})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := txt.ElideURL(tt.url, tt.maxWidth)
+			if got != tt.want {
+				t.Errorf("ElideURL(%q, %.0f) = %q, want %q", tt.url, tt.maxWidth, got, tt.want)
+			}
+			if txt.Width(got) > tt.maxWidth {
+				t.Errorf("ElideURL() width %.1f exceeds maxWidth %.0f", txt.Width(got), tt.maxWidth)
+			}
+		})
+	}
 
 	t.Run("Falls back to generic elision for non-URL text", func(t *testing.T) {
 		input := "not-a-url/with/slashes/and/a/long-tail"
@@ -142,6 +204,45 @@ func TestElideURL(t *testing.T) {
 	})
 }
 
+func TestElideEmail(t *testing.T) {
+	txt := NewTerminal()
+
+	tests := []struct {
+		name     string
+		address  string
+		maxWidth float64
+		want     string
+	}{
+		{"Fits", "user@example.com", 20, "user@example.com"},
+		{"Long local part", "verylongusername@somedomain.example.com", 30, "verylo…@somedomain.example.com"},
+		{"Local part at minimum", "verylongusername@somedomain.example.com", 25, "v…@somedomain.example.com"},
+		{"Domain keeps TLD", "verylongusername@somedomain.example.com", 20, "v…@somedomain.e….com"},
+		{"Domain shortened further", "verylongusername@somedomain.example.com", 12, "v…@some….com"},
+		{"Long domain", "bob@a-really-long-subdomain.example.co.uk", 20, "b…@a-really-long….uk"},
+		{"Short domain", "verylongusername@x.io", 12, "verylo…@x.io"},
+		{"Too narrow for domain", "verylongusername@somedomain.example.com", 6, "ve…om"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := txt.ElideEmail(tt.address, tt.maxWidth)
+			if got != tt.want {
+				t.Errorf("ElideEmail(%q, %.0f) = %q, want %q", tt.address, tt.maxWidth, got, tt.want)
+			}
+			if txt.Width(got) > tt.maxWidth {
+				t.Errorf("ElideEmail() width %.1f exceeds maxWidth %.0f", txt.Width(got), tt.maxWidth)
+			}
+		})
+	}
+
+	t.Run("ElideAuto routes emails", func(t *testing.T) {
+		got := txt.ElideAuto("verylongusername@somedomain.example.com", 30)
+		if want := "verylo…@somedomain.example.com"; got != want {
+			t.Errorf("ElideAuto() = %q, want %q", got, want)
+		}
+	})
+}
+
 // ═══════════════════════════════════════════════════════════════
 //  Custom Ellipsis Tests
 // ═══════════════════════════════════════════════════════════════
@@ -182,6 +283,43 @@ func TestElideWith(t *testing.T) {
 	}
 }
 
+func TestElideStartCount(t *testing.T) {
+	txt := NewTerminal()
+
+	tests := []struct {
+		name        string
+		input       string
+		maxWidth    float64
+		ellipsis    string
+		want        string
+		wantDropped int
+	}{
+		{"Trailing CJK", "2024-01-01 server started 服务器", 12, "…", "…rted 服务器", 21},
+		{"CJK never split", "abc世界你好", 6, "…", "…你好", 5},
+		{"Emoji cluster kept whole", "log line 👨‍👩‍👧", 4, "…", "… 👨‍👩‍👧", 8},
+		{"Fits", "short", 10, "…", "short", 0},
+		{"Default ellipsis", "abcdefghij", 6, "", "...hij", 7},
+		{"Ellipsis too wide", "abcdef", 2, "...", "", 6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, dropped := txt.ElideStartCount(tt.input, tt.maxWidth, tt.ellipsis)
+			if got != tt.want || dropped != tt.wantDropped {
+				t.Errorf("ElideStartCount(%q, %.0f) = %q, %d; want %q, %d",
+					tt.input, tt.maxWidth, got, dropped, tt.want, tt.wantDropped)
+			}
+			if w := txt.Width(got); w > tt.maxWidth {
+				t.Errorf("width %.1f exceeds %.1f", w, tt.maxWidth)
+			}
+			// The kept tail is the source from droppedRunes on
+			if got != "" && got != tt.input && !strings.HasSuffix(got, string([]rune(tt.input)[dropped:])) {
+				t.Errorf("%q does not end with source from rune %d", got, dropped)
+			}
+		})
+	}
+}
+
 func TestElideUnicode(t *testing.T) {
 	txt := NewTerminal()
 
@@ -317,7 +455,7 @@ func TestElideAuto(t *testing.T) {
 			// Should contain ellipsis
 			hasEllipsis := false
 			for _, r := range got {
-				if r == '.' {
+				if r == '.' || r == '…' {
 					hasEllipsis = true
 					break
 				}
@@ -330,6 +468,141 @@ func TestElideAuto(t *testing.T) {
 	}
 }
 
+// ═══════════════════════════════════════════════════════════════
+//  List Elision Tests
+// ═══════════════════════════════════════════════════════════════
+
+func TestElideList(t *testing.T) {
+	txt := NewTerminal()
+	crumbs := []string{"home", "documents", "projects", "report.pdf"}
+
+	tests := []struct {
+		name       string
+		items      []string
+		totalWidth float64
+		sep        string
+		want       []string
+	}{
+		{"Fits", crumbs, 40, " > ", crumbs},
+		{"Proportional", crumbs, 30, " > ", []string{"home", "docum…", "proj…", "repor…"}},
+		{"Near minimum", crumbs, 20, " > ", []string{"home", "d…", "p…", "re…"}},
+		{"Drops trailing items", crumbs, 15, " > ", []string{"ho…", "docu…", "…"}},
+		{"Only omission marker", crumbs, 3, " > ", []string{"…"}},
+		{"Nothing fits", crumbs, 0, " > ", []string{}},
+		{"Wide graphemes", []string{"世界世界", "a", "b"}, 6, "/", []string{"世…", "…"}},
+		{"Empty", nil, 10, "/", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := txt.ElideList(tt.items, tt.totalWidth, tt.sep)
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") || len(got) != len(tt.want) {
+				t.Errorf("ElideList(%.0f) = %q, want %q", tt.totalWidth, got, tt.want)
+			}
+			if w := txt.Width(strings.Join(got, tt.sep)); w > tt.totalWidth {
+				t.Errorf("joined width %.1f exceeds %.0f", w, tt.totalWidth)
+			}
+		})
+	}
+}
+
+// ═══════════════════════════════════════════════════════════════
+//  Distinctive Elision Tests
+// ═══════════════════════════════════════════════════════════════
+
+func TestElideDistinctive(t *testing.T) {
+	txt := NewTerminal()
+
+	paths := []string{
+		"/srv/builds/project/alpha/logs/output.txt",
+		"/srv/builds/project/beta/logs/output.txt",
+		"/srv/builds/project/gamma/logs/output.txt",
+	}
+	words := []string{"alpha", "beta", "gamma"}
+
+	for i, path := range paths {
+		var peers []string
+		for j, p := range paths {
+			if j != i {
+				peers = append(peers, p)
+			}
+		}
+
+		for _, maxWidth := range []float64{12, 18, 24, 30} {
+			got := txt.ElideDistinctive(path, peers, maxWidth)
+
+			if !strings.Contains(got, words[i]) {
+				t.Errorf("ElideDistinctive(%q, %.0f) = %q, lost %q", path, maxWidth, got, words[i])
+			}
+			if w := txt.Width(got); w > maxWidth {
+				t.Errorf("ElideDistinctive(%q, %.0f) = %q, width %.1f exceeds max", path, maxWidth, got, w)
+			}
+			if strings.Contains(got, "...") {
+				t.Errorf("ElideDistinctive(%q, %.0f) = %q, want \"…\" as the ellipsis", path, maxWidth, got)
+			}
+		}
+
+		// Plain middle elision hides the distinguishing directory.
+		if strings.Contains(txt.Elide(path, 24), words[i]) {
+			t.Errorf("Elide(%q, 24) unexpectedly kept %q", path, words[i])
+		}
+	}
+
+	t.Run("Keeps context on both sides", func(t *testing.T) {
+		got := txt.ElideDistinctive(paths[2], paths[:2], 24)
+		if got != "…/project/gamma/logs/ou…" {
+			t.Errorf("got %q, want %q", got, "…/project/gamma/logs/ou…")
+		}
+	})
+
+	t.Run("Only the distinctive part fits", func(t *testing.T) {
+		if got := txt.ElideDistinctive(paths[2], paths[:2], 7); got != "…gamma…" {
+			t.Errorf("got %q, want %q", got, "…gamma…")
+		}
+	})
+
+	t.Run("Fits unchanged", func(t *testing.T) {
+		if got := txt.ElideDistinctive(paths[0], paths[1:], 100); got != paths[0] {
+			t.Errorf("got %q, want unchanged", got)
+		}
+	})
+
+	t.Run("No peers falls back to middle elision", func(t *testing.T) {
+		if got, want := txt.ElideDistinctive(paths[0], nil, 20), txt.ElideWith(paths[0], 20, "…"); got != want {
+			t.Errorf("got %q, want %q", got, want)
+		}
+	})
+}
+
+func TestCommonGraphemePrefixSuffix(t *testing.T) {
+	txt := NewTerminal()
+
+	tests := []struct {
+		name   string
+		strs   []string
+		prefix string
+		suffix string
+	}{
+		{"Empty set", nil, "", ""},
+		{"Single string", []string{"abc"}, "abc", "abc"},
+		{"Shared ends", []string{"main.go", "mail.go"}, "mai", ".go"},
+		{"Nothing shared", []string{"abc", "xyz"}, "", ""},
+		{"Combining mark not split", []string{"cafe\u0301s", "cafes"}, "caf", "s"},
+		{"Emoji cluster", []string{"👨‍👩‍👧 a", "👨‍👩‍👦 a"}, "", " a"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := txt.CommonGraphemePrefix(tt.strs...); got != tt.prefix {
+				t.Errorf("CommonGraphemePrefix = %q, want %q", got, tt.prefix)
+			}
+			if got := txt.CommonGraphemeSuffix(tt.strs...); got != tt.suffix {
+				t.Errorf("CommonGraphemeSuffix = %q, want %q", got, tt.suffix)
+			}
+		})
+	}
+}
+
 // ═══════════════════════════════════════════════════════════════
 //  Unicode Text Elision Tests
 // ═══════════════════════════════════════════════════════════════
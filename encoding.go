@@ -0,0 +1,122 @@
+package text
+
+import (
+	"bytes"
+	"errors"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// Byte Order Mark and Encoding Hints
+//
+// Text read from files frequently starts with a byte order mark (BOM).
+// A UTF-8 BOM (EF BB BF) is just U+FEFF encoded as UTF-8 and can be dropped;
+// a UTF-16 BOM means the bytes are not UTF-8 at all and must be decoded
+// before any width or wrapping operation is meaningful.
+//
+// References:
+//   - Unicode FAQ on BOM: https://www.unicode.org/faq/utf_bom.html#BOM
+//   - WHATWG Encoding (BOM sniffing): https://encoding.spec.whatwg.org/#decode
+
+// ═══════════════════════════════════════════════════════════════
+//  Encoding Detection
+// ═══════════════════════════════════════════════════════════════
+
+// Encoding identifies the text encoding signalled by a byte order mark.
+type Encoding int
+
+const (
+	// EncodingUTF8 is UTF-8, with or without a BOM.
+	// This is the assumed encoding when no BOM is present.
+	EncodingUTF8 Encoding = iota
+
+	// EncodingUTF16LE is little-endian UTF-16 (BOM FF FE).
+	EncodingUTF16LE
+
+	// EncodingUTF16BE is big-endian UTF-16 (BOM FE FF).
+	EncodingUTF16BE
+)
+
+var (
+	bomUTF8    = []byte{0xEF, 0xBB, 0xBF}
+	bomUTF16LE = []byte{0xFF, 0xFE}
+	bomUTF16BE = []byte{0xFE, 0xFF}
+)
+
+// ErrInvalidUTF16 is returned by DecodeBOM when UTF-16 input has an odd number of bytes.
+var ErrInvalidUTF16 = errors.New("text: UTF-16 input has odd byte length")
+
+// DetectEncoding reports the encoding indicated by a leading BOM and the BOM length in bytes.
+//
+// Input without a BOM is reported as EncodingUTF8 with a BOM length of 0.
+//
+// Example:
+//
+//	enc, n := text.DetectEncoding([]byte("\xFF\xFEh\x00i\x00"))
+//	// enc = text.EncodingUTF16LE, n = 2
+func DetectEncoding(b []byte) (enc Encoding, bomLen int) {
+	switch {
+	case bytes.HasPrefix(b, bomUTF8):
+		return EncodingUTF8, len(bomUTF8)
+	case bytes.HasPrefix(b, bomUTF16LE):
+		return EncodingUTF16LE, len(bomUTF16LE)
+	case bytes.HasPrefix(b, bomUTF16BE):
+		return EncodingUTF16BE, len(bomUTF16BE)
+	default:
+		return EncodingUTF8, 0
+	}
+}
+
+// StripBOM removes a leading UTF-8 byte order mark.
+//
+// Input without a UTF-8 BOM is returned unchanged. UTF-16 input is also
+// returned unchanged; use DecodeBOM to convert it to UTF-8.
+//
+// Example:
+//
+//	b := text.StripBOM([]byte("\xEF\xBB\xBFHello"))
+//	// b = []byte("Hello")
+func StripBOM(b []byte) []byte {
+	return bytes.TrimPrefix(b, bomUTF8)
+}
+
+// DecodeBOM converts BOM-prefixed input to UTF-8 without the BOM.
+//
+// UTF-8 input has its BOM stripped. UTF-16 input (LE or BE, detected from
+// the BOM) is decoded to UTF-8; unpaired surrogates become U+FFFD.
+// Returns ErrInvalidUTF16 if UTF-16 input has an odd number of bytes.
+//
+// Example:
+//
+//	b, err := text.DecodeBOM([]byte("\xFF\xFEh\x00i\x00"))
+//	// b = []byte("hi"), err = nil
+func DecodeBOM(b []byte) ([]byte, error) {
+	enc, bomLen := DetectEncoding(b)
+	b = b[bomLen:]
+
+	switch enc {
+	case EncodingUTF16LE, EncodingUTF16BE:
+		if len(b)%2 != 0 {
+			return nil, ErrInvalidUTF16
+		}
+
+		units := make([]uint16, len(b)/2)
+		for i := range units {
+			if enc == EncodingUTF16LE {
+				units[i] = uint16(b[2*i]) | uint16(b[2*i+1])<<8
+			} else {
+				units[i] = uint16(b[2*i])<<8 | uint16(b[2*i+1])
+			}
+		}
+
+		runes := utf16.Decode(units)
+		out := make([]byte, 0, len(runes))
+		for _, r := range runes {
+			out = utf8.AppendRune(out, r)
+		}
+		return out, nil
+
+	default:
+		return b, nil
+	}
+}
@@ -0,0 +1,98 @@
+package text
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestStripBOM(t *testing.T) {
+	tests := []struct {
+		name string
+		in   []byte
+		want string
+	}{
+		{"UTF-8 BOM", []byte("\xEF\xBB\xBFHello"), "Hello"},
+		{"No BOM", []byte("Hello"), "Hello"},
+		{"Empty", nil, ""},
+		{"UTF-16LE BOM untouched", []byte("\xFF\xFEh\x00"), "\xFF\xFEh\x00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := string(StripBOM(tt.in))
+			if got != tt.want {
+				t.Errorf("StripBOM(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDetectEncoding(t *testing.T) {
+	tests := []struct {
+		name       string
+		in         []byte
+		wantEnc    Encoding
+		wantBOMLen int
+	}{
+		{"UTF-8 BOM", []byte("\xEF\xBB\xBFHi"), EncodingUTF8, 3},
+		{"UTF-16LE BOM", []byte("\xFF\xFEH\x00"), EncodingUTF16LE, 2},
+		{"UTF-16BE BOM", []byte("\xFE\xFF\x00H"), EncodingUTF16BE, 2},
+		{"No BOM", []byte("Hi"), EncodingUTF8, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			enc, n := DetectEncoding(tt.in)
+			if enc != tt.wantEnc || n != tt.wantBOMLen {
+				t.Errorf("DetectEncoding(%q) = (%d, %d), want (%d, %d)", tt.in, enc, n, tt.wantEnc, tt.wantBOMLen)
+			}
+		})
+	}
+}
+
+func TestDecodeBOM(t *testing.T) {
+	tests := []struct {
+		name string
+		in   []byte
+		want string
+	}{
+		{"UTF-8 BOM", []byte("\xEF\xBB\xBF世界"), "世界"},
+		{"UTF-16LE", []byte("\xFF\xFEH\x00i\x00\x16\x4e"), "Hi世"},
+		{"UTF-16BE", []byte("\xFE\xFF\x00H\x00i"), "Hi"},
+		{"UTF-16LE surrogate pair", []byte("\xFF\xFE\x3D\xD8\x00\xDE"), "😀"},
+		{"No BOM", []byte("plain"), "plain"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeBOM(tt.in)
+			if err != nil {
+				t.Fatalf("DecodeBOM(%q) error: %v", tt.in, err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("DecodeBOM(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+
+	if _, err := DecodeBOM([]byte("\xFF\xFEH")); !errors.Is(err, ErrInvalidUTF16) {
+		t.Errorf("DecodeBOM(odd UTF-16) error = %v, want ErrInvalidUTF16", err)
+	}
+}
+
+func TestBytesIgnoreBOM(t *testing.T) {
+	txt := NewTerminal()
+	withBOM := []byte("\xEF\xBB\xBFHello world")
+
+	if got := txt.WidthBytes(withBOM); got != 11 {
+		t.Errorf("WidthBytes with BOM = %.1f, want 11.0", got)
+	}
+
+	lines := txt.WrapBytes(withBOM, WrapOptions{MaxWidth: 6})
+	if len(lines) != 2 {
+		t.Fatalf("WrapBytes returned %d lines, want 2", len(lines))
+	}
+	if lines[0].Content != "Hello " || lines[0].Start != 0 {
+		t.Errorf("first line = %+v, want Content %q starting at 0", lines[0], "Hello ")
+	}
+}
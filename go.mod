@@ -5,3 +5,5 @@ go 1.25.4
 require github.com/SCKelemen/units v1.2.1
 
 require github.com/SCKelemen/unicode/v6 v6.2.0
+
+require golang.org/x/text v0.36.0
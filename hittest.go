@@ -146,6 +146,34 @@ func (t *Text) XOffsetToPosition(line Line, xOffset float64) XOffsetInfo {
 	}
 }
 
+// GraphemeAtColumn returns the grapheme cluster that occupies the given
+// display column, and its index in Graphemes(text).
+//
+// A wide character covers two columns, so either of its columns returns
+// it. Zero-width clusters never occupy a column. If column is negative or
+// at or beyond the width of text, it returns "" and -1.
+//
+// Example:
+//
+//	txt := text.NewTerminal()
+//	g, i := txt.GraphemeAtColumn("a世b", 1) // "世", 1
+//	g, i = txt.GraphemeAtColumn("a世b", 2)  // "世", 1 (right half)
+//	g, i = txt.GraphemeAtColumn("a世b", 3)  // "b", 2
+func (t *Text) GraphemeAtColumn(text string, column float64) (cluster string, graphemeIndex int) {
+	if column < 0 {
+		return "", -1
+	}
+
+	x := 0.0
+	for i, g := range t.Graphemes(text) {
+		x += t.graphemeWidth(g)
+		if column < x {
+			return g, i
+		}
+	}
+	return "", -1
+}
+
 // ═══════════════════════════════════════════════════════════════
 //  Line Identification
 // ═══════════════════════════════════════════════════════════════
@@ -192,3 +220,86 @@ func (t *Text) LineContainingPosition(lines []Line, position int) int {
 	}
 	return len(lines)
 }
+
+// ═══════════════════════════════════════════════════════════════
+//  Selection Rectangles
+// ═══════════════════════════════════════════════════════════════
+
+// Rect is an axis-aligned rectangle in the same units as line widths.
+type Rect struct {
+	X, Y, Width, Height float64
+}
+
+// SelectionRects returns the highlight rectangles for the selection
+// [start, end) across wrapped lines.
+//
+// start and end are rune indices, like Line.Start and Line.End. Each line is
+// reordered for display (UAX #9) using the configured base direction, so a
+// selection that crosses an LTR/RTL boundary can produce several rectangles
+// on one line. X is measured from the left edge of the line and Y is the
+// line index times lineHeight. Lines are expected to hold their source text
+// unchanged, as returned by Wrap.
+//
+// Example:
+//
+//	txt := text.NewTerminal()
+//	lines := txt.Wrap("abc אבג def", text.WrapOptions{MaxWidth: 20})
+//	rects := txt.SelectionRects(lines, 2, 5, 1)
+//	// [{X: 2, Width: 2, Height: 1} {X: 6, Width: 1, Height: 1}]
+func (t *Text) SelectionRects(lines []Line, start, end int, lineHeight float64) []Rect {
+	if start > end {
+		start, end = end, start
+	}
+
+	var rects []Rect
+	for i, line := range lines {
+		if end <= line.Start || start >= line.End {
+			continue
+		}
+		y := float64(i) * lineHeight
+		for _, span := range t.selectedSpans(line, start-line.Start, end-line.Start) {
+			rects = append(rects, Rect{X: span[0], Y: y, Width: span[1] - span[0], Height: lineHeight})
+		}
+	}
+	return rects
+}
+
+// selectedSpans returns the visual [x0, x1] spans of line covered by the
+// rune range [from, to) relative to the line start, merging graphemes that
+// are adjacent on screen.
+func (t *Text) selectedSpans(line Line, from, to int) [][2]float64 {
+	runes := []rune(line.Content)
+	levels, paraLevel := bidiLevels(runes, t.config.BaseDirection)
+
+	type cluster struct {
+		start, end int
+		width      float64
+	}
+	var clusters []cluster
+	var clusterLevels []int
+	pos := 0
+	for _, g := range t.Graphemes(line.Content) {
+		n := len([]rune(g))
+		clusters = append(clusters, cluster{pos, pos + n, t.Width(g)})
+		clusterLevels = append(clusterLevels, levels[pos])
+		pos += n
+	}
+
+	var spans [][2]float64
+	x := 0.0
+	prevSelected := false
+	for _, idx := range visualOrder(clusterLevels, paraLevel) {
+		c := clusters[idx]
+		selected := c.start < to && c.end > from
+		if selected {
+			if prevSelected {
+				spans[len(spans)-1][1] = x + c.width
+			} else {
+				spans = append(spans, [2]float64{x, x + c.width})
+			}
+		}
+		prevSelected = selected
+		x += c.width
+	}
+	return spans
+}
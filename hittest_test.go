@@ -1,6 +1,10 @@
 package text
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/SCKelemen/unicode/v6/uax9"
+)
 
 // ═══════════════════════════════════════════════════════════════
 //  XOffsetToPosition Tests
@@ -359,3 +363,140 @@ func BenchmarkLineContainingPosition(b *testing.B) {
 		txt.LineContainingPosition(lines, 35)
 	}
 }
+
+// ═══════════════════════════════════════════════════════════════
+//  GraphemeAtColumn Tests
+// ═══════════════════════════════════════════════════════════════
+
+func TestGraphemeAtColumn(t *testing.T) {
+	txt := NewTerminal()
+
+	tests := []struct {
+		name      string
+		text      string
+		column    float64
+		wantG     string
+		wantIndex int
+	}{
+		{"First column", "a世b", 0, "a", 0},
+		{"Left half of wide char", "a世b", 1, "世", 1},
+		{"Right half of wide char", "a世b", 2, "世", 1},
+		{"Fractional column", "a世b", 2.5, "世", 1},
+		{"After wide char", "a世b", 3, "b", 2},
+		{"Emoji ZWJ sequence", "x👨‍👩‍👧y", 2, "👨‍👩‍👧", 1},
+		{"Combining mark stays with base", "e\u0301z", 0, "e\u0301", 0},
+		{"Past the end", "a世b", 4, "", -1},
+		{"Negative", "a世b", -1, "", -1},
+		{"Empty", "", 0, "", -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, i := txt.GraphemeAtColumn(tt.text, tt.column)
+			if g != tt.wantG || i != tt.wantIndex {
+				t.Errorf("GraphemeAtColumn(%q, %.1f) = (%q, %d), want (%q, %d)",
+					tt.text, tt.column, g, i, tt.wantG, tt.wantIndex)
+			}
+		})
+	}
+}
+
+// ═══════════════════════════════════════════════════════════════
+//  SelectionRects Tests
+// ═══════════════════════════════════════════════════════════════
+
+func TestSelectionRects(t *testing.T) {
+	txt := NewTerminal()
+
+	// Displayed as "abc גבא def": the Hebrew run is reversed on screen.
+	mixed := []Line{{Content: "abc אבג def", Width: 11, Start: 0, End: 11}}
+
+	tests := []struct {
+		name       string
+		lines      []Line
+		start, end int
+		want       []Rect
+	}{
+		{
+			name:  "LTR only",
+			lines: mixed,
+			start: 1, end: 3,
+			want: []Rect{{X: 1, Y: 0, Width: 2, Height: 2}},
+		},
+		{
+			name:  "Crosses into RTL run",
+			lines: mixed,
+			start: 2, end: 5,
+			want: []Rect{
+				{X: 2, Y: 0, Width: 2, Height: 2},
+				{X: 6, Y: 0, Width: 1, Height: 2},
+			},
+		},
+		{
+			name:  "Crosses out of RTL run",
+			lines: mixed,
+			start: 5, end: 9,
+			want: []Rect{
+				{X: 4, Y: 0, Width: 2, Height: 2},
+				{X: 7, Y: 0, Width: 2, Height: 2},
+			},
+		},
+		{
+			name:  "Whole RTL run is contiguous",
+			lines: mixed,
+			start: 4, end: 7,
+			want: []Rect{{X: 4, Y: 0, Width: 3, Height: 2}},
+		},
+		{
+			name: "Across wrapped lines",
+			lines: []Line{
+				{Content: "Hello ", Width: 6, Start: 0, End: 6},
+				{Content: "世界", Width: 4, Start: 6, End: 8},
+			},
+			start: 3, end: 7,
+			want: []Rect{
+				{X: 3, Y: 0, Width: 3, Height: 2},
+				{X: 0, Y: 2, Width: 2, Height: 2},
+			},
+		},
+		{
+			name:  "Reversed bounds",
+			lines: mixed,
+			start: 3, end: 1,
+			want: []Rect{{X: 1, Y: 0, Width: 2, Height: 2}},
+		},
+		{
+			name:  "Empty selection",
+			lines: mixed,
+			start: 3, end: 3,
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := txt.SelectionRects(tt.lines, tt.start, tt.end, 2)
+			if len(got) != len(tt.want) {
+				t.Fatalf("SelectionRects() = %+v, want %+v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("rect %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSelectionRects_RTLBase(t *testing.T) {
+	txt := New(Config{BaseDirection: uax9.DirectionRTL})
+
+	// In an RTL paragraph "אבג abc" displays as "abc גבא".
+	lines := []Line{{Content: "אבג abc", Width: 7, Start: 0, End: 7}}
+
+	got := txt.SelectionRects(lines, 0, 1, 1)
+	want := []Rect{{X: 6, Y: 0, Width: 1, Height: 1}}
+	if len(got) != 1 || got[0] != want[0] {
+		t.Errorf("SelectionRects() = %+v, want %+v", got, want)
+	}
+}
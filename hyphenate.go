@@ -1,7 +1,13 @@
 package text
 
 import (
+	"fmt"
+	"io"
+	"sort"
 	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
 )
 
 // Hyphenation using Liang's Algorithm
@@ -10,7 +16,7 @@ import (
 // Based on pattern matching with priority levels.
 //
 // This package provides decent hyphenation support for English, French, German,
-// Spanish, Swedish, Norwegian, and Danish. For comprehensive language support,
+// Spanish, Swedish, Norwegian, Danish, Italian, Portuguese, Dutch, and Polish. For comprehensive language support,
 // users can load full TeX hyphenation patterns from:
 //
 //   - TeX hyphen patterns: https://github.com/hyphenation/tex-hyphen
@@ -39,9 +45,11 @@ import (
 //	dict := text.NewHyphenationDictionary(myPatterns, 2, 3)
 //	points := dict.Hyphenate("example")
 type HyphenationDictionary struct {
-	patterns map[string]string // pattern -> priority string
-	minLeft  int               // Minimum characters on left
-	minRight int               // Minimum characters on right
+	patterns   map[string]string // pattern -> priority string
+	exceptions map[string][]int  // lowercased word -> exact points
+	minLeft    int               // Minimum characters on left
+	minRight   int               // Minimum characters on right
+	options    HyphenationOptions
 }
 
 // NewHyphenationDictionary creates a custom hyphenation dictionary.
@@ -133,6 +141,42 @@ func NewDanishHyphenation() *HyphenationDictionary {
 	}
 }
 
+// NewItalianHyphenation creates a hyphenation dictionary with Italian patterns.
+func NewItalianHyphenation() *HyphenationDictionary {
+	return &HyphenationDictionary{
+		patterns: italianHyphenationPatterns(),
+		minLeft:  2,
+		minRight: 2,
+	}
+}
+
+// NewPortugueseHyphenation creates a hyphenation dictionary with Portuguese patterns.
+func NewPortugueseHyphenation() *HyphenationDictionary {
+	return &HyphenationDictionary{
+		patterns: portugueseHyphenationPatterns(),
+		minLeft:  2,
+		minRight: 3,
+	}
+}
+
+// NewDutchHyphenation creates a hyphenation dictionary with Dutch patterns.
+func NewDutchHyphenation() *HyphenationDictionary {
+	return &HyphenationDictionary{
+		patterns: dutchHyphenationPatterns(),
+		minLeft:  2,
+		minRight: 2,
+	}
+}
+
+// NewPolishHyphenation creates a hyphenation dictionary with Polish patterns.
+func NewPolishHyphenation() *HyphenationDictionary {
+	return &HyphenationDictionary{
+		patterns: polishHyphenationPatterns(),
+		minLeft:  2,
+		minRight: 2, // Polish allows two letters on either side
+	}
+}
+
 // englishHyphenationPatterns returns a subset of English hyphenation patterns.
 //
 // Pattern format: letters with numbers indicating break priority.
@@ -402,7 +446,6 @@ func germanHyphenationPatterns() map[string]string {
 		".zer3":    ".zer3",
 
 		// Common suffixes
-		"3ung.":    "3ung.",
 		"3schaft.": "3schaft.",
 		"3heit.":   "3heit.",
 		"3keit.":   "3keit.",
@@ -488,6 +531,14 @@ func germanHyphenationPatterns() map[string]string {
 		"1wi": "1wi",
 		"1wo": "1wo",
 		"1wu": "1wu",
+		"1ßa": "1ßa",
+		"1ße": "1ße",
+		"1ßi": "1ßi",
+		"1ßo": "1ßo",
+		"1ßu": "1ßu",
+		"1ßä": "1ßä",
+		"1ßö": "1ßö",
+		"1ßü": "1ßü",
 
 		// Double consonants
 		"2bb": "2bb",
@@ -507,6 +558,9 @@ func germanHyphenationPatterns() map[string]string {
 		"3sch": "3sch",
 		"2ch":  "2ch",
 		"3ck":  "3ck",
+
+		// A single consonant before -ung stays with it (Zei-tung)
+		"2ung.": "2ung.",
 	}
 }
 
@@ -1184,100 +1238,1454 @@ func danishHyphenationPatterns() map[string]string {
 	}
 }
 
-// Hyphenate returns hyphenation points for a word using Liang's algorithm.
-//
-// Returns byte indices where hyphenation is allowed.
-// Uses pattern matching with priority levels to determine break points.
-//
-// Example:
+// italianHyphenationPatterns returns Italian hyphenation patterns.
 //
-//	dict := text.NewEnglishHyphenation()
-//	points := dict.Hyphenate("example")
-//	// Returns []int{2, 4} for ex-am-ple
-func (h *HyphenationDictionary) Hyphenate(word string) []int {
-	if len(word) < h.minLeft+h.minRight {
-		return nil // Too short to hyphenate
-	}
+// Italian syllables break before a single consonant between vowels
+// (pa-ro-la), between double consonants (fat-to), and before clusters of
+// a consonant followed by l or r (ca-pra). An s before a consonant starts
+// the next syllable (pa-sta), and the digraphs gn, gl, ch, gh, and sc are
+// never split.
+func italianHyphenationPatterns() map[string]string {
+	return map[string]string{
+		// Common prefixes
+		".anti3":   ".anti3",
+		".contro3": ".contro3",
+		".extra3":  ".extra3",
+		".inter3":  ".inter3",
+		".sopra3":  ".sopra3",
+		".sotto3":  ".sotto3",
+		".super3":  ".super3",
+		".tra3":    ".tra3",
 
-	// Normalize word: lowercase and add delimiters
-	normalized := "." + strings.ToLower(word) + "."
+		// Common suffixes
+		"5zione.": "5zione.",
+		"5mente.": "5mente.",
+		"5mento.": "5mento.",
+		"5abile.": "5abile.",
+		"5ibile.": "5ibile.",
 
-	// Initialize priority array (one value between each character)
-	// Length is len(normalized) + 1 to account for positions
-	priorities := make([]int, len(normalized)+1)
+		// Consonant-vowel patterns
+		"1ba": "1ba",
+		"1be": "1be",
+		"1bi": "1bi",
+		"1bo": "1bo",
+		"1bu": "1bu",
+		"1ca": "1ca",
+		"1ce": "1ce",
+		"1ci": "1ci",
+		"1co": "1co",
+		"1cu": "1cu",
+		"1da": "1da",
+		"1de": "1de",
+		"1di": "1di",
+		"1do": "1do",
+		"1du": "1du",
+		"1fa": "1fa",
+		"1fe": "1fe",
+		"1fi": "1fi",
+		"1fo": "1fo",
+		"1fu": "1fu",
+		"1ga": "1ga",
+		"1ge": "1ge",
+		"1gi": "1gi",
+		"1go": "1go",
+		"1gu": "1gu",
+		"1la": "1la",
+		"1le": "1le",
+		"1li": "1li",
+		"1lo": "1lo",
+		"1lu": "1lu",
+		"1ma": "1ma",
+		"1me": "1me",
+		"1mi": "1mi",
+		"1mo": "1mo",
+		"1mu": "1mu",
+		"1na": "1na",
+		"1ne": "1ne",
+		"1ni": "1ni",
+		"1no": "1no",
+		"1nu": "1nu",
+		"1pa": "1pa",
+		"1pe": "1pe",
+		"1pi": "1pi",
+		"1po": "1po",
+		"1pu": "1pu",
+		"1ra": "1ra",
+		"1re": "1re",
+		"1ri": "1ri",
+		"1ro": "1ro",
+		"1ru": "1ru",
+		"1sa": "1sa",
+		"1se": "1se",
+		"1si": "1si",
+		"1so": "1so",
+		"1su": "1su",
+		"1ta": "1ta",
+		"1te": "1te",
+		"1ti": "1ti",
+		"1to": "1to",
+		"1tu": "1tu",
+		"1va": "1va",
+		"1ve": "1ve",
+		"1vi": "1vi",
+		"1vo": "1vo",
+		"1vu": "1vu",
+		"1za": "1za",
+		"1ze": "1ze",
+		"1zi": "1zi",
+		"1zo": "1zo",
+		"1zu": "1zu",
+		"1bà": "1bà",
+		"1bè": "1bè",
+		"1bé": "1bé",
+		"1bì": "1bì",
+		"1bò": "1bò",
+		"1bù": "1bù",
+		"1cà": "1cà",
+		"1cè": "1cè",
+		"1cé": "1cé",
+		"1cì": "1cì",
+		"1cò": "1cò",
+		"1cù": "1cù",
+		"1dà": "1dà",
+		"1dè": "1dè",
+		"1dé": "1dé",
+		"1dì": "1dì",
+		"1dò": "1dò",
+		"1dù": "1dù",
+		"1fà": "1fà",
+		"1fè": "1fè",
+		"1fé": "1fé",
+		"1fì": "1fì",
+		"1fò": "1fò",
+		"1fù": "1fù",
+		"1gà": "1gà",
+		"1gè": "1gè",
+		"1gé": "1gé",
+		"1gì": "1gì",
+		"1gò": "1gò",
+		"1gù": "1gù",
+		"1là": "1là",
+		"1lè": "1lè",
+		"1lé": "1lé",
+		"1lì": "1lì",
+		"1lò": "1lò",
+		"1lù": "1lù",
+		"1mà": "1mà",
+		"1mè": "1mè",
+		"1mé": "1mé",
+		"1mì": "1mì",
+		"1mò": "1mò",
+		"1mù": "1mù",
+		"1nà": "1nà",
+		"1nè": "1nè",
+		"1né": "1né",
+		"1nì": "1nì",
+		"1nò": "1nò",
+		"1nù": "1nù",
+		"1pà": "1pà",
+		"1pè": "1pè",
+		"1pé": "1pé",
+		"1pì": "1pì",
+		"1pò": "1pò",
+		"1pù": "1pù",
+		"1rà": "1rà",
+		"1rè": "1rè",
+		"1ré": "1ré",
+		"1rì": "1rì",
+		"1rò": "1rò",
+		"1rù": "1rù",
+		"1sà": "1sà",
+		"1sè": "1sè",
+		"1sé": "1sé",
+		"1sì": "1sì",
+		"1sò": "1sò",
+		"1sù": "1sù",
+		"1tà": "1tà",
+		"1tè": "1tè",
+		"1té": "1té",
+		"1tì": "1tì",
+		"1tò": "1tò",
+		"1tù": "1tù",
+		"1và": "1và",
+		"1vè": "1vè",
+		"1vé": "1vé",
+		"1vì": "1vì",
+		"1vò": "1vò",
+		"1vù": "1vù",
+		"1zà": "1zà",
+		"1zè": "1zè",
+		"1zé": "1zé",
+		"1zì": "1zì",
+		"1zò": "1zò",
+		"1zù": "1zù",
 
-	// Apply all matching patterns
-	for pattern := range h.patterns {
-		h.applyPattern(normalized, pattern, priorities)
-	}
+		// Consonant + l/r clusters stay together
+		"1b2l": "1b2l",
+		"1b2r": "1b2r",
+		"1c2l": "1c2l",
+		"1c2r": "1c2r",
+		"1d2r": "1d2r",
+		"1f2l": "1f2l",
+		"1f2r": "1f2r",
+		"1g2l": "1g2l",
+		"1g2r": "1g2r",
+		"1p2l": "1p2l",
+		"1p2r": "1p2r",
+		"1t2r": "1t2r",
+		"1v2r": "1v2r",
 
-	// Extract hyphenation points
-	var points []int
-	for i := h.minLeft; i < len(word)-h.minRight; i++ {
-		// i+1 because priorities[0] is before first char
-		// Odd priorities indicate allowed breaks
-		if priorities[i+1]%2 == 1 {
-			points = append(points, i)
-		}
-	}
+		// s + consonant starts the next syllable
+		"1s2b": "1s2b",
+		"1s2c": "1s2c",
+		"1s2d": "1s2d",
+		"1s2f": "1s2f",
+		"1s2g": "1s2g",
+		"1s2l": "1s2l",
+		"1s2m": "1s2m",
+		"1s2n": "1s2n",
+		"1s2p": "1s2p",
+		"1s2r": "1s2r",
+		"1s2t": "1s2t",
+		"1s2v": "1s2v",
 
-	return points
+		// Digraphs
+		"1g2n": "1g2n",
+		"1c2h": "1c2h",
+		"1g2h": "1g2h",
+	}
 }
 
-// applyPattern applies a single hyphenation pattern to the word.
-func (h *HyphenationDictionary) applyPattern(word, pattern string, priorities []int) {
-	// Extract letters and numbers from pattern
-	patternLetters := ""
-	patternNumbers := make([]int, len(pattern)+1)
-	pos := 0
+// portugueseHyphenationPatterns returns Portuguese hyphenation patterns.
+//
+// Portuguese breaks before a single consonant between vowels (pa-la-vra),
+// splits rr, ss, sc, sç, and xc (car-ro, nas-cer), and keeps the digraphs
+// ch, lh, nh and consonant + l/r clusters together (fi-lho, li-vro).
+func portugueseHyphenationPatterns() map[string]string {
+	return map[string]string{
+		// Common prefixes
+		".anti3":   ".anti3",
+		".contra3": ".contra3",
+		".entre3":  ".entre3",
+		".inter3":  ".inter3",
+		".sobre3":  ".sobre3",
+		".super3":  ".super3",
 
-	for i := 0; i < len(pattern); i++ {
-		ch := pattern[i]
-		if ch >= '0' && ch <= '9' {
-			patternNumbers[pos] = int(ch - '0')
-		} else {
-			patternLetters += string(ch)
-			pos++
-		}
-	}
+		// Common suffixes
+		"5ção.":   "5ção.",
+		"5ções.":  "5ções.",
+		"5mente.": "5mente.",
+		"5mento.": "5mento.",
+		"5dade.":  "5dade.",
+		"5vel.":   "5vel.",
 
-	// Find all occurrences of the letter pattern in the word
-	for i := 0; i <= len(word)-len(patternLetters); i++ {
-		if word[i:i+len(patternLetters)] == patternLetters {
-			// Apply priority numbers
-			for j := 0; j <= len(patternLetters); j++ {
-				if patternNumbers[j] > priorities[i+j] {
-					priorities[i+j] = patternNumbers[j]
-				}
-			}
-		}
+		// Consonant-vowel patterns
+		"1ba": "1ba",
+		"1be": "1be",
+		"1bi": "1bi",
+		"1bo": "1bo",
+		"1bu": "1bu",
+		"1ca": "1ca",
+		"1ce": "1ce",
+		"1ci": "1ci",
+		"1co": "1co",
+		"1cu": "1cu",
+		"1ça": "1ça",
+		"1çe": "1çe",
+		"1çi": "1çi",
+		"1ço": "1ço",
+		"1çu": "1çu",
+		"1da": "1da",
+		"1de": "1de",
+		"1di": "1di",
+		"1do": "1do",
+		"1du": "1du",
+		"1fa": "1fa",
+		"1fe": "1fe",
+		"1fi": "1fi",
+		"1fo": "1fo",
+		"1fu": "1fu",
+		"1ga": "1ga",
+		"1ge": "1ge",
+		"1gi": "1gi",
+		"1go": "1go",
+		"1gu": "1gu",
+		"1ja": "1ja",
+		"1je": "1je",
+		"1ji": "1ji",
+		"1jo": "1jo",
+		"1ju": "1ju",
+		"1la": "1la",
+		"1le": "1le",
+		"1li": "1li",
+		"1lo": "1lo",
+		"1lu": "1lu",
+		"1ma": "1ma",
+		"1me": "1me",
+		"1mi": "1mi",
+		"1mo": "1mo",
+		"1mu": "1mu",
+		"1na": "1na",
+		"1ne": "1ne",
+		"1ni": "1ni",
+		"1no": "1no",
+		"1nu": "1nu",
+		"1pa": "1pa",
+		"1pe": "1pe",
+		"1pi": "1pi",
+		"1po": "1po",
+		"1pu": "1pu",
+		"1ra": "1ra",
+		"1re": "1re",
+		"1ri": "1ri",
+		"1ro": "1ro",
+		"1ru": "1ru",
+		"1sa": "1sa",
+		"1se": "1se",
+		"1si": "1si",
+		"1so": "1so",
+		"1su": "1su",
+		"1ta": "1ta",
+		"1te": "1te",
+		"1ti": "1ti",
+		"1to": "1to",
+		"1tu": "1tu",
+		"1va": "1va",
+		"1ve": "1ve",
+		"1vi": "1vi",
+		"1vo": "1vo",
+		"1vu": "1vu",
+		"1xa": "1xa",
+		"1xe": "1xe",
+		"1xi": "1xi",
+		"1xo": "1xo",
+		"1xu": "1xu",
+		"1za": "1za",
+		"1ze": "1ze",
+		"1zi": "1zi",
+		"1zo": "1zo",
+		"1zu": "1zu",
+		"1bá": "1bá",
+		"1bâ": "1bâ",
+		"1bã": "1bã",
+		"1bé": "1bé",
+		"1bê": "1bê",
+		"1bí": "1bí",
+		"1bó": "1bó",
+		"1bô": "1bô",
+		"1bõ": "1bõ",
+		"1bú": "1bú",
+		"1cá": "1cá",
+		"1câ": "1câ",
+		"1cã": "1cã",
+		"1cé": "1cé",
+		"1cê": "1cê",
+		"1cí": "1cí",
+		"1có": "1có",
+		"1cô": "1cô",
+		"1cõ": "1cõ",
+		"1cú": "1cú",
+		"1çá": "1çá",
+		"1çâ": "1çâ",
+		"1çã": "1çã",
+		"1çé": "1çé",
+		"1çê": "1çê",
+		"1çí": "1çí",
+		"1çó": "1çó",
+		"1çô": "1çô",
+		"1çõ": "1çõ",
+		"1çú": "1çú",
+		"1dá": "1dá",
+		"1dâ": "1dâ",
+		"1dã": "1dã",
+		"1dé": "1dé",
+		"1dê": "1dê",
+		"1dí": "1dí",
+		"1dó": "1dó",
+		"1dô": "1dô",
+		"1dõ": "1dõ",
+		"1dú": "1dú",
+		"1fá": "1fá",
+		"1fâ": "1fâ",
+		"1fã": "1fã",
+		"1fé": "1fé",
+		"1fê": "1fê",
+		"1fí": "1fí",
+		"1fó": "1fó",
+		"1fô": "1fô",
+		"1fõ": "1fõ",
+		"1fú": "1fú",
+		"1gá": "1gá",
+		"1gâ": "1gâ",
+		"1gã": "1gã",
+		"1gé": "1gé",
+		"1gê": "1gê",
+		"1gí": "1gí",
+		"1gó": "1gó",
+		"1gô": "1gô",
+		"1gõ": "1gõ",
+		"1gú": "1gú",
+		"1já": "1já",
+		"1jâ": "1jâ",
+		"1jã": "1jã",
+		"1jé": "1jé",
+		"1jê": "1jê",
+		"1jí": "1jí",
+		"1jó": "1jó",
+		"1jô": "1jô",
+		"1jõ": "1jõ",
+		"1jú": "1jú",
+		"1lá": "1lá",
+		"1lâ": "1lâ",
+		"1lã": "1lã",
+		"1lé": "1lé",
+		"1lê": "1lê",
+		"1lí": "1lí",
+		"1ló": "1ló",
+		"1lô": "1lô",
+		"1lõ": "1lõ",
+		"1lú": "1lú",
+		"1má": "1má",
+		"1mâ": "1mâ",
+		"1mã": "1mã",
+		"1mé": "1mé",
+		"1mê": "1mê",
+		"1mí": "1mí",
+		"1mó": "1mó",
+		"1mô": "1mô",
+		"1mõ": "1mõ",
+		"1mú": "1mú",
+		"1ná": "1ná",
+		"1nâ": "1nâ",
+		"1nã": "1nã",
+		"1né": "1né",
+		"1nê": "1nê",
+		"1ní": "1ní",
+		"1nó": "1nó",
+		"1nô": "1nô",
+		"1nõ": "1nõ",
+		"1nú": "1nú",
+		"1pá": "1pá",
+		"1pâ": "1pâ",
+		"1pã": "1pã",
+		"1pé": "1pé",
+		"1pê": "1pê",
+		"1pí": "1pí",
+		"1pó": "1pó",
+		"1pô": "1pô",
+		"1põ": "1põ",
+		"1pú": "1pú",
+		"1rá": "1rá",
+		"1râ": "1râ",
+		"1rã": "1rã",
+		"1ré": "1ré",
+		"1rê": "1rê",
+		"1rí": "1rí",
+		"1ró": "1ró",
+		"1rô": "1rô",
+		"1rõ": "1rõ",
+		"1rú": "1rú",
+		"1sá": "1sá",
+		"1sâ": "1sâ",
+		"1sã": "1sã",
+		"1sé": "1sé",
+		"1sê": "1sê",
+		"1sí": "1sí",
+		"1só": "1só",
+		"1sô": "1sô",
+		"1sõ": "1sõ",
+		"1sú": "1sú",
+		"1tá": "1tá",
+		"1tâ": "1tâ",
+		"1tã": "1tã",
+		"1té": "1té",
+		"1tê": "1tê",
+		"1tí": "1tí",
+		"1tó": "1tó",
+		"1tô": "1tô",
+		"1tõ": "1tõ",
+		"1tú": "1tú",
+		"1vá": "1vá",
+		"1vâ": "1vâ",
+		"1vã": "1vã",
+		"1vé": "1vé",
+		"1vê": "1vê",
+		"1ví": "1ví",
+		"1vó": "1vó",
+		"1vô": "1vô",
+		"1võ": "1võ",
+		"1vú": "1vú",
+		"1xá": "1xá",
+		"1xâ": "1xâ",
+		"1xã": "1xã",
+		"1xé": "1xé",
+		"1xê": "1xê",
+		"1xí": "1xí",
+		"1xó": "1xó",
+		"1xô": "1xô",
+		"1xõ": "1xõ",
+		"1xú": "1xú",
+		"1zá": "1zá",
+		"1zâ": "1zâ",
+		"1zã": "1zã",
+		"1zé": "1zé",
+		"1zê": "1zê",
+		"1zí": "1zí",
+		"1zó": "1zó",
+		"1zô": "1zô",
+		"1zõ": "1zõ",
+		"1zú": "1zú",
+
+		// Consonant + l/r clusters stay together
+		"1b2l": "1b2l",
+		"1b2r": "1b2r",
+		"1c2l": "1c2l",
+		"1c2r": "1c2r",
+		"1d2r": "1d2r",
+		"1f2l": "1f2l",
+		"1f2r": "1f2r",
+		"1g2l": "1g2l",
+		"1g2r": "1g2r",
+		"1p2l": "1p2l",
+		"1p2r": "1p2r",
+		"1t2r": "1t2r",
+		"1v2r": "1v2r",
+
+		// Digraphs
+		"1c2h": "1c2h",
+		"1l2h": "1l2h",
+		"1n2h": "1n2h",
+		"1g2u": "1g2u",
+		"1q2u": "1q2u",
+
+		// Split double and s + consonant
+		"r1r": "r1r",
+		"s1s": "s1s",
+		"s1c": "s1c",
+		"s1ç": "s1ç",
+		"x1c": "x1c",
 	}
 }
 
-// HyphenateWithString returns the hyphenated word with hyphens inserted.
-//
-// Example:
+// dutchHyphenationPatterns returns Dutch hyphenation patterns.
 //
-//	dict := text.NewEnglishHyphenation()
-//	result := dict.HyphenateWithString("example", "-")
-//	// Returns "ex-am-ple"
-func (h *HyphenationDictionary) HyphenateWithString(word, hyphen string) string {
-	points := h.Hyphenate(word)
-	if len(points) == 0 {
-		return word
-	}
-
-	var result strings.Builder
-	lastPos := 0
+// Dutch breaks before a single consonant between vowels (bo-ter-ham) and
+// between two consonants (zin-gen, let-ter). The digraphs ch, sch, and ij
+// are never split, and common compound prefixes break after the prefix.
+func dutchHyphenationPatterns() map[string]string {
+	return map[string]string{
+		// Common prefixes
+		".be3":   ".be3",
+		".ge3":   ".ge3",
+		".ont3":  ".ont3",
+		".over3": ".over3",
+		".ver3":  ".ver3",
+		".voor3": ".voor3",
+		".weg3":  ".weg3",
 
-	for _, pos := range points {
-		result.WriteString(word[lastPos:pos])
-		result.WriteString(hyphen)
-		lastPos = pos
-	}
-	result.WriteString(word[lastPos:])
+		// Common suffixes
+		"5heid.": "5heid.",
+		"5lijk.": "5lijk.",
+		"5baar.": "5baar.",
+		"5ing.":  "5ing.",
+		"5tie.":  "5tie.",
 
-	return result.String()
+		// Consonant-vowel patterns
+		"1ba": "1ba",
+		"1be": "1be",
+		"1bi": "1bi",
+		"1bo": "1bo",
+		"1bu": "1bu",
+		"1by": "1by",
+		"1ca": "1ca",
+		"1ce": "1ce",
+		"1ci": "1ci",
+		"1co": "1co",
+		"1cu": "1cu",
+		"1cy": "1cy",
+		"1da": "1da",
+		"1de": "1de",
+		"1di": "1di",
+		"1do": "1do",
+		"1du": "1du",
+		"1dy": "1dy",
+		"1fa": "1fa",
+		"1fe": "1fe",
+		"1fi": "1fi",
+		"1fo": "1fo",
+		"1fu": "1fu",
+		"1fy": "1fy",
+		"1ga": "1ga",
+		"1ge": "1ge",
+		"1gi": "1gi",
+		"1go": "1go",
+		"1gu": "1gu",
+		"1gy": "1gy",
+		"1ha": "1ha",
+		"1he": "1he",
+		"1hi": "1hi",
+		"1ho": "1ho",
+		"1hu": "1hu",
+		"1hy": "1hy",
+		"1ja": "1ja",
+		"1je": "1je",
+		"1ji": "1ji",
+		"1jo": "1jo",
+		"1ju": "1ju",
+		"1jy": "1jy",
+		"1ka": "1ka",
+		"1ke": "1ke",
+		"1ki": "1ki",
+		"1ko": "1ko",
+		"1ku": "1ku",
+		"1ky": "1ky",
+		"1la": "1la",
+		"1le": "1le",
+		"1li": "1li",
+		"1lo": "1lo",
+		"1lu": "1lu",
+		"1ly": "1ly",
+		"1ma": "1ma",
+		"1me": "1me",
+		"1mi": "1mi",
+		"1mo": "1mo",
+		"1mu": "1mu",
+		"1my": "1my",
+		"1na": "1na",
+		"1ne": "1ne",
+		"1ni": "1ni",
+		"1no": "1no",
+		"1nu": "1nu",
+		"1ny": "1ny",
+		"1pa": "1pa",
+		"1pe": "1pe",
+		"1pi": "1pi",
+		"1po": "1po",
+		"1pu": "1pu",
+		"1py": "1py",
+		"1ra": "1ra",
+		"1re": "1re",
+		"1ri": "1ri",
+		"1ro": "1ro",
+		"1ru": "1ru",
+		"1ry": "1ry",
+		"1sa": "1sa",
+		"1se": "1se",
+		"1si": "1si",
+		"1so": "1so",
+		"1su": "1su",
+		"1sy": "1sy",
+		"1ta": "1ta",
+		"1te": "1te",
+		"1ti": "1ti",
+		"1to": "1to",
+		"1tu": "1tu",
+		"1ty": "1ty",
+		"1va": "1va",
+		"1ve": "1ve",
+		"1vi": "1vi",
+		"1vo": "1vo",
+		"1vu": "1vu",
+		"1vy": "1vy",
+		"1wa": "1wa",
+		"1we": "1we",
+		"1wi": "1wi",
+		"1wo": "1wo",
+		"1wu": "1wu",
+		"1wy": "1wy",
+		"1za": "1za",
+		"1ze": "1ze",
+		"1zi": "1zi",
+		"1zo": "1zo",
+		"1zu": "1zu",
+		"1zy": "1zy",
+
+		// Digraphs
+		"1c2h":   "1c2h",
+		"1s2c2h": "1s2c2h",
+		"i2j":    "i2j",
+		".sch2":  ".sch2",
+	}
+}
+
+// polishHyphenationPatterns returns Polish hyphenation patterns.
+//
+// Polish breaks before a single consonant between vowels (ko-la-da) and
+// never splits the digraphs ch, cz, dz, dź, dż, rz, and sz, which act as
+// single consonants (cze-ko-la-da, rze-ka). Clusters that begin a word
+// stay together (szko-ła).
+func polishHyphenationPatterns() map[string]string {
+	return map[string]string{
+		// Common prefixes
+		".naj3": ".naj3",
+
+		// Common suffixes
+		"5s2ki.": "5s2ki.",
+		"5s2ka.": "5s2ka.",
+		"5s2ko.": "5s2ko.",
+		"5c2ki.": "5c2ki.",
+		"5c2ka.": "5c2ka.",
+
+		// Word-initial consonant clusters are not split
+		".br2": ".br2",
+		".ch2": ".ch2",
+		".cz2": ".cz2",
+		".dr2": ".dr2",
+		".dz2": ".dz2",
+		".gr2": ".gr2",
+		".kl2": ".kl2",
+		".kr2": ".kr2",
+		".pl2": ".pl2",
+		".pr2": ".pr2",
+		".sk2": ".sk2",
+		".sp2": ".sp2",
+		".st2": ".st2",
+		".sz2": ".sz2",
+		".tr2": ".tr2",
+		".wr2": ".wr2",
+		".zw2": ".zw2",
+
+		// Consonant-vowel patterns
+		"1ba": "1ba",
+		"1be": "1be",
+		"1bi": "1bi",
+		"1bo": "1bo",
+		"1bu": "1bu",
+		"1by": "1by",
+		"1ca": "1ca",
+		"1ce": "1ce",
+		"1ci": "1ci",
+		"1co": "1co",
+		"1cu": "1cu",
+		"1cy": "1cy",
+		"1ća": "1ća",
+		"1će": "1će",
+		"1ći": "1ći",
+		"1ćo": "1ćo",
+		"1ću": "1ću",
+		"1ćy": "1ćy",
+		"1da": "1da",
+		"1de": "1de",
+		"1di": "1di",
+		"1do": "1do",
+		"1du": "1du",
+		"1dy": "1dy",
+		"1fa": "1fa",
+		"1fe": "1fe",
+		"1fi": "1fi",
+		"1fo": "1fo",
+		"1fu": "1fu",
+		"1fy": "1fy",
+		"1ga": "1ga",
+		"1ge": "1ge",
+		"1gi": "1gi",
+		"1go": "1go",
+		"1gu": "1gu",
+		"1gy": "1gy",
+		"1ha": "1ha",
+		"1he": "1he",
+		"1hi": "1hi",
+		"1ho": "1ho",
+		"1hu": "1hu",
+		"1hy": "1hy",
+		"1ja": "1ja",
+		"1je": "1je",
+		"1ji": "1ji",
+		"1jo": "1jo",
+		"1ju": "1ju",
+		"1jy": "1jy",
+		"1ka": "1ka",
+		"1ke": "1ke",
+		"1ki": "1ki",
+		"1ko": "1ko",
+		"1ku": "1ku",
+		"1ky": "1ky",
+		"1la": "1la",
+		"1le": "1le",
+		"1li": "1li",
+		"1lo": "1lo",
+		"1lu": "1lu",
+		"1ly": "1ly",
+		"1ła": "1ła",
+		"1łe": "1łe",
+		"1łi": "1łi",
+		"1ło": "1ło",
+		"1łu": "1łu",
+		"1ły": "1ły",
+		"1ma": "1ma",
+		"1me": "1me",
+		"1mi": "1mi",
+		"1mo": "1mo",
+		"1mu": "1mu",
+		"1my": "1my",
+		"1na": "1na",
+		"1ne": "1ne",
+		"1ni": "1ni",
+		"1no": "1no",
+		"1nu": "1nu",
+		"1ny": "1ny",
+		"1ńa": "1ńa",
+		"1ńe": "1ńe",
+		"1ńi": "1ńi",
+		"1ńo": "1ńo",
+		"1ńu": "1ńu",
+		"1ńy": "1ńy",
+		"1pa": "1pa",
+		"1pe": "1pe",
+		"1pi": "1pi",
+		"1po": "1po",
+		"1pu": "1pu",
+		"1py": "1py",
+		"1ra": "1ra",
+		"1re": "1re",
+		"1ri": "1ri",
+		"1ro": "1ro",
+		"1ru": "1ru",
+		"1ry": "1ry",
+		"1sa": "1sa",
+		"1se": "1se",
+		"1si": "1si",
+		"1so": "1so",
+		"1su": "1su",
+		"1sy": "1sy",
+		"1śa": "1śa",
+		"1śe": "1śe",
+		"1śi": "1śi",
+		"1śo": "1śo",
+		"1śu": "1śu",
+		"1śy": "1śy",
+		"1ta": "1ta",
+		"1te": "1te",
+		"1ti": "1ti",
+		"1to": "1to",
+		"1tu": "1tu",
+		"1ty": "1ty",
+		"1wa": "1wa",
+		"1we": "1we",
+		"1wi": "1wi",
+		"1wo": "1wo",
+		"1wu": "1wu",
+		"1wy": "1wy",
+		"1za": "1za",
+		"1ze": "1ze",
+		"1zi": "1zi",
+		"1zo": "1zo",
+		"1zu": "1zu",
+		"1zy": "1zy",
+		"1źa": "1źa",
+		"1źe": "1źe",
+		"1źi": "1źi",
+		"1źo": "1źo",
+		"1źu": "1źu",
+		"1źy": "1źy",
+		"1ża": "1ża",
+		"1że": "1że",
+		"1żi": "1żi",
+		"1żo": "1żo",
+		"1żu": "1żu",
+		"1ży": "1ży",
+		"1bą": "1bą",
+		"1bę": "1bę",
+		"1bó": "1bó",
+		"1cą": "1cą",
+		"1cę": "1cę",
+		"1có": "1có",
+		"1ćą": "1ćą",
+		"1ćę": "1ćę",
+		"1ćó": "1ćó",
+		"1dą": "1dą",
+		"1dę": "1dę",
+		"1dó": "1dó",
+		"1fą": "1fą",
+		"1fę": "1fę",
+		"1fó": "1fó",
+		"1gą": "1gą",
+		"1gę": "1gę",
+		"1gó": "1gó",
+		"1hą": "1hą",
+		"1hę": "1hę",
+		"1hó": "1hó",
+		"1ją": "1ją",
+		"1ję": "1ję",
+		"1jó": "1jó",
+		"1ką": "1ką",
+		"1kę": "1kę",
+		"1kó": "1kó",
+		"1lą": "1lą",
+		"1lę": "1lę",
+		"1ló": "1ló",
+		"1łą": "1łą",
+		"1łę": "1łę",
+		"1łó": "1łó",
+		"1mą": "1mą",
+		"1mę": "1mę",
+		"1mó": "1mó",
+		"1ną": "1ną",
+		"1nę": "1nę",
+		"1nó": "1nó",
+		"1ńą": "1ńą",
+		"1ńę": "1ńę",
+		"1ńó": "1ńó",
+		"1pą": "1pą",
+		"1pę": "1pę",
+		"1pó": "1pó",
+		"1rą": "1rą",
+		"1rę": "1rę",
+		"1ró": "1ró",
+		"1są": "1są",
+		"1sę": "1sę",
+		"1só": "1só",
+		"1śą": "1śą",
+		"1śę": "1śę",
+		"1śó": "1śó",
+		"1tą": "1tą",
+		"1tę": "1tę",
+		"1tó": "1tó",
+		"1wą": "1wą",
+		"1wę": "1wę",
+		"1wó": "1wó",
+		"1zą": "1zą",
+		"1zę": "1zę",
+		"1zó": "1zó",
+		"1źą": "1źą",
+		"1źę": "1źę",
+		"1źó": "1źó",
+		"1żą": "1żą",
+		"1żę": "1żę",
+		"1żó": "1żó",
+
+		// Digraphs
+		"1c2h": "1c2h",
+		"1c2z": "1c2z",
+		"1d2z": "1d2z",
+		"1d2ź": "1d2ź",
+		"1d2ż": "1d2ż",
+		"1r2z": "1r2z",
+		"1s2z": "1s2z",
+		"sz2k": "sz2k",
+	}
+}
+
+// Hyphenate returns hyphenation points for a word using Liang's algorithm.
+//
+// Returns rune indices where hyphenation is allowed: a point i means the
+// word may break before its i-th rune.
+// Uses pattern matching with priority levels to determine break points.
+//
+// Example:
+//
+//	dict := text.NewEnglishHyphenation()
+//	points := dict.Hyphenate("example")
+//	// Returns []int{2, 4} for ex-am-ple
+func (h *HyphenationDictionary) Hyphenate(word string) []int {
+	// Exceptions bypass pattern matching and the length limits
+	if points, ok := h.exceptions[lowerRunes(word)]; ok {
+		return append([]int(nil), points...)
+	}
+
+	runes := []rune(word)
+
+	// Patterns are for ordinary words, not identifiers like "JavaScript"
+	if (h.options.SkipMixedCase || h.options.BreakAtCamelCase) && isMixedCaseWord(runes) {
+		if h.options.BreakAtCamelCase {
+			return camelCaseBoundaries(runes)
+		}
+		return nil
+	}
+
+	if len(runes) < h.minLeft+h.minRight {
+		return nil // Too short to hyphenate
+	}
+
+	// Normalize word: lowercase and add delimiters. Runes are lowercased
+	// one by one so indices stay aligned with the original word.
+	normalized := make([]rune, 0, len(runes)+2)
+	normalized = append(normalized, '.')
+	for _, r := range runes {
+		normalized = append(normalized, unicode.ToLower(r))
+	}
+	normalized = append(normalized, '.')
+
+	// Initialize priority array (one value between each character)
+	// Length is len(normalized) + 1 to account for positions
+	priorities := make([]int, len(normalized)+1)
+
+	// Apply all matching patterns
+	for pattern := range h.patterns {
+		h.applyPattern(normalized, pattern, priorities)
+	}
+
+	// Extract hyphenation points
+	var points []int
+	for i := h.minLeft; i <= len(runes)-h.minRight; i++ {
+		// i+1 because priorities[0] is before first char
+		// Odd priorities indicate allowed breaks
+		if priorities[i+1]%2 == 1 {
+			points = append(points, i)
+		}
+	}
+
+	return points
+}
+
+// applyPattern applies a single hyphenation pattern to the word.
+// Matching and priorities are per rune, so patterns with accented letters
+// ("1bä", ".über3") line up with the word's characters.
+func (h *HyphenationDictionary) applyPattern(word []rune, pattern string, priorities []int) {
+	// Extract letters and numbers from pattern
+	var patternLetters []rune
+	patternNumbers := make([]int, len(pattern)+1)
+
+	for _, ch := range pattern {
+		if ch >= '0' && ch <= '9' {
+			patternNumbers[len(patternLetters)] = int(ch - '0')
+		} else {
+			patternLetters = append(patternLetters, ch)
+		}
+	}
+
+	// Find all occurrences of the letter pattern in the word
+	for i := 0; i <= len(word)-len(patternLetters); i++ {
+		if !runesEqual(word[i:i+len(patternLetters)], patternLetters) {
+			continue
+		}
+		// Apply priority numbers
+		for j := 0; j <= len(patternLetters); j++ {
+			if patternNumbers[j] > priorities[i+j] {
+				priorities[i+j] = patternNumbers[j]
+			}
+		}
+	}
+}
+
+// runesEqual reports whether a and b hold the same runes.
+func runesEqual(a, b []rune) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// AddException sets the exact hyphenation points for word, overriding the
+// patterns, like TeX's \hyphenation list. Points are rune indices into the
+// word, as returned by Hyphenate; an empty list forbids hyphenating the
+// word. Matching is case-insensitive.
+//
+// Example:
+//
+//	dict := text.NewEnglishHyphenation()
+//	dict.AddException("present", []int{4}) // pres-ent (noun)
+//	dict.AddException("table", nil)        // never hyphenate
+func (h *HyphenationDictionary) AddException(word string, points []int) {
+	if h.exceptions == nil {
+		h.exceptions = make(map[string][]int)
+	}
+	points = append([]int(nil), points...)
+	sort.Ints(points)
+	h.exceptions[lowerRunes(word)] = points
+}
+
+// lowerRunes lowercases word one rune at a time. Unlike strings.ToLower it
+// never changes the rune count ("İ" becomes "i", not "i̇"), so rune indices
+// into the result are rune indices into word.
+func lowerRunes(word string) string {
+	return strings.Map(unicode.ToLower, word)
+}
+
+// HyphenateWithString returns the hyphenated word with hyphens inserted.
+//
+// Example:
+//
+//	dict := text.NewEnglishHyphenation()
+//	result := dict.HyphenateWithString("example", "-")
+//	// Returns "ex-am-ple"
+func (h *HyphenationDictionary) HyphenateWithString(word, hyphen string) string {
+	points := h.Hyphenate(word)
+	if len(points) == 0 {
+		return word
+	}
+
+	runes := []rune(word)
+	var result strings.Builder
+	lastPos := 0
+
+	for _, pos := range points {
+		if pos <= lastPos || pos >= len(runes) {
+			continue
+		}
+		result.WriteString(string(runes[lastPos:pos]))
+		result.WriteString(hyphen)
+		lastPos = pos
+	}
+	result.WriteString(string(runes[lastPos:]))
+
+	return result.String()
+}
+
+// ═══════════════════════════════════════════════════════════════
+//  Mixed-Case Words
+// ═══════════════════════════════════════════════════════════════
+
+// HyphenationOptions controls how Hyphenate treats words that contain an
+// uppercase letter after the first rune or a digit, such as "JavaScript",
+// "getElementById", "NASA" or "H2O". By default such words are lowercased
+// and hyphenated by the patterns like any other word.
+type HyphenationOptions struct {
+	// SkipMixedCase returns no hyphenation points for mixed-case words.
+	SkipMixedCase bool
+
+	// BreakAtCamelCase returns the camelCase boundaries of mixed-case words
+	// instead of pattern points: "getElementById" breaks as
+	// get-Element-By-Id. Words without such a boundary ("H2O", "NASA") get
+	// no points. Takes precedence over SkipMixedCase.
+	BreakAtCamelCase bool
+}
+
+// SetOptions sets how the dictionary hyphenates mixed-case words.
+// Exceptions added with AddException still take precedence.
+//
+// Example:
+//
+//	dict := text.NewEnglishHyphenation()
+//	dict.SetOptions(text.HyphenationOptions{BreakAtCamelCase: true})
+//	dict.Hyphenate("JavaScript") // []int{4}
+func (h *HyphenationDictionary) SetOptions(opts HyphenationOptions) {
+	h.options = opts
+}
+
+// isMixedCaseWord reports whether word has an uppercase letter after its
+// first rune, or any digit.
+func isMixedCaseWord(word []rune) bool {
+	for i, r := range word {
+		if unicode.IsDigit(r) || (i > 0 && unicode.IsUpper(r)) {
+			return true
+		}
+	}
+	return false
+}
+
+// camelCaseBoundaries returns the rune indices that start a new camelCase
+// part: an uppercase letter after a lowercase one ("getElement"), or the
+// last capital of an acronym followed by lowercase ("XMLHttp").
+func camelCaseBoundaries(word []rune) []int {
+	var points []int
+	for i := 1; i < len(word); i++ {
+		if !unicode.IsUpper(word[i]) {
+			continue
+		}
+		prev := word[i-1]
+		if unicode.IsLower(prev) ||
+			(unicode.IsUpper(prev) && i+1 < len(word) && unicode.IsLower(word[i+1])) {
+			points = append(points, i)
+		}
+	}
+	return points
+}
+
+// ═══════════════════════════════════════════════════════════════
+//  Language Selection
+// ═══════════════════════════════════════════════════════════════
+
+// HyphenationRegistry maps BCP 47 language tags to hyphenation dictionaries.
+//
+// Tags are matched case-insensitively, with "_" accepted for "-", so
+// "de_CH", "DE-ch" and "de-CH" name the same entry. The zero value is an
+// empty registry ready to use, and a registry is safe for concurrent use.
+//
+// Example:
+//
+//	registry := text.NewHyphenationRegistry()
+//	registry.Register("en", text.NewEnglishHyphenation())
+//	registry.Register("de", text.NewGermanHyphenation())
+//	registry.Register("de-CH", swissGerman)
+//	dict, ok := registry.Lookup("de-AT") // German dictionary
+type HyphenationRegistry struct {
+	mu    sync.RWMutex
+	dicts map[string]*HyphenationDictionary // canonical tag -> dictionary
+}
+
+// NewHyphenationRegistry returns an empty HyphenationRegistry.
+func NewHyphenationRegistry() *HyphenationRegistry {
+	return &HyphenationRegistry{}
+}
+
+// Register adds dict to the registry under tag, replacing any dictionary
+// registered under the same tag in another spelling ("de_CH", "DE-ch").
+// Registering a nil dictionary removes the tag.
+func (r *HyphenationRegistry) Register(tag string, dict *HyphenationDictionary) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tag = canonicalLanguageTag(tag)
+	if dict == nil {
+		delete(r.dicts, tag)
+		return
+	}
+	if r.dicts == nil {
+		r.dicts = make(map[string]*HyphenationDictionary)
+	}
+	r.dicts[tag] = dict
+}
+
+// Lookup returns the dictionary for tag. If there is no entry for the full
+// tag, subtags are dropped from the end until one matches
+// ("de-CH-1996" → "de-CH" → "de").
+func (r *HyphenationRegistry) Lookup(tag string) (*HyphenationDictionary, bool) {
+	return lookupLanguage(tag, r.get)
+}
+
+// get returns the dictionary registered under the canonical tag.
+func (r *HyphenationRegistry) get(tag string) (*HyphenationDictionary, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	dict, ok := r.dicts[tag]
+	return dict, ok
+}
+
+// canonicalLanguageTag lowercases tag and replaces "_" with "-", the form
+// HyphenationRegistry stores tags in.
+func canonicalLanguageTag(tag string) string {
+	return strings.ToLower(strings.ReplaceAll(tag, "_", "-"))
+}
+
+// lookupLanguage calls find with the canonical form of tag, then with
+// subtags dropped from the end, until find reports a dictionary.
+func lookupLanguage(tag string, find func(tag string) (*HyphenationDictionary, bool)) (*HyphenationDictionary, bool) {
+	tag = canonicalLanguageTag(tag)
+	for tag != "" {
+		if dict, ok := find(tag); ok {
+			return dict, true
+		}
+		i := strings.LastIndexByte(tag, '-')
+		if i < 0 {
+			break
+		}
+		tag = tag[:i]
+	}
+	return nil, false
+}
+
+// builtinHyphenation maps primary language subtags to the constructors of
+// the built-in dictionaries.
+var builtinHyphenation = map[string]func() *HyphenationDictionary{
+	"en": NewEnglishHyphenation,
+	"fr": NewFrenchHyphenation,
+	"de": NewGermanHyphenation,
+	"es": NewSpanishHyphenation,
+	"sv": NewSwedishHyphenation,
+	"no": NewNorwegianHyphenation,
+	"nb": NewNorwegianHyphenation,
+	"nn": NewNorwegianHyphenation,
+	"da": NewDanishHyphenation,
+	"it": NewItalianHyphenation,
+	"pt": NewPortugueseHyphenation,
+	"nl": NewDutchHyphenation,
+	"pl": NewPolishHyphenation,
+}
+
+// registeredHyphenation holds the dictionaries added with
+// RegisterHyphenation.
+var registeredHyphenation HyphenationRegistry
+
+// RegisterHyphenation makes dict available to HyphenationForLanguage under
+// tag, taking precedence over the built-in dictionary for that tag. It is
+// safe for concurrent use. Registering a nil dictionary removes the tag.
+//
+// Example:
+//
+//	f, _ := os.Open("hyph-de-1996.tex")
+//	dict, _ := text.NewHyphenationFromTeX(f, 2, 2)
+//	text.RegisterHyphenation("de", dict)
+func RegisterHyphenation(tag string, dict *HyphenationDictionary) {
+	registeredHyphenation.Register(tag, dict)
+}
+
+// HyphenationForLanguage returns the hyphenation dictionary for a BCP 47
+// language tag such as "en", "en-US", or "de-CH".
+//
+// Dictionaries registered with RegisterHyphenation are preferred, then the
+// built-in ones. If the full tag has no dictionary, subtags are dropped from
+// the end until one matches, so "de-CH" falls back to "de". Built-in
+// dictionaries are newly created on each call, so exceptions added to one
+// do not affect other callers.
+//
+// Example:
+//
+//	dict, ok := text.HyphenationForLanguage("pt-BR")
+//	if ok {
+//	    points := dict.Hyphenate("palavra")
+//	}
+func HyphenationForLanguage(tag string) (*HyphenationDictionary, bool) {
+	return lookupLanguage(tag, func(tag string) (*HyphenationDictionary, bool) {
+		if dict, ok := registeredHyphenation.get(tag); ok {
+			return dict, true
+		}
+		if newDict, ok := builtinHyphenation[tag]; ok {
+			return newDict(), true
+		}
+		return nil, false
+	})
+}
+
+// ═══════════════════════════════════════════════════════════════
+//  Loading TeX Pattern Files
+// ═══════════════════════════════════════════════════════════════
+
+// LoadHyphenationPatterns parses TeX hyphenation patterns from r.
+//
+// It accepts the hyph-utf8 .tex format, reading the patterns inside the
+// \patterns{...} block, as well as plain pattern lists (.pat.txt files)
+// with one or more whitespace-separated patterns per line. Comments
+// starting with % run to the end of the line. \hyphenation{...} exception
+// lists and other commands with a braced argument (\message{...}) are
+// skipped; NewHyphenationFromTeX loads the exceptions as well.
+//
+// The result can be passed to NewHyphenationDictionary.
+//
+// Example:
+//
+//	f, _ := os.Open("hyph-en-us.tex")
+//	defer f.Close()
+//	patterns, err := text.LoadHyphenationPatterns(f)
+func LoadHyphenationPatterns(r io.Reader) (map[string]string, error) {
+	patterns, _, err := parseTeXHyphenation(r)
+	return patterns, err
+}
+
+// parseTeXHyphenation parses the patterns and the \hyphenation exception
+// words (such as "ta-ble") of a TeX pattern file.
+func parseTeXHyphenation(r io.Reader) (map[string]string, []string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		patterns    []string // tokens inside \patterns{...}
+		exceptions  []string // tokens inside \hyphenation{...}
+		loose       []string // tokens outside any command
+		hasPatterns bool
+	)
+
+	// Strip comments, then tokenize; braces are tokens of their own.
+	var tokens []string
+	for _, line := range strings.Split(string(data), "\n") {
+		if i := strings.IndexByte(line, '%'); i >= 0 {
+			line = line[:i]
+		}
+		line = strings.NewReplacer("{", " { ", "}", " } ").Replace(line)
+		tokens = append(tokens, strings.Fields(line)...)
+	}
+
+	for i := 0; i < len(tokens); i++ {
+		tok := tokens[i]
+
+		if !strings.HasPrefix(tok, "\\") {
+			if tok == "{" || tok == "}" {
+				return nil, nil, fmt.Errorf("text: unexpected %q in hyphenation patterns", tok)
+			}
+			loose = append(loose, tok)
+			continue
+		}
+
+		// A command: find the braced argument, if any.
+		if i+1 >= len(tokens) || tokens[i+1] != "{" {
+			continue
+		}
+		end := i + 2
+		for depth := 1; ; end++ {
+			if end >= len(tokens) {
+				return nil, nil, fmt.Errorf("text: unterminated %s block in hyphenation patterns", tok)
+			}
+			if tokens[end] == "{" {
+				depth++
+			} else if tokens[end] == "}" {
+				depth--
+				if depth == 0 {
+					break
+				}
+			}
+		}
+
+		switch tok {
+		case "\\patterns":
+			hasPatterns = true
+			patterns = append(patterns, tokens[i+2:end]...)
+		case "\\hyphenation":
+			exceptions = append(exceptions, tokens[i+2:end]...)
+		}
+		i = end
+	}
+
+	if !hasPatterns {
+		patterns = loose
+	}
+
+	result := make(map[string]string, len(patterns))
+	for _, pattern := range patterns {
+		if !isValidHyphenationPattern(pattern) {
+			return nil, nil, fmt.Errorf("text: invalid hyphenation pattern %q", pattern)
+		}
+		result[pattern] = pattern
+	}
+	return result, exceptions, nil
+}
+
+// NewHyphenationFromTeX creates a hyphenation dictionary from a TeX
+// pattern file. See LoadHyphenationPatterns for the accepted formats.
+// Words in a \hyphenation{...} block, written with hyphens at the allowed
+// points ("ta-ble"), are added as exceptions.
+//
+// Example:
+//
+//	f, _ := os.Open("hyph-en-us.tex")
+//	defer f.Close()
+//	dict, err := text.NewHyphenationFromTeX(f, 2, 3)
+func NewHyphenationFromTeX(r io.Reader, minLeft, minRight int) (*HyphenationDictionary, error) {
+	patterns, exceptions, err := parseTeXHyphenation(r)
+	if err != nil {
+		return nil, err
+	}
+
+	dict := NewHyphenationDictionary(patterns, minLeft, minRight)
+	for _, hyphenated := range exceptions {
+		var points []int
+		word := ""
+		for _, part := range strings.Split(hyphenated, "-") {
+			if word != "" {
+				points = append(points, utf8.RuneCountInString(word))
+			}
+			word += part
+		}
+		dict.AddException(word, points)
+	}
+	return dict, nil
+}
+
+// isValidHyphenationPattern reports whether p is a Liang pattern: letters
+// with interleaved digits, optionally anchored by a leading or trailing dot.
+func isValidHyphenationPattern(p string) bool {
+	letters := 0
+	for i, r := range p {
+		switch {
+		case r == '.':
+			if i != 0 && i != len(p)-1 {
+				return false
+			}
+		case r >= '0' && r <= '9':
+		case r == '\\' || r == '{' || r == '}':
+			return false
+		default:
+			letters++
+		}
+	}
+	return letters > 0
 }
 
 // ═══════════════════════════════════════════════════════════════
@@ -1299,6 +2707,8 @@ func NewEnglishDictionaryWithHyphenation() *EnglishDictionaryWithHyphenation {
 }
 
 // GetHyphenationPoints implements DictionaryProvider with actual hyphenation.
+// Points are rune indices into word, as returned by Hyphenate, so "café"
+// and other non-ASCII words must be split with []rune(word), not by byte.
 func (d *EnglishDictionaryWithHyphenation) GetHyphenationPoints(word string) []int {
 	if d.hyphenation == nil {
 		return nil
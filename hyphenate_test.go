@@ -1,7 +1,11 @@
 package text
 
 import (
+	"errors"
+	"fmt"
+	"strings"
 	"testing"
+	"testing/iotest"
 )
 
 // ═══════════════════════════════════════════════════════════════
@@ -379,6 +383,79 @@ func TestGermanHyphenation(t *testing.T) {
 	}
 }
 
+func TestGermanHyphenation_Umlauts(t *testing.T) {
+	dict := NewGermanHyphenation()
+
+	tests := []struct {
+		word   string
+		points []int // rune indices
+		want   string
+	}{
+		{"Begrüßung", []int{2, 5}, "Be-grü-ßung"},   // ß starts the next syllable
+		{"Übergrößen", []int{4, 7}, "Über-grö-ßen"}, // .über3 matches at the word start
+		{"Zeitung", []int{3}, "Zei-tung"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			points := dict.Hyphenate(tt.word)
+			if fmt.Sprint(points) != fmt.Sprint(tt.points) {
+				t.Errorf("Hyphenate(%q) = %v, want %v", tt.word, points, tt.points)
+			}
+			if got := dict.HyphenateWithString(tt.word, "-"); got != tt.want {
+				t.Errorf("HyphenateWithString(%q) = %q, want %q", tt.word, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHyphenate_RuneIndices(t *testing.T) {
+	patterns := map[string]string{"1bä": "1bä", "ä1c": "ä1c"}
+	dict := NewHyphenationDictionary(patterns, 1, 1)
+
+	// "abäcd": the break before "b" is rune 1 and after "ä" is rune 3,
+	// though "ä" occupies bytes 2-3.
+	if got := dict.Hyphenate("abäcd"); fmt.Sprint(got) != "[1 3]" {
+		t.Errorf("Hyphenate(abäcd) = %v, want [1 3]", got)
+	}
+	if got := dict.HyphenateWithString("ABÄCD", "-"); got != "A-BÄ-CD" {
+		t.Errorf("HyphenateWithString(ABÄCD) = %q, want %q", got, "A-BÄ-CD")
+	}
+}
+
+func TestEnglishDictionaryWithHyphenation_RuneIndices(t *testing.T) {
+	dict := NewEnglishDictionaryWithHyphenation()
+	dict.hyphenation = NewHyphenationDictionary(map[string]string{"é1t": "é1t", "é1r": "é1r"}, 1, 1)
+
+	// "é" is two bytes, so byte offsets would be [5 8].
+	if got := dict.GetHyphenationPoints("cafétéria"); fmt.Sprint(got) != "[4 6]" {
+		t.Errorf("GetHyphenationPoints(cafétéria) = %v, want [4 6]", got)
+	}
+
+	runes := []rune("cafétéria")
+	points := dict.GetHyphenationPoints("cafétéria")
+	if got := string(runes[:points[0]]); got != "café" {
+		t.Errorf("first piece = %q, want %q", got, "café")
+	}
+	if got := dict.hyphenation.HyphenateWithString("Cafétéria", "-"); got != "Café-té-ria" {
+		t.Errorf("HyphenateWithString(Cafétéria) = %q, want %q", got, "Café-té-ria")
+	}
+}
+
+func TestHyphenationDictionary_ExceptionRuneIndices(t *testing.T) {
+	dict := NewHyphenationDictionary(nil, 2, 2)
+	dict.AddException("istanbul", []int{3})
+
+	// strings.ToLower("İ") is two runes; exceptions must still match and
+	// keep their rune indices.
+	if got := dict.Hyphenate("İSTANBUL"); fmt.Sprint(got) != "[3]" {
+		t.Errorf("Hyphenate(İSTANBUL) = %v, want [3]", got)
+	}
+	if got := dict.HyphenateWithString("İSTANBUL", "-"); got != "İST-ANBUL" {
+		t.Errorf("HyphenateWithString(İSTANBUL) = %q, want %q", got, "İST-ANBUL")
+	}
+}
+
 func TestSpanishHyphenation(t *testing.T) {
 	dict := NewSpanishHyphenation()
 
@@ -505,6 +582,135 @@ func TestDanishHyphenation(t *testing.T) {
 	}
 }
 
+func TestHyphenate_MinRightBound(t *testing.T) {
+	// minLeft and minRight are the fewest letters allowed on each side of a
+	// hyphen, as TeX's \lefthyphenmin and \righthyphenmin: a point leaving
+	// exactly minRight letters is allowed.
+	tests := []struct {
+		name string
+		dict *HyphenationDictionary
+		word string
+		want string
+	}{
+		{"English ex-am-ple", NewEnglishHyphenation(), "example", "ex-am-ple"}, // minRight 3, "ple"
+		{"English ta-ble", NewEnglishHyphenation(), "table", "ta-ble"},         // minRight 3, "ble"
+		{"German Tan-te", NewGermanHyphenation(), "Tante", "Tan-te"},           // minRight 2, "te"
+		{"German Son-ne", NewGermanHyphenation(), "Sonne", "Son-ne"},           // minRight 2, "ne"
+		{"Custom 1/1", NewHyphenationDictionary(map[string]string{"1c": "1c"}, 1, 1), "abc", "ab-c"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.dict.HyphenateWithString(tt.word, "-"); got != tt.want {
+				t.Errorf("HyphenateWithString(%q) = %q, want %q", tt.word, got, tt.want)
+			}
+		})
+	}
+
+	// No point ever leaves fewer than minRight letters.
+	english := NewEnglishHyphenation()
+	for _, word := range []string{"example", "table", "computer", "hyphenation", "algorithm"} {
+		for _, p := range english.Hyphenate(word) {
+			if right := len([]rune(word)) - p; right < 3 {
+				t.Errorf("Hyphenate(%q) point %d leaves %d letters, want at least 3", word, p, right)
+			}
+		}
+	}
+}
+
+func TestItalianHyphenation(t *testing.T) {
+	dict := NewItalianHyphenation()
+
+	tests := []struct {
+		word string
+		want string
+	}{
+		{"parola", "pa-ro-la"},
+		{"montagna", "mon-ta-gna"}, // gn is never split
+		{"fatto", "fat-to"},        // double consonants split
+		{"capra", "ca-pra"},        // consonant + r stays together
+		{"pasta", "pa-sta"},        // s + consonant starts the syllable
+		{"famiglia", "fa-mi-glia"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			if got := dict.HyphenateWithString(tt.word, "-"); got != tt.want {
+				t.Errorf("HyphenateWithString(%q) = %q, want %q", tt.word, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPortugueseHyphenation(t *testing.T) {
+	dict := NewPortugueseHyphenation()
+
+	tests := []struct {
+		word string
+		want string
+	}{
+		{"palavra", "pa-la-vra"},
+		{"trabalho", "tra-ba-lho"}, // lh is never split
+		{"nascer", "nas-cer"},      // sc splits
+		{"coração", "co-ra-ção"},
+		{"carro", "carro"}, // minRight 3
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			if got := dict.HyphenateWithString(tt.word, "-"); got != tt.want {
+				t.Errorf("HyphenateWithString(%q) = %q, want %q", tt.word, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDutchHyphenation(t *testing.T) {
+	dict := NewDutchHyphenation()
+
+	tests := []struct {
+		word string
+		want string
+	}{
+		{"boterham", "bo-ter-ham"},
+		{"letter", "let-ter"},
+		{"lachen", "la-chen"},        // ch is never split
+		{"bijzonder", "bij-zon-der"}, // ij is one vowel
+		{"schrijven", "schrij-ven"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			if got := dict.HyphenateWithString(tt.word, "-"); got != tt.want {
+				t.Errorf("HyphenateWithString(%q) = %q, want %q", tt.word, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPolishHyphenation(t *testing.T) {
+	dict := NewPolishHyphenation()
+
+	tests := []struct {
+		word string
+		want string
+	}{
+		{"czekolada", "cze-ko-la-da"}, // cz acts as one consonant
+		{"rzeka", "rze-ka"},           // minLeft and minRight of 2
+		{"szkoła", "szko-ła"},
+		{"samochód", "sa-mo-chód"},
+		{"polski", "pol-ski"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			if got := dict.HyphenateWithString(tt.word, "-"); got != tt.want {
+				t.Errorf("HyphenateWithString(%q) = %q, want %q", tt.word, got, tt.want)
+			}
+		})
+	}
+}
+
 // ═══════════════════════════════════════════════════════════════
 //  Custom Dictionary Tests
 // ═══════════════════════════════════════════════════════════════
@@ -568,6 +774,311 @@ func TestCustomDictionaryConstraints(t *testing.T) {
 	}
 }
 
+// ═══════════════════════════════════════════════════════════════
+//  Language Selection Tests
+// ═══════════════════════════════════════════════════════════════
+
+func TestHyphenationRegistry_Lookup(t *testing.T) {
+	en := NewEnglishHyphenation()
+	de := NewGermanHyphenation()
+	deCH := NewGermanHyphenation()
+	var registry HyphenationRegistry // the zero value is ready to use
+	registry.Register("en", en)
+	registry.Register("DE", de)
+	registry.Register("de_CH", deCH)
+
+	tests := []struct {
+		tag  string
+		want *HyphenationDictionary
+	}{
+		{"en", en},
+		{"en-US", en},
+		{"EN_gb", en},
+		{"de", de},
+		{"de-AT", de},
+		{"de-CH", deCH},
+		{"de-ch-1996", deCH},
+		{"fr", nil},
+		{"", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			got, ok := registry.Lookup(tt.tag)
+			if got != tt.want || ok != (tt.want != nil) {
+				t.Errorf("Lookup(%q) = %p, %v; want %p", tt.tag, got, ok, tt.want)
+			}
+		})
+	}
+
+	// Spellings of one tag share an entry, so the last registration wins.
+	fr, frCA := NewFrenchHyphenation(), NewFrenchHyphenation()
+	registry.Register("FR-ca", fr)
+	registry.Register("fr_CA", frCA)
+	if got, _ := registry.Lookup("fr-CA"); got != frCA {
+		t.Errorf("Lookup(fr-CA) = %p, want %p", got, frCA)
+	}
+	if got, ok := registry.Lookup("fr"); ok {
+		t.Errorf("Lookup(fr) = %p, want no entry", got)
+	}
+
+	registry.Register("DE-ch", nil)
+	if got, _ := registry.Lookup("de-CH"); got != de {
+		t.Errorf("after removing de-CH, Lookup(de-CH) = %p, want %p", got, de)
+	}
+}
+
+func TestHyphenationForLanguage(t *testing.T) {
+	tests := []struct {
+		tag  string
+		word string
+		want string
+		ok   bool
+	}{
+		{"en", "example", "ex-am-ple", true},
+		{"en-US", "example", "ex-am-ple", true},
+		{"de", "Tante", "Tan-te", true},
+		{"de-CH", "Tante", "Tan-te", true},
+		{"fr", "développement", "dé-ve-lop-pe-ment", true},
+		{"nb-NO", "nordmann", "nord-mann", true},
+		{"pt_BR", "palavra", "pa-la-vra", true},
+		{"PL", "rzeka", "rze-ka", true},
+		{"xx", "", "", false},
+		{"", "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			dict, ok := HyphenationForLanguage(tt.tag)
+			if ok != tt.ok {
+				t.Fatalf("HyphenationForLanguage(%q) ok = %v, want %v", tt.tag, ok, tt.ok)
+			}
+			if !ok {
+				return
+			}
+			if got := dict.HyphenateWithString(tt.word, "-"); got != tt.want {
+				t.Errorf("%s: %q -> %q, want %q", tt.tag, tt.word, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegisterHyphenation(t *testing.T) {
+	custom := NewHyphenationDictionary(map[string]string{}, 1, 1)
+	custom.AddException("Tante", []int{1})
+
+	RegisterHyphenation("de-CH", custom)
+	defer RegisterHyphenation("de-CH", nil)
+
+	if dict, _ := HyphenationForLanguage("de-CH"); dict != custom {
+		t.Error("de-CH did not return the registered dictionary")
+	}
+	if dict, _ := HyphenationForLanguage("de-ch-1996"); dict != custom {
+		t.Error("de-CH-1996 did not fall back to the registered de-CH dictionary")
+	}
+	if dict, _ := HyphenationForLanguage("de"); dict == custom {
+		t.Error("de returned the de-CH registration")
+	}
+
+	// Built-in dictionaries are fresh copies.
+	de, _ := HyphenationForLanguage("de")
+	de.AddException("Tante", nil)
+	if again, _ := HyphenationForLanguage("de"); again.HyphenateWithString("Tante", "-") != "Tan-te" {
+		t.Error("exception leaked into the built-in German dictionary")
+	}
+
+	RegisterHyphenation("de-CH", nil)
+	if dict, _ := HyphenationForLanguage("de-CH"); dict == custom {
+		t.Error("unregistered dictionary still returned")
+	}
+}
+
+// ═══════════════════════════════════════════════════════════════
+//  TeX Pattern Loading Tests
+// ═══════════════════════════════════════════════════════════════
+
+// liangTeX is a hyph-utf8 style file using the patterns from Liang's thesis.
+const liangTeX = `% hyph-xx.tex
+% Test patterns from "Word Hy-phen-a-tion by Com-put-er"
+\message{Loading test patterns}
+
+\patterns{ % patterns follow
+.hy3ph he2n
+hena4 hen5at   1na n2at
+1tio 2io % trailing comment
+}
+
+\hyphenation{
+ta-ble
+}
+`
+
+func TestLoadHyphenationPatterns(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{
+			name:  "TeX file",
+			input: liangTeX,
+			want:  []string{".hy3ph", "he2n", "hena4", "hen5at", "1na", "n2at", "1tio", "2io"},
+		},
+		{
+			name:  "Plain pattern list",
+			input: "% comment\n.ach4\n.ad4der .af1t\nzz5\n",
+			want:  []string{".ach4", ".ad4der", ".af1t", "zz5"},
+		},
+		{
+			name:  "UTF-8 patterns",
+			input: "\\patterns{.ä2b 1ße}",
+			want:  []string{".ä2b", "1ße"},
+		},
+		{
+			name:  "Empty patterns block",
+			input: "\\patterns{}",
+			want:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			patterns, err := LoadHyphenationPatterns(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("LoadHyphenationPatterns error: %v", err)
+			}
+			if len(patterns) != len(tt.want) {
+				t.Fatalf("got %d patterns %v, want %d", len(patterns), patterns, len(tt.want))
+			}
+			for _, p := range tt.want {
+				if patterns[p] != p {
+					t.Errorf("missing pattern %q", p)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadHyphenationPatterns_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"Unterminated block", "\\patterns{ 1na n2at"},
+		{"Stray brace", "1na }"},
+		{"Dot inside pattern", "\\patterns{ a.b1c }"},
+		{"Digits only", "\\patterns{ 12 }"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := LoadHyphenationPatterns(strings.NewReader(tt.input)); err == nil {
+				t.Errorf("expected error for %q", tt.input)
+			}
+		})
+	}
+
+	errRead := errors.New("read failed")
+	if _, err := NewHyphenationFromTeX(iotest.ErrReader(errRead), 2, 3); !errors.Is(err, errRead) {
+		t.Errorf("reader error = %v, want %v", err, errRead)
+	}
+}
+
+func TestNewHyphenationFromTeX(t *testing.T) {
+	dict, err := NewHyphenationFromTeX(strings.NewReader(liangTeX), 2, 3)
+	if err != nil {
+		t.Fatalf("NewHyphenationFromTeX error: %v", err)
+	}
+
+	if got := dict.HyphenateWithString("hyphenation", "-"); got != "hy-phen-ation" {
+		t.Errorf("hyphenation -> %q, want %q", got, "hy-phen-ation")
+	}
+
+	// \hyphenation{ta-ble} is loaded as an exception.
+	if got := dict.HyphenateWithString("Table", "-"); got != "Ta-ble" {
+		t.Errorf("Table -> %q, want %q", got, "Ta-ble")
+	}
+}
+
+func TestHyphenationDictionary_AddException(t *testing.T) {
+	dict := NewHyphenationDictionary(map[string]string{}, 2, 3)
+
+	if points := dict.Hyphenate("project"); len(points) != 0 {
+		t.Fatalf("project points = %v, want none before exception", points)
+	}
+
+	dict.AddException("Project", []int{3})
+
+	tests := []struct {
+		word string
+		want []int
+	}{
+		{"project", []int{3}},
+		{"PROJECT", []int{3}},
+		{"projects", nil}, // Exceptions match whole words only
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			got := dict.Hyphenate(tt.word)
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("Hyphenate(%q) = %v, want %v", tt.word, got, tt.want)
+			}
+		})
+	}
+
+	// Exceptions override patterns, including forbidding hyphenation.
+	english := NewEnglishHyphenation()
+	english.AddException("table", nil)
+	if points := english.Hyphenate("table"); len(points) != 0 {
+		t.Errorf("table points = %v, want none", points)
+	}
+	english.AddException("present", []int{3})
+	if got := english.HyphenateWithString("present", "-"); got != "pre-sent" {
+		t.Errorf("present -> %q, want %q", got, "pre-sent")
+	}
+}
+
+func TestHyphenationDictionary_SetOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		opts HyphenationOptions
+		word string
+		want []int
+	}{
+		{"Skip camelCase", HyphenationOptions{SkipMixedCase: true}, "JavaScript", nil},
+		{"Skip identifier", HyphenationOptions{SkipMixedCase: true}, "getElementById", nil},
+		{"Skip digits", HyphenationOptions{SkipMixedCase: true}, "H2O", nil},
+		{"Skip keeps capitalized words", HyphenationOptions{SkipMixedCase: true}, "Example", []int{2, 4}},
+		{"Camel JavaScript", HyphenationOptions{BreakAtCamelCase: true}, "JavaScript", []int{4}},
+		{"Camel identifier", HyphenationOptions{BreakAtCamelCase: true}, "getElementById", []int{3, 10, 12}},
+		{"Camel acronym prefix", HyphenationOptions{BreakAtCamelCase: true}, "XMLHttpRequest", []int{3, 7}},
+		{"Camel all caps", HyphenationOptions{BreakAtCamelCase: true}, "NASA", nil},
+		{"Camel digits", HyphenationOptions{BreakAtCamelCase: true}, "H2O", nil},
+		{"Camel wins over skip", HyphenationOptions{SkipMixedCase: true, BreakAtCamelCase: true}, "JavaScript", []int{4}},
+		{"Camel keeps lowercase words", HyphenationOptions{BreakAtCamelCase: true}, "example", []int{2, 4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dict := NewEnglishHyphenation()
+			dict.SetOptions(tt.opts)
+			got := dict.Hyphenate(tt.word)
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("Hyphenate(%q) = %v, want %v", tt.word, got, tt.want)
+			}
+		})
+	}
+
+	// Exceptions still take precedence over the options.
+	dict := NewEnglishHyphenation()
+	dict.SetOptions(HyphenationOptions{SkipMixedCase: true})
+	dict.AddException("JavaScript", []int{4})
+	if got := dict.HyphenateWithString("JavaScript", "-"); got != "Java-Script" {
+		t.Errorf("JavaScript -> %q, want %q", got, "Java-Script")
+	}
+}
+
 // ═══════════════════════════════════════════════════════════════
 //  Benchmark Tests
 // ═══════════════════════════════════════════════════════════════
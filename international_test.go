@@ -191,6 +191,40 @@ func TestCJK_Japanese(t *testing.T) {
 	}
 }
 
+func TestCJK_HalfwidthKatakana(t *testing.T) {
+	// "ガ" written as halfwidth KA (U+FF76) + halfwidth voiced sound mark (U+FF9E)
+	const halfwidthGa = "ｶﾞ"
+
+	tests := []struct {
+		name    string
+		compose bool
+		text    string
+		want    float64
+	}{
+		{"Base alone", false, "ｶ", 1},
+		{"Base + dakuten, separate cells", false, halfwidthGa, 2},
+		{"Base + dakuten, composed", true, halfwidthGa, 1},
+		{"Base + handakuten, composed", true, "ﾊﾟ", 1}, // ﾊﾟ
+		{"Standalone dakuten, composed", true, "ﾞ", 1},
+		{"Word, composed", true, "ﾊﾟﾝ", 2}, // ﾊﾟﾝ
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			txt := New(Config{ComposeHalfwidthKana: tt.compose})
+			if got := txt.Width(tt.text); got != tt.want {
+				t.Errorf("Width(%q) = %.1f, want %.1f", tt.text, got, tt.want)
+			}
+		})
+	}
+
+	// The pair is one grapheme cluster either way, so it never wraps apart.
+	txt := NewTerminal()
+	if n := txt.GraphemeCount(halfwidthGa); n != 1 {
+		t.Errorf("GraphemeCount(%q) = %d, want 1", halfwidthGa, n)
+	}
+}
+
 func TestCJK_Korean(t *testing.T) {
 	txt := NewTerminal()
 
@@ -438,6 +472,31 @@ func TestScripts_Thai(t *testing.T) {
 	}
 }
 
+func TestScripts_PersianZWNJ(t *testing.T) {
+	txt := NewTerminal()
+
+	// "می‌خواهم" (I want) uses ZWNJ (U+200C) between the prefix and the stem.
+	word := "می‌خواهم"
+	withoutZWNJ := "میخواهم"
+
+	if got := TerminalMeasure('\u200C'); got != 0 {
+		t.Errorf("TerminalMeasure(ZWNJ) = %.1f, want 0", got)
+	}
+	if got, want := txt.Width(word), txt.Width(withoutZWNJ); got != want {
+		t.Errorf("Width(%q) = %.1f, want %.1f (ZWNJ is zero width)", word, got, want)
+	}
+
+	// The word must never be split at the ZWNJ, even when it overflows.
+	sentence := "من " + word
+	lines := txt.Wrap(sentence, WrapOptions{MaxWidth: 5})
+	if len(lines) != 2 {
+		t.Fatalf("Wrap(%q) = %d lines, want 2", sentence, len(lines))
+	}
+	if lines[1].Content != word {
+		t.Errorf("second line = %q, want whole word %q", lines[1].Content, word)
+	}
+}
+
 func TestScripts_Devanagari(t *testing.T) {
 	txt := NewTerminal()
 
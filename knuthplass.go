@@ -3,6 +3,8 @@ package text
 import (
 	"math"
 	"strings"
+
+	"github.com/SCKelemen/unicode/v6/uax29"
 )
 
 // Knuth-Plass Line Breaking Algorithm
@@ -48,6 +50,15 @@ type KnuthPlassOptions struct {
 	// LinePenalty is the penalty for each line (encourages fewer lines)
 	// Default: 10
 	LinePenalty float64
+
+	// BreakLongWords splits words wider than MaxWidth into pieces that fit,
+	// instead of letting them overflow. Pieces end at hyphenation points when
+	// Hyphenate is true, otherwise at grapheme cluster boundaries, so CJK
+	// characters and emoji ZWJ sequences are never split mid-cluster.
+	// When set, no line is allowed to exceed MaxWidth (unless a single
+	// grapheme is itself wider).
+	// Default: false
+	BreakLongWords bool
 }
 
 // DefaultKnuthPlassOptions returns sensible defaults.
@@ -105,6 +116,9 @@ func (t *Text) WrapKnuthPlass(text string, opts KnuthPlassOptions) []Line {
 	if len(boxes) == 0 {
 		return nil
 	}
+	if opts.BreakLongWords {
+		boxes = t.splitLongBoxes(boxes, opts)
+	}
 
 	// Find optimal breakpoints using dynamic programming
 	breakpoints := t.findOptimalBreakpoints(boxes, opts)
@@ -156,6 +170,100 @@ func (t *Text) textToBoxes(text string) []box {
 	return boxes
 }
 
+// splitLongBoxes replaces word boxes wider than opts.MaxWidth with
+// consecutive sub-boxes that each fit. Sub-boxes are not separated by glue,
+// so the line breaker may break between them.
+func (t *Text) splitLongBoxes(boxes []box, opts KnuthPlassOptions) []box {
+	var hyphenation *HyphenationDictionary
+	if opts.Hyphenate {
+		hyphenation = NewEnglishHyphenation()
+	}
+
+	result := make([]box, 0, len(boxes))
+	for _, b := range boxes {
+		if b.isGlue || b.width <= opts.MaxWidth {
+			result = append(result, b)
+			continue
+		}
+		result = append(result, t.splitWord(b, opts.MaxWidth, hyphenation)...)
+	}
+	return result
+}
+
+// splitWord packs the pieces of a word greedily into boxes no wider than
+// maxWidth. Pieces are hyphenation syllables when hyphenation is non-nil
+// (falling back to graphemes for syllables that are still too wide), or
+// grapheme clusters otherwise.
+func (t *Text) splitWord(word box, maxWidth float64, hyphenation *HyphenationDictionary) []box {
+	graphemes := uax29.Graphemes(word.content)
+
+	// Byte offsets of grapheme boundaries, so hyphenation points that
+	// would fall inside a cluster are ignored.
+	boundaries := make(map[int]bool, len(graphemes))
+	offset := 0
+	for _, g := range graphemes {
+		offset += len(g)
+		boundaries[offset] = true
+	}
+
+	var pieces []string
+	if hyphenation != nil {
+		last := 0
+		toBytes := runeToByteCursor(word.content)
+		for _, p := range hyphenation.Hyphenate(word.content) {
+			point := toBytes(p)
+			if boundaries[point] && point > last {
+				pieces = append(pieces, word.content[last:point])
+				last = point
+			}
+		}
+		pieces = append(pieces, word.content[last:])
+	} else {
+		pieces = []string{word.content}
+	}
+
+	var units []string
+	for _, piece := range pieces {
+		if t.Width(piece) > maxWidth {
+			units = append(units, uax29.Graphemes(piece)...)
+		} else {
+			units = append(units, piece)
+		}
+	}
+
+	var result []box
+	var current strings.Builder
+	currentWidth := 0.0
+	position := word.position
+
+	flush := func() {
+		if current.Len() == 0 {
+			return
+		}
+		content := current.String()
+		result = append(result, box{
+			content:  content,
+			width:    currentWidth,
+			position: position,
+		})
+		position += len([]rune(content))
+		current.Reset()
+		currentWidth = 0
+	}
+
+	for _, unit := range units {
+		unitWidth := t.Width(unit)
+		if currentWidth+unitWidth > maxWidth {
+			flush()
+		}
+		current.WriteString(unit)
+		currentWidth += unitWidth
+	}
+	flush()
+
+	return result
+}
+
 // findOptimalBreakpoints uses dynamic programming to find the best set of breakpoints.
 func (t *Text) findOptimalBreakpoints(boxes []box, opts KnuthPlassOptions) []int {
 	if len(boxes) == 0 {
@@ -190,8 +298,8 @@ func (t *Text) findOptimalBreakpoints(boxes []box, opts KnuthPlassOptions) []int
 
 			// Calculate adjustment ratio
 			ratio := (opts.MaxWidth - lineWidth) / opts.MaxWidth
-			if ratio < -1 {
-				// Line is too full, can't break here
+			if ratio < 0 {
+				// Line is too full; glue never shrinks, so it would overflow
 				continue
 			}
 
@@ -240,11 +348,20 @@ func (t *Text) findOptimalBreakpoints(boxes []box, opts KnuthPlassOptions) []int
 		}
 	}
 
-	// Find best final breakpoint
+	// Find best final breakpoint: it must end after the last word,
+	// otherwise the trailing text would be left unbroken.
+	end := len(boxes)
+	for end > 0 && boxes[end-1].isGlue {
+		end--
+	}
+
 	var best *breakpoint
 	minDemerits := math.MaxFloat64
 
 	for _, node := range active {
+		if node.position != end {
+			continue
+		}
 		if node.demerits < minDemerits {
 			minDemerits = node.demerits
 			best = node
@@ -267,7 +384,12 @@ func (t *Text) findOptimalBreakpoints(boxes []box, opts KnuthPlassOptions) []int
 }
 
 // calculateLineWidth computes the width of text from boxes[start] to boxes[end].
+// Glue at the start of the line is skipped, since it is discarded at the break.
 func (t *Text) calculateLineWidth(boxes []box, start, end int) float64 {
+	for start < end && boxes[start].isGlue {
+		start++
+	}
+
 	width := 0.0
 	for i := start; i <= end && i < len(boxes); i++ {
 		width += boxes[i].width
@@ -2,6 +2,7 @@ package text
 
 import (
 	"math"
+	"reflect"
 	"testing"
 )
 
@@ -103,6 +104,109 @@ func TestWrapKnuthPlass_SingleWord(t *testing.T) {
 	}
 }
 
+func TestWrapKnuthPlass_BreakLongWords(t *testing.T) {
+	txt := NewTerminal()
+
+	tests := []struct {
+		name      string
+		text      string
+		maxWidth  float64
+		hyphenate bool
+	}{
+		{"Long word", "Supercalifragilisticexpialidocious", 10, false},
+		{"Long word between words", "a Supercalifragilisticexpialidocious word", 10, false},
+		{"Long word with hyphenation", "a Supercalifragilisticexpialidocious word", 10, true},
+		{"CJK run", "世界世界世界世界世界世界 ok", 5, false},
+		{"ZWJ sequences", "👨‍👩‍👧👨‍👩‍👧👨‍👩‍👧👨‍👩‍👧", 5, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := DefaultKnuthPlassOptions(tt.maxWidth)
+			opts.BreakLongWords = true
+			opts.Hyphenate = tt.hyphenate
+
+			lines := txt.WrapKnuthPlass(tt.text, opts)
+			if len(lines) < 2 {
+				t.Fatalf("expected the long word to be split, got %d lines", len(lines))
+			}
+
+			// Rune offsets of grapheme boundaries in the source
+			boundaries := map[int]bool{0: true}
+			pos := 0
+			for _, g := range txt.Graphemes(tt.text) {
+				pos += len([]rune(g))
+				boundaries[pos] = true
+			}
+
+			runes := []rune(tt.text)
+			for i, line := range lines {
+				if line.Width > tt.maxWidth {
+					t.Errorf("line %d %q width %.1f exceeds %.1f", i, line.Content, line.Width, tt.maxWidth)
+				}
+				if got := string(runes[line.Start:line.End]); got != line.Content {
+					t.Errorf("line %d Start/End map to %q, want %q", i, got, line.Content)
+				}
+				if !boundaries[line.Start] || !boundaries[line.End] {
+					t.Errorf("line %d [%d:%d] splits a grapheme cluster", i, line.Start, line.End)
+				}
+			}
+		})
+	}
+}
+
+func TestWrapKnuthPlass_DefaultBreaks(t *testing.T) {
+	txt := NewTerminal()
+
+	// These used to come back as greedy Wrap output: the start node, with
+	// zero demerits, always won as the final breakpoint, and the space
+	// before each line counted toward its width.
+	tests := []struct {
+		name     string
+		text     string
+		maxWidth float64
+		want     []string
+	}{
+		{"Leading space not counted", "aaa bb cc ddddd", 5, []string{"aaa", "bb cc", "ddddd"}},
+		{"Final break after last word", "The quick brown fox jumps over the lazy dog and runs away", 25,
+			[]string{"The quick brown fox jumps", "over the lazy dog and", "runs away"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines := txt.WrapKnuthPlass(tt.text, DefaultKnuthPlassOptions(tt.maxWidth))
+
+			var got []string
+			runes := []rune(tt.text)
+			for i, line := range lines {
+				got = append(got, line.Content)
+				if line.Width > tt.maxWidth {
+					t.Errorf("line %d %q width %.1f exceeds %.1f", i, line.Content, line.Width, tt.maxWidth)
+				}
+				if src := string(runes[line.Start:line.End]); src != line.Content {
+					t.Errorf("line %d Start/End map to %q, want %q", i, src, line.Content)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWrapKnuthPlass_NoOverfullLines(t *testing.T) {
+	txt := NewTerminal()
+	text := "The quick brown fox jumps over the lazy dog and runs away"
+
+	for width := 5.0; width <= 30; width++ {
+		for _, line := range txt.WrapKnuthPlass(text, DefaultKnuthPlassOptions(width)) {
+			if line.Width > width {
+				t.Errorf("width %.0f: line %q is %.1f wide", width, line.Content, line.Width)
+			}
+		}
+	}
+}
+
 func TestWrapKnuthPlass_QualityComparison(t *testing.T) {
 	txt := NewTerminal()
 
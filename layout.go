@@ -12,6 +12,12 @@ type TextStyle struct {
 	FontFamily    string
 	Bold          bool
 	Italic        bool
+
+	// WideLineHeight is the line height for lines with wide content (CJK
+	// ideographs, fullwidth forms, emoji), whose glyphs are often taller
+	// than Latin text. It only raises the height: a value below LineHeight,
+	// or 0, leaves such lines at LineHeight.
+	WideLineHeight float64
 }
 
 // Metrics implements layout.TextMetricsProvider for integration with the layout engine.
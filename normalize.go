@@ -0,0 +1,112 @@
+package text
+
+import (
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Unicode Normalization
+//
+// The same user-perceived text can be encoded in more than one way: "é" is
+// either U+00E9 or "e" followed by U+0301 COMBINING ACUTE ACCENT. Width and
+// grapheme clustering already treat both spellings alike (a combining mark
+// extends the cluster and has no width), but rune indices, byte lengths and
+// string comparisons differ. Normalizing first makes them consistent.
+//
+// References:
+//   - UAX #15: Unicode Normalization Forms (https://www.unicode.org/reports/tr15/)
+
+// ═══════════════════════════════════════════════════════════════
+//  Normalization Forms
+// ═══════════════════════════════════════════════════════════════
+
+// NormalizationForm selects a Unicode normalization form.
+type NormalizationForm int
+
+const (
+	// NFC is canonical composition: "é" becomes "é".
+	NFC NormalizationForm = iota
+
+	// NFD is canonical decomposition: "é" becomes "é".
+	NFD
+
+	// NFKC is compatibility composition. Besides composing, it replaces
+	// compatibility characters: "ﬁ" becomes "fi" and fullwidth "Ａ" becomes "A".
+	NFKC
+
+	// NFKD is compatibility decomposition.
+	NFKD
+)
+
+// Normalize converts text to the given normalization form.
+//
+// NFC and NFD never change how text clusters into graphemes, only how many
+// runes each cluster holds, so Width and GraphemeCount are unchanged.
+// NFKC and NFKD can change both: "ﬁ" (one cluster) becomes "fi" (two), and
+// fullwidth letters become narrow ones.
+//
+// Example:
+//
+//	nfc := text.Normalize("café", text.NFC) // "café", 4 runes instead of 5
+func Normalize(text string, form NormalizationForm) string {
+	switch form {
+	case NFD:
+		return norm.NFD.String(text)
+	case NFKC:
+		return norm.NFKC.String(text)
+	case NFKD:
+		return norm.NFKD.String(text)
+	default:
+		return norm.NFC.String(text)
+	}
+}
+
+// normalizeInput applies Config.NormalizeInput. Already normalized text,
+// the common case, is returned as is.
+func (t *Text) normalizeInput(s string) string {
+	if !t.config.NormalizeInput || norm.NFC.IsNormalString(s) {
+		return s
+	}
+	return norm.NFC.String(s)
+}
+
+// normalizedOffset maps byte offset off in text, computed by a caller on
+// the text before normalizeInput, to the matching byte offset in
+// normalized (the result of normalizeInput on text). An offset inside a
+// rune that composes with its neighbour moves to the end of that rune.
+func (t *Text) normalizedOffset(text, normalized string, off int) int {
+	if text == normalized {
+		return off
+	}
+	off = max(0, min(off, len(text)))
+	n := min(len(t.normalizeInput(text[:off])), len(normalized))
+	for n < len(normalized) && !utf8.RuneStart(normalized[n]) {
+		n++
+	}
+	return n
+}
+
+// sourceOffsetCursor returns a function mapping byte offsets in normalized
+// (the result of normalizeInput on text) back to byte offsets in text.
+// text is walked one normalization segment at a time, and an offset inside
+// a segment that normalization changed moves to the end of that segment.
+// Calls must use non-decreasing offsets, which lets the cursor walk text
+// only once.
+func sourceOffsetCursor(text, normalized string) func(off int) int {
+	if text == normalized {
+		return func(off int) int { return off }
+	}
+	src, dst := 0, 0
+	return func(off int) int {
+		for dst < off && src < len(text) {
+			n := norm.NFC.NextBoundaryInString(text[src:], true)
+			if n <= 0 {
+				n = len(text) - src
+			}
+			dst += len(norm.NFC.String(text[src : src+n]))
+			src += n
+		}
+		return src
+	}
+}
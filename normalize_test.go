@@ -0,0 +1,65 @@
+package text
+
+import "testing"
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		form  NormalizationForm
+		want  string
+	}{
+		{"NFC composes", "cafe\u0301", NFC, "caf\u00e9"},
+		{"NFD decomposes", "caf\u00e9", NFD, "cafe\u0301"},
+		{"NFKC folds ligature", "ﬁne", NFKC, "fine"},
+		{"NFKD folds and decomposes", "Ａ\u00e9", NFKD, "Ae\u0301"},
+		{"Already normalized", "hello", NFC, "hello"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Normalize(tt.input, tt.form); got != tt.want {
+				t.Errorf("Normalize(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalize_SameWidthAndGraphemes(t *testing.T) {
+	txt := NewTerminal()
+	nfc := Normalize("caf\u00e9", NFC)
+	nfd := Normalize("caf\u00e9", NFD)
+
+	if nfc == nfd {
+		t.Fatal("NFC and NFD forms should differ")
+	}
+	if txt.Width(nfc) != txt.Width(nfd) {
+		t.Errorf("Width: NFC %.1f, NFD %.1f", txt.Width(nfc), txt.Width(nfd))
+	}
+	if txt.GraphemeCount(nfc) != txt.GraphemeCount(nfd) {
+		t.Errorf("GraphemeCount: NFC %d, NFD %d", txt.GraphemeCount(nfc), txt.GraphemeCount(nfd))
+	}
+}
+
+func TestConfig_NormalizeInput(t *testing.T) {
+	txt := New(Config{MeasureFunc: TerminalMeasure, NormalizeInput: true})
+	nfd := "cafe\u0301 au lait"
+
+	lines := txt.Wrap(nfd, WrapOptions{MaxWidth: 8})
+	if len(lines) != 2 {
+		t.Fatalf("Wrap returned %d lines, want 2", len(lines))
+	}
+	if lines[0].Content != "caf\u00e9 au " || lines[0].End != 8 {
+		t.Errorf("line 0 = %+v, want NFC content ending at rune 8", lines[0])
+	}
+	if lines[1].Start != 8 || lines[1].End != 12 {
+		t.Errorf("line 1 = %+v, want Start 8, End 12", lines[1])
+	}
+
+	if got := txt.Graphemes(nfd)[3]; got != "\u00e9" {
+		t.Errorf("Graphemes()[3] = %q, want %q", got, "\u00e9")
+	}
+	if got := txt.GraphemeAt(nfd, 3); got != "\u00e9" {
+		t.Errorf("GraphemeAt(3) = %q, want %q", got, "\u00e9")
+	}
+}
@@ -0,0 +1,113 @@
+package text
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Invisible Characters
+//
+// Control characters (C0, DEL and C1), zero width spaces and byte order
+// marks draw nothing, but a MeasureFunc such as TerminalMeasure counts
+// each one as a cell, and writing them to a terminal is unsafe: ESC starts
+// escape sequences that move the cursor or retitle the window, and BEL
+// rings the bell. SanitizeVisible removes them before display, and
+// Config.ZeroWidthControls measures them as zero width in place.
+
+// ═══════════════════════════════════════════════════════════════
+//  Sanitizing
+// ═══════════════════════════════════════════════════════════════
+
+// SanitizeOptions configures SanitizeVisibleWith.
+type SanitizeOptions struct {
+	// StripNewlines replaces each line break ("\n", "\r\n" or "\r") with a
+	// space. By default line breaks are kept, normalized to "\n".
+	StripNewlines bool
+
+	// StripTabs replaces each tab with a space. By default tabs are kept.
+	StripTabs bool
+}
+
+// SanitizeVisible removes characters that draw nothing and may be unsafe
+// to print: control characters, zero width spaces (U+200B), word joiners
+// (U+2060) and byte order marks (U+FEFF). Escape sequences are removed
+// whole, so "\x1b[31m" leaves no "[31m" behind. Invalid UTF-8 becomes
+// U+FFFD. Newlines (normalized to "\n") and tabs are kept.
+//
+// Joiners that shape visible text, such as ZWJ in emoji sequences, are
+// kept.
+//
+// Example:
+//
+//	clean := text.SanitizeVisible("a\x00b\u200bc\x1b[2Jd") // "abcd"
+func SanitizeVisible(text string) string {
+	return SanitizeVisibleWith(text, SanitizeOptions{})
+}
+
+// SanitizeVisibleWith is SanitizeVisible with control over how newlines
+// and tabs are treated.
+//
+// Example:
+//
+//	oneLine := text.SanitizeVisibleWith("a\r\nb\tc", text.SanitizeOptions{
+//	    StripNewlines: true,
+//	    StripTabs:     true,
+//	}) // "a b c"
+func SanitizeVisibleWith(text string, opts SanitizeOptions) string {
+	var b strings.Builder
+	b.Grow(len(text))
+
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		switch {
+		case r == utf8.RuneError && size == 1:
+			b.WriteRune(utf8.RuneError)
+
+		case r == escapeByte:
+			i = escapeEnd(text, i)
+			continue
+
+		case r == '\r' || r == '\n':
+			if r == '\r' && i+1 < len(text) && text[i+1] == '\n' {
+				size++
+			}
+			if opts.StripNewlines {
+				b.WriteByte(' ')
+			} else {
+				b.WriteByte('\n')
+			}
+
+		case r == '\t':
+			if opts.StripTabs {
+				b.WriteByte(' ')
+			} else {
+				b.WriteByte('\t')
+			}
+
+		case isInvisibleControl(r):
+			// Dropped
+
+		default:
+			b.WriteString(text[i : i+size])
+		}
+		i += size
+	}
+
+	return b.String()
+}
+
+// isInvisibleControl reports whether r draws nothing and is removed by
+// SanitizeVisible: a control character other than tab and line breaks,
+// ZERO WIDTH SPACE, WORD JOINER or ZERO WIDTH NO-BREAK SPACE (BOM).
+func isInvisibleControl(r rune) bool {
+	switch r {
+	case '\t', '\n', '\r':
+		return false
+	case 0x200B, // ZERO WIDTH SPACE
+		0x2060, // WORD JOINER
+		0xFEFF: // ZERO WIDTH NO-BREAK SPACE
+		return true
+	}
+	return unicode.IsControl(r)
+}
@@ -0,0 +1,68 @@
+package text
+
+import "testing"
+
+func TestSanitizeVisible(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"NUL", "a\x00b", "ab"},
+		{"Zero width space", "a\u200Bb", "ab"},
+		{"Byte order mark", "\uFEFFhello", "hello"},
+		{"Word joiner", "a\u2060b", "ab"},
+		{"Bell and DEL", "ding\a\x7f", "ding"},
+		{"C1 control", "a\u0085b", "ab"},
+		{"CSI sequence removed whole", "\x1b[1;31mred\x1b[0m", "red"},
+		{"OSC sequence removed whole", "\x1b]0;title\aok", "ok"},
+		{"Newlines normalized", "a\r\nb\rc\nd", "a\nb\nc\nd"},
+		{"Tabs kept", "a\tb", "a\tb"},
+		{"Invalid UTF-8", "a\xffb", "a\uFFFDb"},
+		{"Emoji ZWJ kept", "👩\u200D💻", "👩\u200D💻"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SanitizeVisible(tt.input); got != tt.want {
+				t.Errorf("SanitizeVisible(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSanitizeVisibleWith(t *testing.T) {
+	opts := SanitizeOptions{StripNewlines: true, StripTabs: true}
+	if got := SanitizeVisibleWith("a\r\nb\tc\nd", opts); got != "a b c d" {
+		t.Errorf("SanitizeVisibleWith = %q, want %q", got, "a b c d")
+	}
+}
+
+func TestConfig_ZeroWidthControls(t *testing.T) {
+	plain := NewTerminal()
+	zero := New(Config{MeasureFunc: TerminalMeasure, ZeroWidthControls: true})
+
+	tests := []struct {
+		name  string
+		input string
+		want  float64
+	}{
+		{"NUL", "a\x00b", 2},
+		{"Zero width space", "a\u200Bb", 2},
+		{"Byte order mark", "\uFEFFhi", 2},
+		{"Bell", "a\ab", 2},
+		{"Tab still measured", "a\tb", 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := zero.Width(tt.input); got != tt.want {
+				t.Errorf("Width(%q) = %.1f, want %.1f", tt.input, got, tt.want)
+			}
+			// Measuring in place agrees with measuring the sanitized text
+			if got, want := zero.Width(tt.input), plain.Width(SanitizeVisible(tt.input)); got != want {
+				t.Errorf("Width(%q) = %.1f, want width of sanitized text %.1f", tt.input, got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package text
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Wrap Serialization
+//
+// Wrapped layouts can be computed once (for example on a server) and shipped
+// to clients or cached on disk. MarshalWrap/UnmarshalWrap round-trip a slice
+// of lines including content, width, rune offsets, and break types.
+//
+// The encoding is compact JSON with a format version so that older caches can
+// be detected and discarded when the format changes.
+
+// wrapFormatVersion is the current MarshalWrap encoding version.
+const wrapFormatVersion = 1
+
+type wrapEnvelope struct {
+	Version int        `json:"v"`
+	Lines   []wrapLine `json:"l"`
+}
+
+type wrapLine struct {
+	Content   string    `json:"c"`
+	Width     float64   `json:"w"`
+	Start     int       `json:"s"`
+	End       int       `json:"e"`
+	BreakType BreakType `json:"b,omitempty"`
+}
+
+// MarshalWrap encodes wrapped lines for storage or transmission.
+//
+// Example:
+//
+//	txt := text.NewTerminal()
+//	lines := txt.Wrap("Hello world", text.WrapOptions{MaxWidth: 6})
+//	data := txt.MarshalWrap(lines)
+//	restored, err := txt.UnmarshalWrap(data)
+func (t *Text) MarshalWrap(lines []Line) []byte {
+	env := wrapEnvelope{
+		Version: wrapFormatVersion,
+		Lines:   make([]wrapLine, len(lines)),
+	}
+	for i, line := range lines {
+		env.Lines[i] = wrapLine(line)
+	}
+
+	// Marshaling a struct of strings and numbers cannot fail.
+	data, _ := json.Marshal(env)
+	return data
+}
+
+// UnmarshalWrap decodes lines produced by MarshalWrap.
+//
+// Returns an error if the data is malformed or was written by an
+// unsupported format version.
+func (t *Text) UnmarshalWrap(data []byte) ([]Line, error) {
+	var env wrapEnvelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return nil, fmt.Errorf("text: decode wrap: %w", err)
+	}
+	if env.Version != wrapFormatVersion {
+		return nil, fmt.Errorf("text: unsupported wrap format version %d", env.Version)
+	}
+
+	lines := make([]Line, len(env.Lines))
+	for i, line := range env.Lines {
+		lines[i] = Line(line)
+	}
+	return lines, nil
+}
@@ -0,0 +1,76 @@
+package text
+
+import (
+	"testing"
+)
+
+func TestMarshalWrap_RoundTrip(t *testing.T) {
+	txt := NewTerminal()
+
+	lines := txt.Wrap("Hello 世界!\nThis is a test of the wrap cache.", WrapOptions{
+		MaxWidth:         12,
+		PreserveNewlines: true,
+	})
+	if len(lines) < 3 {
+		t.Fatalf("expected at least 3 lines to exercise break types, got %d", len(lines))
+	}
+
+	data := txt.MarshalWrap(lines)
+	got, err := txt.UnmarshalWrap(data)
+	if err != nil {
+		t.Fatalf("UnmarshalWrap error: %v", err)
+	}
+
+	if len(got) != len(lines) {
+		t.Fatalf("round trip returned %d lines, want %d", len(got), len(lines))
+	}
+	for i := range lines {
+		if got[i] != lines[i] {
+			t.Errorf("line %d = %+v, want %+v", i, got[i], lines[i])
+		}
+	}
+
+	// The hard break at "\n", soft breaks, and the final line must all survive.
+	if lines[0].BreakType != BreakHard {
+		t.Errorf("line 0 BreakType = %d, want BreakHard", lines[0].BreakType)
+	}
+	if lines[1].BreakType != BreakSoft {
+		t.Errorf("line 1 BreakType = %d, want BreakSoft", lines[1].BreakType)
+	}
+	if last := lines[len(lines)-1]; last.BreakType != BreakNone {
+		t.Errorf("last line BreakType = %d, want BreakNone", last.BreakType)
+	}
+}
+
+func TestUnmarshalWrap_Errors(t *testing.T) {
+	txt := NewTerminal()
+
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"Malformed", `{"v":1,"l":[`},
+		{"Unknown version", `{"v":99,"l":[]}`},
+		{"Missing version", `{"l":[]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := txt.UnmarshalWrap([]byte(tt.data)); err == nil {
+				t.Errorf("UnmarshalWrap(%s) expected error", tt.data)
+			}
+		})
+	}
+}
+
+func TestMarshalWrap_Empty(t *testing.T) {
+	txt := NewTerminal()
+
+	got, err := txt.UnmarshalWrap(txt.MarshalWrap(nil))
+	if err != nil {
+		t.Fatalf("UnmarshalWrap error: %v", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("expected no lines, got %d", len(got))
+	}
+}
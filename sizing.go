@@ -3,7 +3,13 @@ package text
 import (
 	"math"
 	"strings"
+	"unicode"
+	"unsafe"
 
+	"github.com/SCKelemen/unicode/v6/uax11"
+	"github.com/SCKelemen/unicode/v6/uax14"
+	"github.com/SCKelemen/unicode/v6/uax29"
+	"github.com/SCKelemen/unicode/v6/uts51"
 	"github.com/SCKelemen/units"
 )
 
@@ -40,49 +46,39 @@ type IntrinsicSize struct {
 // IntrinsicSizing calculates intrinsic sizes for text.
 //
 // Returns:
-//   - MinContent: Width of the widest word/grapheme (won't overflow)
+//   - MinContent: Width of the widest unbreakable segment (won't overflow)
 //   - MaxContent: Width if text never wraps (single line)
 //   - PreferredWidth: Comfortable reading width (60-80 ch)
 //
+// MinContent uses UAX #14 line break opportunities, not spaces, so every
+// ideograph of CJK text is its own segment. Trailing white space hangs and
+// does not count.
+//
 // Example:
 //
 //	txt := text.NewTerminal()
 //	sizes := txt.IntrinsicSizing("Hello world! This is a test.")
-//	// sizes.MinContent = 6.0 (widest word "Hello!" or "world!")
+//	// sizes.MinContent = 6.0 (widest segment "world!")
 //	// sizes.MaxContent = 28.0 (full line width)
+//	sizes = txt.IntrinsicSizing("你好世界")
+//	// sizes.MinContent = 2.0 (one ideograph)
 func (t *Text) IntrinsicSizing(text string) IntrinsicSize {
+	breakPoints := uax14.FindLineBreakOpportunities(text, t.config.HyphenationMode)
+	return t.intrinsicSizes(text, breakPoints)
+}
+
+// intrinsicSizes calculates intrinsic sizes for text that may only wrap at
+// breakPoints (byte offsets).
+func (t *Text) intrinsicSizes(text string, breakPoints []int) IntrinsicSize {
 	// MaxContent: full line width
 	maxContent := t.Width(text)
 
 	// MinContent: width of widest unbreakable segment
-	// For proper intrinsic sizing, we should look at the longest segment
-	// between forced breaks (spaces for most text, or line break opportunities for CJK).
-	// Using space-separated segments gives us the "longest word" in Latin text
-	// and works reasonably for CJK with spaces.
-	segments := strings.Fields(text)
 	minContent := 0.0
-
-	if len(segments) > 0 {
-		// Use space-separated segments for min-content
-		for _, segment := range segments {
-			w := t.Width(segment)
-			if w > minContent {
-				minContent = w
-			}
-		}
-	} else {
-		// No spaces - the whole text is one segment
-		minContent = maxContent
-	}
-
-	// Fallback: if still zero, use widest grapheme
-	if minContent == 0 {
-		graphemes := t.Graphemes(text)
-		for _, g := range graphemes {
-			w := t.Width(g)
-			if w > minContent {
-				minContent = w
-			}
+	for i := 1; i < len(breakPoints); i++ {
+		segment := strings.TrimRightFunc(text[breakPoints[i-1]:breakPoints[i]], unicode.IsSpace)
+		if w := t.Width(segment); w > minContent {
+			minContent = w
 		}
 	}
 
@@ -101,6 +97,93 @@ func (t *Text) IntrinsicSizing(text string) IntrinsicSize {
 	}
 }
 
+// IntrinsicSizingWithStyle calculates intrinsic sizes honoring CSS text
+// properties that affect where text may break.
+//
+// The line-break and word-break properties adjust the break opportunities
+// as in WrapCSS: word-break: break-all shrinks MinContent to the widest
+// grapheme cluster, and keep-all makes a CJK run as wide as the whole run.
+//
+// With overflow-wrap: anywhere, emergency breaks between any grapheme
+// clusters count as soft wrap opportunities, so MinContent shrinks to the
+// widest grapheme cluster. With overflow-wrap: break-word (and normal),
+// emergency breaks are not considered for min-content, which stays the
+// width of the widest word, as CSS Text Level 3 specifies.
+//
+// Example:
+//
+//	style := text.DefaultCSSTextStyle()
+//	style.OverflowWrap = text.OverflowWrapAnywhere
+//	sizes := txt.IntrinsicSizingWithStyle("Supercalifragilistic", style)
+//	// sizes.MinContent = 1.0 (one grapheme)
+func (t *Text) IntrinsicSizingWithStyle(text string, style CSSTextStyle) IntrinsicSize {
+	breakPoints := uax14.FindLineBreakOpportunities(text, t.config.HyphenationMode)
+	breakPoints = t.applyLineBreak(text, breakPoints, style.LineBreak)
+	if style.LineBreak != LineBreakAnywhere {
+		breakPoints = t.applyWordBreak(text, breakPoints, style.WordBreak)
+	}
+	sizes := t.intrinsicSizes(text, breakPoints)
+
+	if style.OverflowWrap == OverflowWrapAnywhere {
+		minContent := 0.0
+		for _, g := range t.Graphemes(text) {
+			if w := t.Width(g); w > minContent {
+				minContent = w
+			}
+		}
+		sizes.MinContent = minContent
+	}
+
+	return sizes
+}
+
+// MinWidthForLines returns the smallest width at which Wrap fits text in at
+// most maxLines lines, for panels that answer "how wide must this be to
+// take 3 lines?". The width counts trailing spaces the way Wrap does, so
+// wrapping at the returned width gives at most maxLines lines.
+//
+// The search runs between the IntrinsicSizing bounds. If even the
+// min-content width needs no more than maxLines lines, or maxLines < 1,
+// the min-content width is returned.
+//
+// Example:
+//
+//	txt := text.NewTerminal()
+//	w := txt.MinWidthForLines("The quick brown fox jumps over the lazy dog", 2) // 23.0
+//	lines := txt.Wrap("The quick brown fox jumps over the lazy dog", text.WrapOptions{MaxWidth: w})
+//	// "The quick brown fox ", "jumps over the lazy dog"
+func (t *Text) MinWidthForLines(text string, maxLines int) float64 {
+	sizes := t.IntrinsicSizing(text)
+	if maxLines < 1 || text == "" {
+		return sizes.MinContent
+	}
+
+	a := t.Analyze(text)
+	lo, hi := sizes.MinContent, sizes.MaxContent
+	if len(a.WrapAt(lo)) <= maxLines {
+		return lo
+	}
+
+	// Line count only grows as the width shrinks, so bisect for the
+	// narrowest width that keeps it within maxLines.
+	for range 48 {
+		mid := (lo + hi) / 2
+		if len(a.WrapAt(mid)) <= maxLines {
+			hi = mid
+		} else {
+			lo = mid
+		}
+	}
+
+	// The widest line of the layout at hi wraps the same way, and is the
+	// exact width rather than a point inside the last bisection step
+	widest := 0.0
+	for _, line := range a.WrapAt(hi) {
+		widest = max(widest, line.Width)
+	}
+	return widest
+}
+
 // ═══════════════════════════════════════════════════════════════
 //  Line Box Metrics
 // ═══════════════════════════════════════════════════════════════
@@ -126,6 +209,11 @@ type LineBoxMetrics struct {
 	// Position in source text
 	Start int // Rune index in original text
 	End   int // Rune index in original text
+
+	// HasWideContent reports whether the line contains wide graphemes
+	// (East Asian Wide or Fullwidth characters, or emoji presentation),
+	// which may need a taller box or extra leading.
+	HasWideContent bool
 }
 
 // MeasureLineBox calculates complete metrics for a line of text.
@@ -135,14 +223,31 @@ type LineBoxMetrics struct {
 //   - style: Font and line height settings
 //
 // Returns metrics needed for proper line box positioning and alignment.
+// A line with wide content uses style.WideLineHeight when it is taller than
+// style.LineHeight, so its ascent and descent grow with it.
+//
+// With font metrics (see WithFontMetrics), Ascent and Descent come from the
+// font, scaled by style.FontSize per em (1 if unset). An explicit
+// style.LineHeight still sets the line height, and the difference from
+// Ascent+Descent becomes Leading, negative when the line is tighter than
+// the font, as in CSS. Without a LineHeight, the font's LineGap is the
+// Leading (CSS line-height: normal).
 func (t *Text) MeasureLineBox(text string, style TextStyle) LineBoxMetrics {
+	if t.fontMetrics != nil {
+		return t.measureLineBoxWithFont(text, style)
+	}
+
 	width := t.Width(text)
+	wide := hasWideContent(text)
 
 	// Get line height from style or default to 1.0
 	lineHeight := style.LineHeight
 	if lineHeight == 0 {
 		lineHeight = 1.0
 	}
+	if wide && style.WideLineHeight > lineHeight {
+		lineHeight = style.WideLineHeight
+	}
 
 	// For terminal/simple rendering, use standard proportions
 	// In real font rendering, these would come from font metrics
@@ -175,9 +280,69 @@ func (t *Text) MeasureLineBox(text string, style TextStyle) LineBoxMetrics {
 		Baseline:   baseline,
 		Start:      0,
 		End:        len([]rune(text)),
+
+		HasWideContent: wide,
+	}
+}
+
+// measureLineBoxWithFont implements MeasureLineBox using t.fontMetrics.
+func (t *Text) measureLineBoxWithFont(text string, style TextStyle) LineBoxMetrics {
+	fm := t.fontMetrics
+	wide := hasWideContent(text)
+
+	// Font units per em, scaled to the font size
+	unitsPerEm := fm.UnitsPerEm()
+	if unitsPerEm <= 0 {
+		unitsPerEm = 1
+	}
+	fontSize := style.FontSize
+	if fontSize == 0 {
+		fontSize = 1.0
+	}
+	scale := fontSize / unitsPerEm
+
+	ascent := fm.Ascent() * scale
+	descent := math.Abs(fm.Descent()) * scale // Some fonts report descent as negative
+
+	lineHeight := style.LineHeight
+	if lineHeight == 0 {
+		lineHeight = ascent + descent + fm.LineGap()*scale
+	}
+	if wide && style.WideLineHeight > lineHeight {
+		lineHeight = style.WideLineHeight
+	}
+	leading := lineHeight - (ascent + descent)
+
+	return LineBoxMetrics{
+		Width:      t.Width(text),
+		Content:    text,
+		Ascent:     ascent,
+		Descent:    descent,
+		Leading:    leading,
+		LineHeight: lineHeight,
+		Baseline:   ascent + leading/2,
+		Start:      0,
+		End:        len([]rune(text)),
+
+		HasWideContent: wide,
 	}
 }
 
+// hasWideContent reports whether text contains an East Asian Wide or
+// Fullwidth character, or a character displayed as emoji. It does not
+// depend on the configured MeasureFunc.
+func hasWideContent(text string) bool {
+	for _, r := range text {
+		if uts51.HasEmojiPresentation(r) || uts51.IsRegionalIndicator(r) || r == '\uFE0F' {
+			return true
+		}
+		if uax11.CharWidth(r, uax11.ContextNarrow) == 2 {
+			return true
+		}
+	}
+	return false
+}
+
 // ═══════════════════════════════════════════════════════════════
 //  Multi-Line Text Bounds
 // ═══════════════════════════════════════════════════════════════
@@ -218,6 +383,34 @@ func (t *Text) MeasureMultiLine(text string, wrapOpts WrapOptions, style TextSty
 	// Wrap text into lines
 	lines := t.Wrap(text, wrapOpts)
 
+	return t.measureLines(lines, style, 0)
+}
+
+// MeasureParagraphs calculates bounds for text made of several paragraphs.
+//
+// Each newline starts a new paragraph (wrapping is done with
+// PreserveNewlines forced on). paragraphSpacing is added to the height
+// between consecutive paragraphs, but not before the first or after the last,
+// so Height and LastBaseline include the gaps.
+//
+// Example:
+//
+//	txt := text.NewTerminal()
+//	bounds := txt.MeasureParagraphs("First paragraph.\nSecond one.",
+//	    text.WrapOptions{MaxWidth: 40},
+//	    text.TextStyle{LineHeight: 1},
+//	    0.5)
+//	// bounds.Height = 2 lines + 0.5 spacing = 2.5
+func (t *Text) MeasureParagraphs(text string, wrapOpts WrapOptions, style TextStyle, paragraphSpacing float64) TextBounds {
+	wrapOpts.PreserveNewlines = true
+	lines := t.Wrap(text, wrapOpts)
+
+	return t.measureLines(lines, style, paragraphSpacing)
+}
+
+// measureLines computes TextBounds for already-wrapped lines, adding
+// paragraphSpacing after every line that ends a paragraph (BreakHard).
+func (t *Text) measureLines(lines []Line, style TextStyle, paragraphSpacing float64) TextBounds {
 	if len(lines) == 0 {
 		return TextBounds{}
 	}
@@ -238,6 +431,9 @@ func (t *Text) MeasureMultiLine(text string, wrapOpts WrapOptions, style TextSty
 
 		lineMetrics[i] = metrics
 		currentY += metrics.LineHeight
+		if line.BreakType == BreakHard && i < len(lines)-1 {
+			currentY += paragraphSpacing
+		}
 	}
 
 	// Calculate baseline positions
@@ -254,6 +450,122 @@ func (t *Text) MeasureMultiLine(text string, wrapOpts WrapOptions, style TextSty
 	}
 }
 
+// ═══════════════════════════════════════════════════════════════
+//  Wrap Cost Estimation
+// ═══════════════════════════════════════════════════════════════
+
+// lineSize is the size of a Line value, excluding its content bytes.
+const lineSize = int(unsafe.Sizeof(Line{}))
+
+// WrapCost estimates the output of wrapping, for preallocating buffers.
+type WrapCost struct {
+	EstimatedLines int // Number of lines Wrap returns
+	EstimatedBytes int // Line content bytes plus the Line values themselves
+}
+
+// CountWrappedLines returns the number of lines Wrap would produce for text,
+// without building the line contents.
+//
+// Example:
+//
+//	txt := text.NewTerminal()
+//	n := txt.CountWrappedLines("Hello world", text.WrapOptions{MaxWidth: 6}) // 2
+func (t *Text) CountWrappedLines(text string, opts WrapOptions) int {
+	if opts.MaxWidth <= 0 {
+		return 1
+	}
+	if !opts.PreserveNewlines {
+		return t.countSegmentLines(text, opts)
+	}
+
+	parts, _ := splitLineBreaks(text)
+	count := 0
+	for _, part := range parts {
+		n := t.countSegmentLines(part, opts)
+		if n == 0 && len(parts) > 1 {
+			n = 1
+		}
+		count += n
+	}
+	return count
+}
+
+// countSegmentLines counts the lines wrapSegment would produce.
+func (t *Text) countSegmentLines(text string, opts WrapOptions) int {
+	if text == "" {
+		return 0
+	}
+	if (opts.TabSize != nil && strings.Contains(text, "\t")) ||
+		(opts.SoftHyphens && strings.Contains(text, softHyphen)) {
+		return len(t.wrapSegment(text, opts, 0))
+	}
+
+	breakPoints := uax14.FindLineBreakOpportunities(text, t.config.HyphenationMode)
+	if len(breakPoints) < 2 {
+		breakPoints = []int{0, len(text)}
+	}
+
+	count := 0
+	width := 0.0
+	open := false
+	for i := 1; i < len(breakPoints); i++ {
+		segment := text[breakPoints[i-1]:breakPoints[i]]
+		if segment == "" {
+			continue
+		}
+
+		w := t.Width(segment)
+		if open && width+w > opts.MaxWidth {
+			open = false
+		}
+
+		if opts.BreakWords && t.Width(strings.TrimRightFunc(segment, unicode.IsSpace)) > opts.MaxWidth {
+			for _, g := range uax29.Graphemes(segment) {
+				gw := t.Width(g)
+				if open && width+gw > opts.MaxWidth && strings.TrimRightFunc(g, unicode.IsSpace) != "" {
+					open = false
+				}
+				if !open {
+					count++
+					width = 0
+					open = true
+				}
+				width += gw
+			}
+			continue
+		}
+
+		if !open {
+			count++
+			width = 0
+			open = true
+		}
+		width += w
+	}
+	return count
+}
+
+// EstimateWrapCost estimates how many lines and bytes Wrap will produce for
+// text, so callers can size buffers before rendering at scale.
+//
+// EstimatedLines is exact. EstimatedBytes counts every input byte as line
+// content plus the size of each Line value; it may slightly overestimate
+// when PreserveNewlines drops line break characters.
+//
+// Example:
+//
+//	txt := text.NewTerminal()
+//	cost := txt.EstimateWrapCost(doc, text.WrapOptions{MaxWidth: 80})
+//	var buf bytes.Buffer
+//	buf.Grow(cost.EstimatedBytes)
+func (t *Text) EstimateWrapCost(text string, opts WrapOptions) WrapCost {
+	lines := t.CountWrappedLines(text, opts)
+	return WrapCost{
+		EstimatedLines: lines,
+		EstimatedBytes: len(text) + lines*lineSize,
+	}
+}
+
 // ═══════════════════════════════════════════════════════════════
 //  CSS-Aware Sizing
 // ═══════════════════════════════════════════════════════════════
@@ -279,6 +591,10 @@ type CSSTextBounds struct {
 // - Text transformation
 // - Letter/word spacing
 //
+// The lines measured are those WrapCSS returns for the same options, so
+// LineCount agrees with it, including breaks forced by newlines that
+// white-space preserves.
+//
 // Example:
 //
 //	txt := text.NewTerminal()
@@ -294,30 +610,14 @@ type CSSTextBounds struct {
 //	})
 func (t *Text) MeasureCSS(text string, cssOpts CSSWrapOptions, textStyle TextStyle) CSSTextBounds {
 	// Process text according to CSS properties
-	processed, allowWrap := t.ProcessWhiteSpace(text, cssOpts.Style.WhiteSpace)
+	processed, _ := t.ProcessWhiteSpace(text, cssOpts.Style.WhiteSpace)
 	processed = t.Transform(processed, cssOpts.Style.TextTransform)
 
 	// Calculate intrinsic sizing
-	intrinsic := t.IntrinsicSizing(processed)
+	intrinsic := t.IntrinsicSizingWithStyle(processed, cssOpts.Style)
 
 	// Wrap and measure
-	var bounds TextBounds
-	if allowWrap {
-		bounds = t.MeasureMultiLine(processed, WrapOptions{
-			MaxWidth: cssOpts.MaxWidth.Raw(),
-		}, textStyle)
-	} else {
-		// No wrapping: single line
-		lineMetrics := t.MeasureLineBox(processed, textStyle)
-		bounds = TextBounds{
-			Width:         lineMetrics.Width,
-			Height:        lineMetrics.LineHeight,
-			FirstBaseline: lineMetrics.Baseline,
-			LastBaseline:  lineMetrics.Baseline,
-			LineCount:     1,
-			Lines:         []LineBoxMetrics{lineMetrics},
-		}
-	}
+	bounds := t.measureLines(t.WrapCSS(text, cssOpts), textStyle, 0)
 
 	return CSSTextBounds{
 		TextBounds:    bounds,
@@ -361,15 +661,26 @@ type FontMetrics interface {
 	UnitsPerEm() float64
 }
 
-// WithFontMetrics creates a Text instance with real font metrics.
+// WithFontMetrics returns a copy of t that sizes line boxes with real font
+// metrics.
 //
-// When provided, font metrics override the default ascent/descent calculations.
-// This enables proper support for font-relative units (em, ex, cap, ch, ic).
+// MeasureLineBox, and so MeasureMultiLine, MeasureParagraphs and MeasureCSS,
+// derive ascent, descent and leading from fm instead of the default 0.8/0.2
+// split. Widths still come from the MeasureFunc. t itself is unchanged, and
+// a nil fm restores the default sizing.
 //
-// Future enhancement: Store and use font metrics in line box measurement for
-// pixel-perfect canvas/GUI rendering with proper baseline alignment.
+// Example:
+//
+//	txt := text.NewTerminal().WithFontMetrics(face)
+//	box := txt.MeasureLineBox("Hello", text.TextStyle{FontSize: 16})
+//	// box.Ascent = face.Ascent() * 16 / face.UnitsPerEm()
 func (t *Text) WithFontMetrics(fm FontMetrics) *Text {
-	// Placeholder - font metrics not yet stored
-	// Current implementation uses basic cell-based sizing suitable for terminals
-	return t
+	clone := *t
+	clone.fontMetrics = fm
+	return &clone
+}
+
+// FontMetrics returns the font metrics set by WithFontMetrics, or nil.
+func (t *Text) FontMetrics() FontMetrics {
+	return t.fontMetrics
 }
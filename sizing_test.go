@@ -1,6 +1,8 @@
 package text
 
 import (
+	"math"
+	"strings"
 	"testing"
 
 	"github.com/SCKelemen/units"
@@ -14,34 +16,58 @@ func TestIntrinsicSizing(t *testing.T) {
 	txt := NewTerminal()
 
 	tests := []struct {
-		name              string
-		text              string
-		wantMinContentMin float64 // Minimum expected min-content
-		wantMaxContent    float64
+		name           string
+		text           string
+		wantMinContent float64
+		wantMaxContent float64
 	}{
 		{
-			name:              "Single word",
-			text:              "Hello",
-			wantMinContentMin: 5.0,
-			wantMaxContent:    5.0,
+			name:           "Single word",
+			text:           "Hello",
+			wantMinContent: 5.0,
+			wantMaxContent: 5.0,
 		},
 		{
-			name:              "Multiple words",
-			text:              "Hello world",
-			wantMinContentMin: 5.0, // At least as wide as "Hello" or "world"
-			wantMaxContent:    11.0,
+			name:           "Multiple words",
+			text:           "Hello world",
+			wantMinContent: 5.0, // "Hello" or "world"
+			wantMaxContent: 11.0,
 		},
 		{
-			name:              "Long word",
-			text:              "Supercalifragilisticexpialidocious",
-			wantMinContentMin: 34.0,
-			wantMaxContent:    34.0,
+			name:           "Punctuation stays with its word",
+			text:           "Hello world! This is a test.",
+			wantMinContent: 6.0, // "world!"
+			wantMaxContent: 28.0,
 		},
 		{
-			name:              "CJK text",
-			text:              "世界 你好",
-			wantMinContentMin: 4.0, // "世界" or "你好" = 4 cells
-			wantMaxContent:    9.0, // 4 + 1 space + 4
+			name:           "Long word",
+			text:           "Supercalifragilisticexpialidocious",
+			wantMinContent: 34.0,
+			wantMaxContent: 34.0,
+		},
+		{
+			name:           "CJK text",
+			text:           "世界 你好",
+			wantMinContent: 2.0, // Each ideograph is a break opportunity
+			wantMaxContent: 9.0, // 4 + 1 space + 4
+		},
+		{
+			name:           "CJK without spaces",
+			text:           "你好世界你好世界",
+			wantMinContent: 2.0,
+			wantMaxContent: 16.0,
+		},
+		{
+			name:           "CJK closing punctuation",
+			text:           "你好。世界",
+			wantMinContent: 4.0, // "好。" cannot break before the full stop
+			wantMaxContent: 10.0,
+		},
+		{
+			name:           "Trailing spaces hang",
+			text:           "ab   ",
+			wantMinContent: 2.0,
+			wantMaxContent: 5.0,
 		},
 	}
 
@@ -49,8 +75,8 @@ func TestIntrinsicSizing(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			sizes := txt.IntrinsicSizing(tt.text)
 
-			if sizes.MinContent < tt.wantMinContentMin {
-				t.Errorf("MinContent = %.1f, want >= %.1f", sizes.MinContent, tt.wantMinContentMin)
+			if sizes.MinContent != tt.wantMinContent {
+				t.Errorf("MinContent = %.1f, want %.1f", sizes.MinContent, tt.wantMinContent)
 			}
 
 			if sizes.MaxContent != tt.wantMaxContent {
@@ -68,6 +94,46 @@ func TestIntrinsicSizing(t *testing.T) {
 	}
 }
 
+func TestMinWidthForLines(t *testing.T) {
+	txt := NewTerminal()
+	sentence := "The quick brown fox jumps over the lazy dog"
+
+	tests := []struct {
+		name     string
+		text     string
+		maxLines int
+		want     float64
+	}{
+		{"Two lines", sentence, 2, 23},
+		{"Three lines", sentence, 3, 16},
+		{"One line", sentence, 1, 43},
+		{"Any number of lines", sentence, 100, 5},
+		{"No lines", sentence, 0, 5},
+		{"CJK", "你好世界你好", 2, 6},
+		{"Empty", "", 2, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := txt.MinWidthForLines(tt.text, tt.maxLines)
+			if got != tt.want {
+				t.Errorf("MinWidthForLines(%q, %d) = %.3f, want %.1f", tt.text, tt.maxLines, got, tt.want)
+			}
+			if tt.maxLines < 1 || tt.text == "" {
+				return
+			}
+			if n := len(txt.Wrap(tt.text, WrapOptions{MaxWidth: got})); n > tt.maxLines {
+				t.Errorf("Wrap at %.1f gives %d lines, want at most %d", got, n, tt.maxLines)
+			}
+			if got > txt.IntrinsicSizing(tt.text).MinContent {
+				if n := len(txt.Wrap(tt.text, WrapOptions{MaxWidth: got - 1})); n <= tt.maxLines {
+					t.Errorf("Wrap at %.1f also gives %d lines; %.1f is not minimal", got-1, n, got)
+				}
+			}
+		})
+	}
+}
+
 // ═══════════════════════════════════════════════════════════════
 //  Line Box Metrics Tests
 // ═══════════════════════════════════════════════════════════════
@@ -139,10 +205,72 @@ func TestMeasureLineBox(t *testing.T) {
 	}
 }
 
+func TestMeasureLineBox_WideContent(t *testing.T) {
+	txt := NewTerminal()
+	style := TextStyle{LineHeight: 1.0, WideLineHeight: 1.25}
+
+	tests := []struct {
+		name           string
+		text           string
+		wantWide       bool
+		wantLineHeight float64
+	}{
+		{"ASCII", "Hello", false, 1.0},
+		{"CJK", "Hello 世界", true, 1.25},
+		{"Fullwidth", "ＡＢ", true, 1.25},
+		{"Emoji", "ok 😀", true, 1.25},
+		{"Text symbol with VS16", "\u2764\uFE0F", true, 1.25},
+		{"Ambiguous width", "α±", false, 1.0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := txt.MeasureLineBox(tt.text, style)
+			if m.HasWideContent != tt.wantWide {
+				t.Errorf("HasWideContent = %v, want %v", m.HasWideContent, tt.wantWide)
+			}
+			if m.LineHeight != tt.wantLineHeight {
+				t.Errorf("LineHeight = %.2f, want %.2f", m.LineHeight, tt.wantLineHeight)
+			}
+		})
+	}
+
+	// A CJK line has a taller content box than an ASCII line.
+	ascii := txt.MeasureLineBox("Hello", style)
+	cjk := txt.MeasureLineBox("世界", style)
+	if cjk.Ascent+cjk.Descent <= ascii.Ascent+ascii.Descent {
+		t.Errorf("CJK content height %.2f not larger than ASCII %.2f",
+			cjk.Ascent+cjk.Descent, ascii.Ascent+ascii.Descent)
+	}
+
+	// WideLineHeight never lowers the line height.
+	if got := txt.MeasureLineBox("世界", TextStyle{LineHeight: 1.5, WideLineHeight: 1.2}).LineHeight; got != 1.5 {
+		t.Errorf("LineHeight = %.2f, want 1.50", got)
+	}
+}
+
 // ═══════════════════════════════════════════════════════════════
 //  Multi-Line Text Bounds Tests
 // ═══════════════════════════════════════════════════════════════
 
+func TestMeasureMultiLine_WideContent(t *testing.T) {
+	txt := NewTerminal()
+
+	bounds := txt.MeasureMultiLine("Hello\n世界", WrapOptions{MaxWidth: 10, PreserveNewlines: true},
+		TextStyle{LineHeight: 1.0, WideLineHeight: 1.5})
+
+	if bounds.LineCount != 2 {
+		t.Fatalf("LineCount = %d, want 2", bounds.LineCount)
+	}
+	if bounds.Lines[0].HasWideContent || !bounds.Lines[1].HasWideContent {
+		t.Errorf("HasWideContent = %v, %v, want false, true",
+			bounds.Lines[0].HasWideContent, bounds.Lines[1].HasWideContent)
+	}
+	if bounds.Height != 2.5 {
+		t.Errorf("Height = %.2f, want 2.50", bounds.Height)
+	}
+}
+
 func TestMeasureMultiLine(t *testing.T) {
 	txt := NewTerminal()
 
@@ -220,6 +348,40 @@ func TestMeasureMultiLine(t *testing.T) {
 	}
 }
 
+func TestMeasureParagraphs(t *testing.T) {
+	txt := NewTerminal()
+
+	text := "Hello world test\nSecond paragraph"
+	opts := WrapOptions{MaxWidth: 10}
+	style := TextStyle{LineHeight: 1.0}
+
+	// "Hello " / "world test" + "Second " / "paragraph" = 4 lines
+	without := txt.MeasureParagraphs(text, opts, style, 0)
+	if without.LineCount != 4 {
+		t.Fatalf("LineCount = %d, want 4", without.LineCount)
+	}
+	if without.Height != 4.0 {
+		t.Errorf("Height without spacing = %.2f, want 4.00", without.Height)
+	}
+
+	with := txt.MeasureParagraphs(text, opts, style, 0.75)
+	if with.Height != 4.75 {
+		t.Errorf("Height with spacing = %.2f, want 4.75 (one gap between two paragraphs)", with.Height)
+	}
+	if got, want := with.LastBaseline-without.LastBaseline, 0.75; got != want {
+		t.Errorf("LastBaseline shifted by %.2f, want %.2f", got, want)
+	}
+	if with.FirstBaseline != without.FirstBaseline {
+		t.Errorf("FirstBaseline changed: %.2f vs %.2f", with.FirstBaseline, without.FirstBaseline)
+	}
+
+	// A single paragraph gets no spacing.
+	single := txt.MeasureParagraphs("Hello world test", opts, style, 0.75)
+	if single.Height != 2.0 {
+		t.Errorf("single paragraph Height = %.2f, want 2.00", single.Height)
+	}
+}
+
 func TestMeasureMultiLine_Empty(t *testing.T) {
 	txt := NewTerminal()
 
@@ -234,6 +396,51 @@ func TestMeasureMultiLine_Empty(t *testing.T) {
 	}
 }
 
+// ═══════════════════════════════════════════════════════════════
+//  Wrap Cost Estimation Tests
+// ═══════════════════════════════════════════════════════════════
+
+func TestEstimateWrapCost(t *testing.T) {
+	txt := NewTerminal()
+	corpus := streamCorpus()
+
+	tests := []struct {
+		name string
+		text string
+		opts WrapOptions
+	}{
+		{"Greedy", corpus, WrapOptions{MaxWidth: 20}},
+		{"Break words", corpus, WrapOptions{MaxWidth: 13, BreakWords: true}},
+		{"Preserve newlines", corpus, WrapOptions{MaxWidth: 30, PreserveNewlines: true}},
+		{"Blank lines", "a\n\n\nb c d", WrapOptions{MaxWidth: 2, PreserveNewlines: true}},
+		{"CRLF and lone CR", "a\r\n\r\nb c\rd", WrapOptions{MaxWidth: 2, PreserveNewlines: true}},
+		{"No wrapping", "Hello world", WrapOptions{}},
+		{"Long word", "supercalifragilistic", WrapOptions{MaxWidth: 5}},
+		{"Empty", "", WrapOptions{MaxWidth: 10}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines := txt.Wrap(tt.text, tt.opts)
+			cost := txt.EstimateWrapCost(tt.text, tt.opts)
+
+			if cost.EstimatedLines != len(lines) {
+				t.Errorf("EstimatedLines = %d, want %d", cost.EstimatedLines, len(lines))
+			}
+
+			actual := len(lines) * lineSize
+			for _, line := range lines {
+				actual += len(line.Content)
+			}
+			// Only dropped newlines may be overcounted.
+			slack := strings.Count(tt.text, "\n") + strings.Count(tt.text, "\r")
+			if cost.EstimatedBytes < actual || cost.EstimatedBytes > actual+slack {
+				t.Errorf("EstimatedBytes = %d, want within [%d, %d]", cost.EstimatedBytes, actual, actual+slack)
+			}
+		})
+	}
+}
+
 // ═══════════════════════════════════════════════════════════════
 //  CSS Text Bounds Tests
 // ═══════════════════════════════════════════════════════════════
@@ -320,6 +527,117 @@ func TestMeasureCSS(t *testing.T) {
 	}
 }
 
+// ═══════════════════════════════════════════════════════════════
+//  Font Metrics Tests
+// ═══════════════════════════════════════════════════════════════
+
+// fakeFontMetrics is a FontMetrics with fixed values in font units.
+type fakeFontMetrics struct {
+	ascent, descent, lineGap, capHeight, xHeight, unitsPerEm float64
+}
+
+func TestMeasureCSS_MatchesWrapCSS(t *testing.T) {
+	txt := NewTerminal()
+
+	for _, ws := range []WhiteSpace{WhiteSpaceNormal, WhiteSpaceNoWrap, WhiteSpacePre, WhiteSpacePreWrap, WhiteSpacePreLine, WhiteSpaceBreakSpaces} {
+		for _, text := range []string{"Hello    world\nNext", "a\r\nb\rc", "one two three four"} {
+			opts := CSSWrapOptions{MaxWidth: units.Px(8), Style: DefaultCSSTextStyle()}
+			opts.Style.WhiteSpace = ws
+
+			lines := txt.WrapCSS(text, opts)
+			bounds := txt.MeasureCSS(text, opts, TextStyle{LineHeight: 1})
+			if bounds.LineCount != len(lines) {
+				t.Errorf("white-space %v, %q: LineCount = %d, WrapCSS returned %d lines", ws, text, bounds.LineCount, len(lines))
+				continue
+			}
+			for i, line := range lines {
+				if bounds.Lines[i].Content != line.Content {
+					t.Errorf("white-space %v, %q: line %d = %q, want %q", ws, text, i, bounds.Lines[i].Content, line.Content)
+				}
+			}
+		}
+	}
+}
+
+func (f fakeFontMetrics) Ascent() float64     { return f.ascent }
+func (f fakeFontMetrics) Descent() float64    { return f.descent }
+func (f fakeFontMetrics) LineGap() float64    { return f.lineGap }
+func (f fakeFontMetrics) CapHeight() float64  { return f.capHeight }
+func (f fakeFontMetrics) XHeight() float64    { return f.xHeight }
+func (f fakeFontMetrics) UnitsPerEm() float64 { return f.unitsPerEm }
+
+func TestMeasureLineBox_FontMetrics(t *testing.T) {
+	fm := fakeFontMetrics{ascent: 900, descent: 300, lineGap: 200, capHeight: 700, xHeight: 500, unitsPerEm: 1000}
+	txt := NewTerminal().WithFontMetrics(fm)
+
+	tests := []struct {
+		name        string
+		style       TextStyle
+		wantAscent  float64
+		wantDescent float64
+		wantLeading float64
+		wantHeight  float64
+	}{
+		{"Normal line height", TextStyle{FontSize: 10}, 9, 3, 2, 14},
+		{"Explicit line height", TextStyle{FontSize: 10, LineHeight: 18}, 9, 3, 6, 18},
+		{"Tight line height", TextStyle{FontSize: 10, LineHeight: 10}, 9, 3, -2, 10},
+		{"Default font size", TextStyle{}, 0.9, 0.3, 0.2, 1.4},
+	}
+
+	const epsilon = 1e-9
+	near := func(a, b float64) bool { return math.Abs(a-b) < epsilon }
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := txt.MeasureLineBox("Hello", tt.style)
+			if !near(m.Ascent, tt.wantAscent) || !near(m.Descent, tt.wantDescent) ||
+				!near(m.Leading, tt.wantLeading) || !near(m.LineHeight, tt.wantHeight) {
+				t.Errorf("Ascent, Descent, Leading, LineHeight = %g, %g, %g, %g, want %g, %g, %g, %g",
+					m.Ascent, m.Descent, m.Leading, m.LineHeight,
+					tt.wantAscent, tt.wantDescent, tt.wantLeading, tt.wantHeight)
+			}
+			if !near(m.Baseline, m.Ascent+m.Leading/2) {
+				t.Errorf("Baseline = %g, want %g", m.Baseline, m.Ascent+m.Leading/2)
+			}
+			if m.Width != 5 {
+				t.Errorf("Width = %g, want 5", m.Width)
+			}
+		})
+	}
+
+	// Negative descent, as some fonts report it, is treated as a distance.
+	neg := NewTerminal().WithFontMetrics(fakeFontMetrics{ascent: 800, descent: -200, unitsPerEm: 1000})
+	if got := neg.MeasureLineBox("x", TextStyle{FontSize: 10}).Descent; !near(got, 2) {
+		t.Errorf("Descent = %g, want 2", got)
+	}
+}
+
+func TestWithFontMetrics(t *testing.T) {
+	base := NewTerminal()
+	fm := fakeFontMetrics{ascent: 750, descent: 250, unitsPerEm: 1000}
+	withFont := base.WithFontMetrics(fm)
+
+	if base.FontMetrics() != nil {
+		t.Error("WithFontMetrics modified the original Text")
+	}
+	if withFont.FontMetrics() != fm {
+		t.Errorf("FontMetrics() = %v, want %v", withFont.FontMetrics(), fm)
+	}
+	if got := base.MeasureLineBox("Hello", TextStyle{}).Ascent; got != 0.8 {
+		t.Errorf("default Ascent = %g, want 0.8", got)
+	}
+
+	bounds := withFont.MeasureMultiLine("Hello world", WrapOptions{MaxWidth: 6}, TextStyle{FontSize: 20})
+	if bounds.LineCount != 2 || bounds.Height != 40 || bounds.FirstBaseline != 15 {
+		t.Errorf("LineCount, Height, FirstBaseline = %d, %g, %g, want 2, 40, 15",
+			bounds.LineCount, bounds.Height, bounds.FirstBaseline)
+	}
+
+	if got := withFont.WithFontMetrics(nil).MeasureLineBox("Hello", TextStyle{}).Ascent; got != 0.8 {
+		t.Errorf("Ascent after WithFontMetrics(nil) = %g, want 0.8", got)
+	}
+}
+
 // ═══════════════════════════════════════════════════════════════
 //  Benchmark Tests
 // ═══════════════════════════════════════════════════════════════
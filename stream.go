@@ -0,0 +1,288 @@
+package text
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"unicode/utf8"
+
+	"github.com/SCKelemen/unicode/v6/uax14"
+)
+
+// Streaming Wrap
+//
+// WrapReader wraps text read incrementally from an io.Reader, so large logs
+// or files can be wrapped without holding them in memory. Each read is
+// wrapped together with the text still pending from the previous one, and
+// only lines that are far enough from the end of the buffer are emitted.
+// The last line of the buffer (and anything within a small lookahead window
+// of the end) is carried over and re-wrapped after the next read, so
+// grapheme clusters and UAX #14 break opportunities that straddle a read
+// boundary are resolved with the full context. With PreserveNewlines, lines
+// up to the last newline read are final and are emitted at once, so
+// interactive input such as a terminal produces lines as they are typed.
+//
+// Memory use is bounded by the chunk size plus the longest run of text
+// without a break opportunity. Such a run is not re-wrapped on every read;
+// only the newly read bytes are checked for a break opportunity.
+
+const (
+	// streamChunkSize is the most bytes read per refill.
+	streamChunkSize = 4096
+
+	// streamLookahead is how many bytes must follow a line before it is
+	// emitted. It covers the context UAX #14 and UAX #29 inspect after a
+	// boundary, so breaks near the end of the buffer are never final.
+	streamLookahead = 64
+)
+
+// ErrNilReader is returned by WrapReader when given a nil reader.
+var ErrNilReader = errors.New("text: nil reader")
+
+// LineScanner yields wrapped lines from a stream.
+//
+// Use it like bufio.Scanner:
+//
+//	sc, err := txt.WrapReader(file, text.WrapOptions{MaxWidth: 80})
+//	if err != nil {
+//	    return err
+//	}
+//	for sc.Scan() {
+//	    fmt.Println(sc.Line().Content)
+//	}
+//	if err := sc.Err(); err != nil {
+//	    return err
+//	}
+type LineScanner struct {
+	t    *Text
+	r    io.Reader
+	opts WrapOptions
+
+	buf     []byte // unconsumed input
+	offset  int    // stream byte offset of buf[0]
+	eof     bool
+	wrapped bool // buf has been wrapped since the last read
+	noBreak int  // buf[:noBreak] is known to hold no break opportunity
+	pending []Line
+	line    Line
+	err     error
+}
+
+// WrapReader returns a LineScanner that wraps text read from r.
+//
+// Lines are the same as Wrap would produce for the whole input, except that
+// Line.Start and Line.End are byte offsets into the stream rather than rune
+// indices. A leading UTF-8 byte order mark is skipped (offsets still count
+// it). With Config.NormalizeInput, Content is in NFC while Start and End
+// still index the bytes as read, so stream[Start:End] may be decomposed. An error from the first read is returned immediately; later read
+// errors are reported by LineScanner.Err.
+//
+// Example:
+//
+//	txt := text.NewTerminal()
+//	sc, err := txt.WrapReader(os.Stdin, text.WrapOptions{
+//	    MaxWidth:         80,
+//	    PreserveNewlines: true,
+//	})
+func (t *Text) WrapReader(r io.Reader, opts WrapOptions) (*LineScanner, error) {
+	if r == nil {
+		return nil, ErrNilReader
+	}
+
+	s := &LineScanner{t: t, r: r, opts: opts}
+	for {
+		if _, err := s.fill(); err != nil {
+			return nil, err
+		}
+		// Keep reading while the input could still be a split byte order mark
+		if s.eof || len(s.buf) >= len(bomUTF8) || !bytes.HasPrefix(bomUTF8, s.buf) {
+			break
+		}
+	}
+
+	if stripped := StripBOM(s.buf); len(stripped) < len(s.buf) {
+		s.offset = len(s.buf) - len(stripped)
+		s.buf = stripped
+	}
+
+	return s, nil
+}
+
+// Scan advances to the next wrapped line, which is then available through
+// Line. It returns false when the input is exhausted or a read fails.
+func (s *LineScanner) Scan() bool {
+	for len(s.pending) == 0 {
+		if s.err != nil || (s.eof && len(s.buf) == 0) {
+			return false
+		}
+		s.wrapBuffered()
+	}
+
+	s.line = s.pending[0]
+	s.pending = s.pending[1:]
+	return true
+}
+
+// Line returns the most recent line produced by Scan.
+// Start and End are byte offsets into the stream.
+func (s *LineScanner) Line() Line {
+	return s.line
+}
+
+// Err returns the first non-EOF error encountered while reading. Lines
+// read before the error are still produced by Scan first.
+func (s *LineScanner) Err() error {
+	return s.err
+}
+
+// fill appends the result of one Read, at most streamChunkSize bytes, to
+// the buffer and returns the number of bytes read. A single Read returns
+// whatever is available, so a pipe or terminal is not made to wait for a
+// full chunk.
+func (s *LineScanner) fill() (int, error) {
+	if s.eof {
+		return 0, nil
+	}
+
+	start := len(s.buf)
+	s.buf = append(s.buf, make([]byte, streamChunkSize)...)
+	n, err := s.r.Read(s.buf[start:])
+	s.buf = s.buf[:start+n]
+
+	if err == io.EOF {
+		s.eof = true
+		return n, nil
+	}
+	return n, err
+}
+
+// wrapBuffered wraps the buffer, reading more input first if it has already
+// been wrapped, and queues every line that can no longer be affected by
+// input that has not been read yet. If no line is final yet, the buffer
+// simply grows on the next call. After a read error, everything buffered is
+// queued before Scan stops.
+func (s *LineScanner) wrapBuffered() {
+	if s.wrapped {
+		n, err := s.fill()
+		if err != nil {
+			s.err = err
+		} else if n == 0 && !s.eof {
+			return
+		}
+	}
+	s.wrapped = true
+	final := s.eof || s.err != nil
+
+	// Hold back a rune split by the read boundary until it is complete.
+	text := string(s.buf[:completeRunes(s.buf, final)])
+
+	// A buffer that is one unbreakable run stays one line until a break
+	// opportunity arrives, so only the newly read text needs checking.
+	if !final && s.noBreak > 0 {
+		if !s.hasBreakOpportunity(text, s.noBreak) {
+			s.noBreak = len(text)
+			return
+		}
+		s.noBreak = 0
+	}
+
+	// Line offsets index the normalized text; map them back to the bytes
+	// read so that Start, End and what is consumed refer to the stream.
+	normalized := s.t.normalizeInput(text)
+	lines := s.t.Wrap(normalized, s.opts)
+	toNormalized := runeToByteCursor(normalized)
+	toSource := sourceOffsetCursor(text, normalized)
+	toBytes := func(runeIndex int) int { return toSource(toNormalized(runeIndex)) }
+
+	// Each paragraph is wrapped on its own with PreserveNewlines, so lines
+	// up to a hard break do not depend on what follows it.
+	lastHard := -1
+	for i, line := range lines {
+		if line.BreakType == BreakHard {
+			lastHard = i
+		}
+	}
+
+	consumed := 0
+	for i, line := range lines {
+		start, end := toBytes(line.Start), toBytes(line.End)
+
+		if !final {
+			if i == len(lines)-1 {
+				break
+			}
+			next := toBytes(lines[i+1].Start)
+			if i > lastHard && len(text)-next < streamLookahead {
+				break
+			}
+			consumed = next
+		}
+
+		line.Start = s.offset + start
+		line.End = s.offset + end
+		s.pending = append(s.pending, line)
+	}
+
+	if final {
+		s.buf = nil
+		return
+	}
+
+	s.buf = append(s.buf[:0], s.buf[consumed:]...)
+	s.offset += consumed
+
+	if consumed == 0 && len(lines) == 1 && !s.opts.BreakWords && !s.hasBreakOpportunity(text, 0) {
+		s.noBreak = len(text)
+	}
+}
+
+// hasBreakOpportunity reports whether text has a UAX #14 break opportunity
+// strictly inside it, looking only at the text from a lookahead window
+// before from onward. The end of text is not an opportunity: more input may
+// follow it.
+func (s *LineScanner) hasBreakOpportunity(text string, from int) bool {
+	from = max(0, from-streamLookahead)
+	for from > 0 && !utf8.RuneStart(text[from]) {
+		from--
+	}
+
+	for _, bp := range uax14.FindLineBreakOpportunities(text[from:], s.t.config.HyphenationMode) {
+		// The window's own start is not an opportunity either
+		if bp > 0 && from+bp < len(text) {
+			return true
+		}
+	}
+	return false
+}
+
+// completeRunes returns the length of the prefix of b that does not end in
+// a truncated multi-byte rune. At the end of input the whole buffer is used.
+func completeRunes(b []byte, final bool) int {
+	if final {
+		return len(b)
+	}
+
+	start := len(b) - 1
+	for start > 0 && len(b)-start < utf8.UTFMax && !utf8.RuneStart(b[start]) {
+		start--
+	}
+	if start >= 0 && !utf8.FullRune(b[start:]) {
+		return start
+	}
+	return len(b)
+}
+
+// runeToByteCursor returns a function mapping rune indices in s to byte
+// offsets. Calls must use non-decreasing indices, which lets the cursor
+// walk s only once.
+func runeToByteCursor(s string) func(runeIndex int) int {
+	runeIdx, byteIdx := 0, 0
+	return func(target int) int {
+		for runeIdx < target && byteIdx < len(s) {
+			_, size := utf8.DecodeRuneInString(s[byteIdx:])
+			byteIdx += size
+			runeIdx++
+		}
+		return byteIdx
+	}
+}
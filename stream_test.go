@@ -0,0 +1,245 @@
+package text
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+)
+
+// streamCorpus returns text long enough to need several refills, mixing
+// Latin, CJK, emoji ZWJ sequences, and combining marks.
+func streamCorpus() string {
+	var b strings.Builder
+	for i := 0; b.Len() < 3*streamChunkSize; i++ {
+		b.WriteString("The quick brown fox 世界你好 jumps 👨‍👩‍👧 over the lazy dog. Café naïve é ")
+		if i%7 == 0 {
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+// collectStream drains a LineScanner.
+func collectStream(t *testing.T, sc *LineScanner) []Line {
+	t.Helper()
+
+	var lines []Line
+	for sc.Scan() {
+		lines = append(lines, sc.Line())
+	}
+	if err := sc.Err(); err != nil {
+		t.Fatalf("Err() = %v", err)
+	}
+	return lines
+}
+
+func TestWrapReader_MatchesWrap(t *testing.T) {
+	txt := NewTerminal()
+	text := streamCorpus()
+
+	readers := []struct {
+		name string
+		r    func() io.Reader
+	}{
+		{"Whole", func() io.Reader { return strings.NewReader(text) }},
+		{"OneByte", func() io.Reader { return iotest.OneByteReader(strings.NewReader(text)) }},
+		{"Half", func() io.Reader { return iotest.HalfReader(strings.NewReader(text)) }},
+	}
+
+	optsList := []WrapOptions{
+		{MaxWidth: 20},
+		{MaxWidth: 13, BreakWords: true},
+		{MaxWidth: 30, PreserveNewlines: true},
+	}
+
+	for _, opts := range optsList {
+		want := txt.Wrap(text, opts)
+
+		for _, rd := range readers {
+			t.Run(rd.name, func(t *testing.T) {
+				sc, err := txt.WrapReader(rd.r(), opts)
+				if err != nil {
+					t.Fatalf("WrapReader error: %v", err)
+				}
+				got := collectStream(t, sc)
+
+				if len(got) != len(want) {
+					t.Fatalf("%+v: got %d lines, want %d", opts, len(got), len(want))
+				}
+				for i := range want {
+					if got[i].Content != want[i].Content || got[i].Width != want[i].Width || got[i].BreakType != want[i].BreakType {
+						t.Fatalf("%+v: line %d = %+v, want %+v", opts, i, got[i], want[i])
+					}
+					// Start/End are byte offsets into the stream.
+					if text[got[i].Start:got[i].End] != got[i].Content {
+						t.Fatalf("%+v: line %d byte range [%d:%d] = %q, want %q",
+							opts, i, got[i].Start, got[i].End, text[got[i].Start:got[i].End], got[i].Content)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestWrapReader_NormalizeInput(t *testing.T) {
+	txt := New(Config{MeasureFunc: TerminalMeasure, NormalizeInput: true})
+	// Decomposed input: every "é" is "e" + U+0301 in the stream.
+	text := Normalize(streamCorpus(), NFD)
+	opts := WrapOptions{MaxWidth: 20, PreserveNewlines: true}
+	want := txt.Wrap(text, opts)
+
+	sc, err := txt.WrapReader(iotest.OneByteReader(strings.NewReader(text)), opts)
+	if err != nil {
+		t.Fatalf("WrapReader error: %v", err)
+	}
+	got := collectStream(t, sc)
+
+	if len(got) != len(want) {
+		t.Fatalf("got %d lines, want %d", len(got), len(want))
+	}
+	end := 0
+	for i := range want {
+		if got[i].Content != want[i].Content {
+			t.Fatalf("line %d = %q, want %q", i, got[i].Content, want[i].Content)
+		}
+		// Start/End index the decomposed bytes as read.
+		source := text[got[i].Start:got[i].End]
+		if Normalize(source, NFC) != got[i].Content {
+			t.Fatalf("line %d byte range [%d:%d] = %q, want the NFD form of %q",
+				i, got[i].Start, got[i].End, source, got[i].Content)
+		}
+		if got[i].Start < end {
+			t.Fatalf("line %d starts at %d, before the previous line's end %d", i, got[i].Start, end)
+		}
+		end = got[i].End
+	}
+	if end != len(text) && strings.TrimSpace(text[end:]) != "" {
+		t.Errorf("lines end at %d of %d bytes", end, len(text))
+	}
+}
+
+func TestWrapReader_UnbreakableRun(t *testing.T) {
+	txt := NewTerminal()
+	text := "head " + strings.Repeat("x", streamChunkSize) + " tail words"
+	opts := WrapOptions{MaxWidth: 20}
+
+	sc, err := txt.WrapReader(iotest.OneByteReader(strings.NewReader(text)), opts)
+	if err != nil {
+		t.Fatalf("WrapReader error: %v", err)
+	}
+	got := collectStream(t, sc)
+
+	want := txt.Wrap(text, opts)
+	if len(got) != len(want) {
+		t.Fatalf("got %d lines, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i].Content != want[i].Content {
+			t.Errorf("line %d = %q, want %q", i, got[i].Content, want[i].Content)
+		}
+	}
+}
+
+func TestWrapReader_Interactive(t *testing.T) {
+	txt := NewTerminal()
+	pr, pw := io.Pipe()
+	defer pw.Close()
+
+	go pw.Write([]byte("one two\n"))
+
+	sc, err := txt.WrapReader(pr, WrapOptions{MaxWidth: 20, PreserveNewlines: true})
+	if err != nil {
+		t.Fatalf("WrapReader error: %v", err)
+	}
+
+	// The first line must arrive while the writer is still open.
+	lines := make(chan string, 1)
+	go func() {
+		if sc.Scan() {
+			lines <- sc.Line().Content
+		}
+	}()
+
+	select {
+	case got := <-lines:
+		if got != "one two" {
+			t.Errorf("first line = %q, want %q", got, "one two")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no line produced before more input arrived")
+	}
+}
+
+func TestWrapReader_BOM(t *testing.T) {
+	txt := NewTerminal()
+
+	sc, err := txt.WrapReader(strings.NewReader("\xEF\xBB\xBFHello world"), WrapOptions{MaxWidth: 6})
+	if err != nil {
+		t.Fatalf("WrapReader error: %v", err)
+	}
+	lines := collectStream(t, sc)
+
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2", len(lines))
+	}
+	if lines[0].Content != "Hello " || lines[0].Start != 3 {
+		t.Errorf("first line = %+v, want %q at byte 3", lines[0], "Hello ")
+	}
+}
+
+func TestWrapReader_Empty(t *testing.T) {
+	txt := NewTerminal()
+
+	sc, err := txt.WrapReader(strings.NewReader(""), WrapOptions{MaxWidth: 10})
+	if err != nil {
+		t.Fatalf("WrapReader error: %v", err)
+	}
+	if lines := collectStream(t, sc); len(lines) != 0 {
+		t.Errorf("expected no lines, got %+v", lines)
+	}
+}
+
+func TestWrapReader_Errors(t *testing.T) {
+	txt := NewTerminal()
+	errRead := errors.New("boom")
+
+	if _, err := txt.WrapReader(nil, WrapOptions{MaxWidth: 10}); !errors.Is(err, ErrNilReader) {
+		t.Errorf("nil reader error = %v, want ErrNilReader", err)
+	}
+
+	if _, err := txt.WrapReader(iotest.ErrReader(errRead), WrapOptions{MaxWidth: 10}); !errors.Is(err, errRead) {
+		t.Errorf("first read error = %v, want %v", err, errRead)
+	}
+
+	// A failure after the first chunk is reported through Err.
+	r := io.MultiReader(strings.NewReader(streamCorpus()), iotest.ErrReader(errRead))
+	sc, err := txt.WrapReader(r, WrapOptions{MaxWidth: 20})
+	if err != nil {
+		t.Fatalf("WrapReader error: %v", err)
+	}
+	for sc.Scan() {
+	}
+	if !errors.Is(sc.Err(), errRead) {
+		t.Errorf("Err() = %v, want %v", sc.Err(), errRead)
+	}
+
+	// Text read before the failure is still produced.
+	r = io.MultiReader(strings.NewReader("Hello world"), iotest.ErrReader(errRead))
+	sc, err = txt.WrapReader(r, WrapOptions{MaxWidth: 6})
+	if err != nil {
+		t.Fatalf("WrapReader error: %v", err)
+	}
+	var got []string
+	for sc.Scan() {
+		got = append(got, sc.Line().Content)
+	}
+	if want := []string{"Hello ", "world"}; strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("lines before error = %q, want %q", got, want)
+	}
+	if !errors.Is(sc.Err(), errRead) {
+		t.Errorf("Err() = %v, want %v", sc.Err(), errRead)
+	}
+}
@@ -64,7 +64,11 @@
 package text
 
 import (
+	"sort"
 	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/SCKelemen/unicode/v6/uax11"
 	"github.com/SCKelemen/unicode/v6/uax14"
@@ -94,6 +98,67 @@ type Config struct {
 
 	// BaseDirection specifies the default paragraph direction for UAX #9.
 	BaseDirection uax9.Direction
+
+	// ComposeHalfwidthKana measures a halfwidth katakana followed by a
+	// halfwidth voiced/semi-voiced sound mark (U+FF9E, U+FF9F) as one cell.
+	// By default (false) the sound mark occupies its own cell, so "ｶﾞ" is
+	// 2 cells wide, matching how terminals render halfwidth katakana.
+	// Set to true for renderers that compose the pair into a single glyph.
+	ComposeHalfwidthKana bool
+
+	// SplitZWJSequences measures an emoji ZWJ sequence as the sum of its
+	// joined parts, for terminals that cannot compose such sequences and
+	// draw each part as a separate emoji. By default (false) a sequence like
+	// "👩🏽‍🤝‍👨🏻" is one glyph, 2 cells wide; with this set it is 6 cells
+	// (woman, handshake, man). Either way the cluster is never split when
+	// wrapping.
+	SplitZWJSequences bool
+
+	// CacheWidths memoizes MeasureFunc results per rune. Repeated
+	// measurements of the same runes (typical when a UI re-measures text
+	// every frame) then skip the Unicode property lookups. The cache is
+	// safe for concurrent use and is keyed only by rune, so MeasureFunc must
+	// be a pure function of its argument.
+	CacheWidths bool
+
+	// NewlinesAsSpaces measures a line break ("\n", "\r", or "\r\n") as
+	// one space, for callers that collapse newlines into spaces before
+	// display. By default (false) a line break has no width, so the width
+	// of a multi-line string is the sum of its visible characters.
+	NewlinesAsSpaces bool
+
+	// PadFunc builds the padding that alignment and justification insert,
+	// given the width to fill. By default padding is whole spaces, so with
+	// a pixel MeasureFunc it can fall short of the requested width by up to
+	// one space. Set PadFunc to fill the remainder, for example with narrower
+	// space characters (U+2009 THIN SPACE, U+200A HAIR SPACE) that
+	// MeasureFunc reports at their real widths.
+	PadFunc PadFunc
+
+	// FontSize is the font size in MeasureFunc units, used to resolve
+	// font-relative CSS lengths such as "0.1em" letter-spacing (see
+	// ResolveLength). For a pixel MeasureFunc this is the font size in
+	// pixels. When 0, em and rem lengths are taken as plain units.
+	FontSize float64
+
+	// XHeight is the height of lowercase "x" in MeasureFunc units, used to
+	// resolve ex lengths. When 0 it comes from the font metrics (see
+	// WithFontMetrics), or else is half of FontSize, as CSS specifies.
+	XHeight float64
+
+	// NormalizeInput converts text to NFC (see Normalize) in Width, Wrap
+	// and the grapheme operations before measuring or splitting it. Grapheme
+	// clusters and widths are the same either way; what changes is that
+	// rune indices such as Line.Start/End refer to the NFC text, so "é"
+	// counts as one rune whether the caller passed it composed or not.
+	NormalizeInput bool
+
+	// ZeroWidthControls measures characters that draw nothing as zero
+	// width instead of asking MeasureFunc: control characters other than
+	// tab and line breaks, ZERO WIDTH SPACE (U+200B), WORD JOINER (U+2060)
+	// and the byte order mark (U+FEFF). These are the characters
+	// SanitizeVisible removes.
+	ZeroWidthControls bool
 }
 
 // MeasureFunc measures the width of a single rune in abstract units.
@@ -102,9 +167,16 @@ type Config struct {
 // For pixel-based rendering, it should return the actual pixel width.
 type MeasureFunc func(r rune) float64
 
+// PadFunc returns padding that measures as close to width as possible
+// without exceeding it.
+type PadFunc func(width float64) string
+
 // Text provides high-level Unicode-aware text operations.
 type Text struct {
 	config Config
+
+	// fontMetrics, when set by WithFontMetrics, sizes line boxes.
+	fontMetrics FontMetrics
 }
 
 // New creates a new Text instance with the given configuration.
@@ -119,10 +191,26 @@ func New(config Config) *Text {
 	if config.BaseDirection == 0 {
 		config.BaseDirection = uax9.DirectionLTR
 	}
+	if config.CacheWidths {
+		config.MeasureFunc = cachedMeasure(config.MeasureFunc)
+	}
 
 	return &Text{config: config}
 }
 
+// cachedMeasure wraps measure in a concurrency-safe per-rune memo.
+func cachedMeasure(measure MeasureFunc) MeasureFunc {
+	var cache sync.Map // rune -> float64
+	return func(r rune) float64 {
+		if w, ok := cache.Load(r); ok {
+			return w.(float64)
+		}
+		w := measure(r)
+		cache.Store(r, w)
+		return w
+	}
+}
+
 // NewTerminal creates a Text instance configured for terminal rendering.
 //
 // Uses:
@@ -166,6 +254,7 @@ func NewTerminalEastAsian() *Text {
 //   - Emoji (2 cells/units wide)
 //   - Combining marks (0 width)
 //   - Zero-width joiners (0 width)
+//   - Line breaks (0 width, see Config.NewlinesAsSpaces)
 //
 // Example:
 //
@@ -175,19 +264,21 @@ func NewTerminalEastAsian() *Text {
 //	width = txt.Width("👋🏻")        // 2.0 cells (emoji + skin tone modifier)
 func (t *Text) Width(s string) float64 {
 	width := 0.0
-	for _, g := range uax29.Graphemes(s) {
+	for _, g := range uax29.Graphemes(t.normalizeInput(s)) {
 		width += t.graphemeWidth(g)
 	}
 	return width
 }
 
 // WidthBytes measures the display width of UTF-8 bytes.
+// A leading UTF-8 byte order mark is ignored.
 func (t *Text) WidthBytes(b []byte) float64 {
-	return t.Width(string(b))
+	return t.Width(string(StripBOM(b)))
 }
 
 // WidthUpTo measures text width and reports if the max width was exceeded.
 // If exceeded is true, the returned width includes the grapheme that exceeded maxWidth.
+// Use FitWidth to find how much of s fits within maxWidth.
 func (t *Text) WidthUpTo(s string, maxWidth float64) (width float64, exceeded bool) {
 	width = 0.0
 	for _, g := range uax29.Graphemes(s) {
@@ -199,19 +290,223 @@ func (t *Text) WidthUpTo(s string, maxWidth float64) (width float64, exceeded bo
 	return width, false
 }
 
+// FitWidth returns the longest prefix of s, in whole grapheme clusters, that
+// fits within maxWidth: its length in runes and its width. Like Fits, it
+// stops measuring at the first cluster that does not fit.
+//
+// Example:
+//
+//	txt := text.NewTerminal()
+//	n, w := txt.FitWidth("Hello 世界", 7) // 6, 6 ("Hello "; 世 needs 2 more)
+//	prefix := string([]rune("Hello 世界")[:n])
+func (t *Text) FitWidth(s string, maxWidth float64) (runeCount int, width float64) {
+	_, runeCount, width = t.fitPrefix(s, maxWidth)
+	return runeCount, width
+}
+
+// ClipToWidth splits s at a grapheme cluster boundary into head, the
+// longest prefix that fits within maxWidth, and tail, the rest. No ellipsis
+// is added, so head+tail == s. head is empty when the first cluster alone is
+// wider than maxWidth; clusters such as emoji ZWJ sequences are never split.
+//
+// Example:
+//
+//	txt := text.NewTerminal()
+//	head, tail, w := txt.ClipToWidth("Hello 世界", 7)
+//	// head: "Hello ", tail: "世界", w: 6
+func (t *Text) ClipToWidth(s string, maxWidth float64) (head, tail string, headWidth float64) {
+	end, _, headWidth := t.fitPrefix(s, maxWidth)
+	return s[:end], s[end:], headWidth
+}
+
+// fitPrefix measures the longest prefix of s, in whole grapheme clusters,
+// that fits within maxWidth, returning its length in bytes and runes and
+// its width.
+func (t *Text) fitPrefix(s string, maxWidth float64) (byteLen, runeCount int, width float64) {
+	for byteLen < len(s) {
+		n := asciiCluster(s, byteLen)
+		runes := 1
+		if n == 0 {
+			n = nextGrapheme(s, byteLen)
+			runes = utf8.RuneCountInString(s[byteLen : byteLen+n])
+		} else if n == 2 {
+			runes = 2
+		}
+		w := t.graphemeWidth(s[byteLen : byteLen+n])
+		if width+w > maxWidth {
+			break
+		}
+		width += w
+		byteLen += n
+		runeCount += runes
+	}
+	return byteLen, runeCount, width
+}
+
+// Fits reports whether text fits within maxWidth without wrapping.
+//
+// Unlike Width(text) <= maxWidth, Fits stops measuring as soon as the
+// accumulated width exceeds maxWidth, so its cost depends on maxWidth
+// rather than on the length of text. ASCII runs are measured without
+// allocating; other clusters are segmented one at a time with the same
+// UAX #29 rules Width uses, so clusters that join an ASCII character to its
+// neighbours, such as a Prepend mark before a digit (GB9b), are measured
+// whole.
+//
+// Example:
+//
+//	txt := text.NewTerminal()
+//	txt.Fits("Hello", 5)      // true
+//	txt.Fits("Hello 世界", 8) // false (9 cells)
+func (t *Text) Fits(s string, maxWidth float64) bool {
+	s = t.normalizeInput(s)
+	width := 0.0
+	for i := 0; i < len(s); {
+		n := asciiCluster(s, i)
+		if n == 0 {
+			n = nextGrapheme(s, i)
+		}
+		width += t.graphemeWidth(s[i : i+n])
+		if width > maxWidth {
+			return false
+		}
+		i += n
+	}
+	return true
+}
+
+// asciiCluster returns the length of the grapheme cluster at byte i when
+// it is made of ASCII only and cannot join what follows: a single ASCII
+// character, or CR LF, followed by another ASCII character or the end of
+// s. It returns 0 otherwise, since a non-ASCII neighbour may be a
+// combining mark that belongs to the cluster.
+func asciiCluster(s string, i int) int {
+	if s[i] >= utf8.RuneSelf {
+		return 0
+	}
+	n := 1
+	if s[i] == '\r' && i+1 < len(s) && s[i+1] == '\n' {
+		n = 2
+	}
+	if i+n < len(s) && s[i+n] >= utf8.RuneSelf {
+		return 0
+	}
+	return n
+}
+
+// nextGrapheme returns the length in bytes of the grapheme cluster that
+// starts at byte i of s, which must be a cluster boundary. Only a window
+// after i is segmented, doubling until a boundary falls inside it, so the
+// cost depends on the cluster rather than on the rest of s.
+func nextGrapheme(s string, i int) int {
+	for size := 32; ; size *= 2 {
+		end := i + size
+		if end >= len(s) {
+			end = len(s)
+		}
+		for end < len(s) && !utf8.RuneStart(s[end]) {
+			end++
+		}
+		for _, b := range uax29.FindGraphemeBreaks(s[i:end]) {
+			// The end of a cut window is not a real boundary: the
+			// next rune may still extend the cluster.
+			if b > 0 && (b < end-i || end == len(s)) {
+				return b
+			}
+		}
+	}
+}
+
+// VisibleWidth measures the display width of text excluding trailing
+// whitespace.
+//
+// Trailing spaces are invisible, so this is the width that matters for
+// right-alignment, cursor placement, and deciding whether a line really
+// overflows. All Unicode white space is trimmed, including the ideographic
+// space (U+3000) and no-break space.
+//
+// Example:
+//
+//	txt := text.NewTerminal()
+//	txt.Width("Hello   ")        // 8.0 cells
+//	txt.VisibleWidth("Hello   ") // 5.0 cells
+func (t *Text) VisibleWidth(s string) float64 {
+	return t.Width(strings.TrimRightFunc(s, unicode.IsSpace))
+}
+
 func (t *Text) graphemeWidth(g string) float64 {
+	if g == "\n" || g == "\r" || g == "\r\n" {
+		if t.config.NewlinesAsSpaces {
+			return t.config.MeasureFunc(' ')
+		}
+		return 0
+	}
+
+	if t.config.SplitZWJSequences && strings.ContainsRune(g, zeroWidthJoiner) {
+		width := 0.0
+		for _, part := range strings.Split(g, string(zeroWidthJoiner)) {
+			width += t.graphemeWidth(part)
+		}
+		return width
+	}
+
 	runes := []rune(g)
 	if emojiWidth, ok := emojiClusterWidth(runes); ok {
 		return float64(emojiWidth)
 	}
 
 	width := 0.0
-	for _, r := range runes {
+	for i, r := range runes {
+		if isZeroWidthFormat(r) || (t.config.ZeroWidthControls && isInvisibleControl(r)) {
+			continue
+		}
+		if i > 0 && t.config.ComposeHalfwidthKana && isHalfwidthSoundMark(r) && isHalfwidthKatakana(runes[i-1]) {
+			continue
+		}
 		width += t.config.MeasureFunc(r)
 	}
 	return width
 }
 
+// zeroWidthJoiner (U+200D) joins emoji into a single ZWJ sequence.
+const zeroWidthJoiner = rune(0x200D)
+
+// isZeroWidthFormat reports whether r is a format control that never
+// occupies space, regardless of the MeasureFunc in use.
+//
+// ZWNJ (U+200C) and ZWJ (U+200D) only affect shaping of their neighbours.
+// They are Extend characters in UAX #29 and carry no break opportunity in
+// UAX #14, so they always stay inside the surrounding word.
+func isZeroWidthFormat(r rune) bool {
+	switch r {
+	case 0x200C, // ZERO WIDTH NON-JOINER
+		zeroWidthJoiner:
+		return true
+	}
+	return false
+}
+
+// isCombiningMark reports whether r is a nonspacing or enclosing mark
+// (general categories Mn and Me). These render on top of the preceding base
+// character and take no cell of their own, as terminals (and wcwidth) draw
+// them. UAX #11 classes most of them as neutral, which would count a cell
+// per mark, so "e\u0301" would be wider than "é" and Hebrew with vowel
+// points wider than without. Spacing marks (Mc) are not included.
+func isCombiningMark(r rune) bool {
+	return r >= 0x0300 && unicode.In(r, unicode.Mn, unicode.Me)
+}
+
+// isHalfwidthKatakana reports whether r is in the Halfwidth Katakana block (U+FF65–U+FF9D).
+func isHalfwidthKatakana(r rune) bool {
+	return r >= 0xFF65 && r <= 0xFF9D
+}
+
+// isHalfwidthSoundMark reports whether r is the halfwidth voiced (U+FF9E)
+// or semi-voiced (U+FF9F) sound mark.
+func isHalfwidthSoundMark(r rune) bool {
+	return r == 0xFF9E || r == 0xFF9F
+}
+
 // WidthMany measures multiple strings and returns per-string widths.
 func (t *Text) WidthMany(strings []string) []float64 {
 	out := make([]float64, len(strings))
@@ -221,11 +516,97 @@ func (t *Text) WidthMany(strings []string) []float64 {
 	return out
 }
 
+// WidthPalette holds precomputed widths for a fixed set of strings.
+// It is read-only after creation and safe for concurrent use.
+type WidthPalette struct {
+	t      *Text
+	widths map[string]float64
+}
+
+// Palette measures strings once and returns a WidthPalette for constant-time
+// lookups. Use it for labels that are redrawn often, such as menu items or
+// status words. The palette is an explicit cache: it never grows, and
+// strings outside it are measured on demand by WidthOrMeasure.
+//
+// Example:
+//
+//	labels := txt.Palette([]string{"Running", "Stopped", "失敗"})
+//	w, _ := labels.Width("失敗") // 4
+func (t *Text) Palette(strings []string) *WidthPalette {
+	widths := make(map[string]float64, len(strings))
+	for _, s := range strings {
+		if _, ok := widths[s]; !ok {
+			widths[s] = t.Width(s)
+		}
+	}
+	return &WidthPalette{t: t, widths: widths}
+}
+
+// Width returns the precomputed width of s and whether s is in the palette.
+func (p *WidthPalette) Width(s string) (float64, bool) {
+	w, ok := p.widths[s]
+	return w, ok
+}
+
+// WidthOrMeasure returns the precomputed width of s, measuring it with the
+// palette's Text if s is not in the palette.
+func (p *WidthPalette) WidthOrMeasure(s string) float64 {
+	if w, ok := p.widths[s]; ok {
+		return w
+	}
+	return p.t.Width(s)
+}
+
+// validFlagRegions lists the region codes of the RGI emoji flag sequences
+// (UTS #51, emoji-sequences.txt).
+const validFlagRegions = "" +
+	"AC AD AE AF AG AI AL AM AO AQ AR AS AT AU AW AX AZ " +
+	"BA BB BD BE BF BG BH BI BJ BL BM BN BO BQ BR BS BT BV BW BY BZ " +
+	"CA CC CD CF CG CH CI CK CL CM CN CO CP CR CU CV CW CX CY CZ " +
+	"DE DG DJ DK DM DO DZ EA EC EE EG EH ER ES ET EU FI FJ FK FM FO FR " +
+	"GA GB GD GE GF GG GH GI GL GM GN GP GQ GR GS GT GU GW GY " +
+	"HK HM HN HR HT HU IC ID IE IL IM IN IO IQ IR IS IT JE JM JO JP " +
+	"KE KG KH KI KM KN KP KR KW KY KZ LA LB LC LI LK LR LS LT LU LV LY " +
+	"MA MC MD ME MF MG MH MK ML MM MN MO MP MQ MR MS MT MU MV MW MX MY MZ " +
+	"NA NC NE NF NG NI NL NO NP NR NU NZ OM PA PE PF PG PH PK PL PM PN PR PS PT PW PY " +
+	"QA RE RO RS RU RW SA SB SC SD SE SG SH SI SJ SK SL SM SN SO SR SS ST SV SX SY SZ " +
+	"TA TC TD TF TG TH TJ TK TL TM TN TO TR TT TV TW TZ UA UG UM UN US UY UZ " +
+	"VA VC VE VG VI VN VU WF WS XK YE YT ZA ZM ZW"
+
+// IsValidFlagSequence reports whether the regional indicator symbols a and b
+// form a recommended (RGI) emoji flag, such as 🇺🇸 (U+1F1FA U+1F1F8).
+//
+// Terminals draw a valid flag as one 2-cell glyph, but an unknown pair like
+// 🇿🇿 falls back to two separate letter glyphs, so Width measures it as 4.
+//
+// Example:
+//
+//	text.IsValidFlagSequence('\U0001F1FA', '\U0001F1F8') // true (US)
+//	text.IsValidFlagSequence('\U0001F1FF', '\U0001F1FF') // false (ZZ)
+func IsValidFlagSequence(a, b rune) bool {
+	if !uts51.IsRegionalIndicator(a) || !uts51.IsRegionalIndicator(b) {
+		return false
+	}
+	code := string([]rune{'A' + a - uts51.RegionalIndicatorBase, 'A' + b - uts51.RegionalIndicatorBase})
+	for i := 0; i+2 <= len(validFlagRegions); i += 3 {
+		if validFlagRegions[i:i+2] == code {
+			return true
+		}
+	}
+	return false
+}
+
 func emojiClusterWidth(runes []rune) (int, bool) {
 	if len(runes) == 0 {
 		return 0, false
 	}
 
+	// A lone character that defaults to text presentation, such as a digit
+	// or "©", is ordinary text measured by the MeasureFunc.
+	if len(runes) == 1 && !uts51.HasEmojiPresentation(runes[0]) {
+		return 0, false
+	}
+
 	const (
 		variationSelector15      = rune(0xFE0E)
 		variationSelector16      = rune(0xFE0F)
@@ -268,9 +649,19 @@ func emojiClusterWidth(runes []rune) (int, bool) {
 		return 0, false
 	}
 
-	// Flag sequences (regional indicator pairs) are 2 cells.
+	// Flag sequences (regional indicator pairs) are 2 cells. A pair that is
+	// not a known flag is drawn as two separate letter glyphs.
 	if regionalCount >= 2 {
-		return 2, true
+		var regionals []rune
+		for _, r := range runes {
+			if isRegional(r) {
+				regionals = append(regionals, r)
+			}
+		}
+		if len(regionals) == 2 && IsValidFlagSequence(regionals[0], regionals[1]) {
+			return 2, true
+		}
+		return 2 * regionalCount, true
 	}
 
 	// Keycap sequences render as emoji.
@@ -322,6 +713,39 @@ func (t *Text) WidthRange(s string, start, end int) float64 {
 	return t.Width(string(runes[start:end]))
 }
 
+// WidthRangeGraphemes is like WidthRange, but first widens the range to
+// whole grapheme clusters: start moves back to the beginning of the cluster
+// it falls in, and end moves forward to the end of its cluster. Use it when
+// the range is sliced out for rendering, so a combining mark or emoji
+// modifier is never separated from its base.
+//
+// Example:
+//
+//	txt := text.NewTerminal()
+//	s := "cafe\u0301!"                    // "café!" with a decomposed é
+//	txt.WidthRange(s, 0, 4)             // 4.0 ("cafe", the mark is cut off)
+//	txt.WidthRangeGraphemes(s, 0, 4)    // 4.0 ("café", runes 0–5)
+//	txt.WidthRangeGraphemes(s, 4, 5)    // 1.0 ("é", not just the mark)
+func (t *Text) WidthRangeGraphemes(s string, start, end int) float64 {
+	if start >= end {
+		return 0
+	}
+
+	width := 0.0
+	pos := 0
+	for _, g := range t.Graphemes(s) {
+		next := pos + utf8.RuneCountInString(g)
+		if next > start && pos < end {
+			width += t.graphemeWidth(g)
+		}
+		if next >= end {
+			break
+		}
+		pos = next
+	}
+	return width
+}
+
 // ═══════════════════════════════════════════════════════════════
 //  Pre-configured Measure Functions
 // ═══════════════════════════════════════════════════════════════
@@ -331,11 +755,15 @@ func (t *Text) WidthRange(s string, start, end int) float64 {
 // Returns:
 //   - 2 for wide characters (CJK ideographs, fullwidth, emoji)
 //   - 1 for narrow characters (ASCII, halfwidth)
-//   - 0 for zero-width characters (combining marks, ZWJ, variation selectors, emoji modifiers)
+//   - 0 for zero-width characters (combining marks, ZWJ, ZWNJ, variation selectors, emoji modifiers)
 //
 // Uses UAX #11 with ContextNarrow (ambiguous characters treated as narrow).
 // UTS #51 takes precedence for emoji characters.
 func TerminalMeasure(r rune) float64 {
+	if isZeroWidthFormat(r) || isCombiningMark(r) {
+		return 0
+	}
+
 	// Check if this is an emoji character (has emoji properties)
 	// UTS #51 takes precedence over UAX #11 for emoji
 	if uts51.IsEmoji(r) || uts51.IsEmojiComponent(r) {
@@ -351,6 +779,10 @@ func TerminalMeasure(r rune) float64 {
 // Same as TerminalMeasure but treats ambiguous characters as wide (2 cells).
 // Use this for terminals with East Asian locales (Chinese, Japanese, Korean).
 func TerminalMeasureEastAsian(r rune) float64 {
+	if isZeroWidthFormat(r) || isCombiningMark(r) {
+		return 0
+	}
+
 	// Check if this is an emoji character (has emoji properties)
 	// UTS #51 takes precedence over UAX #11 for emoji
 	if uts51.IsEmoji(r) || uts51.IsEmojiComponent(r) {
@@ -374,14 +806,38 @@ type WrapOptions struct {
 	MaxWidth float64
 
 	// BreakWords allows breaking in the middle of words if necessary.
+	// Lines still break at UAX #14 line break opportunities; only a word
+	// too wide for a line of its own is split, at grapheme boundaries.
 	// If false, only breaks at UAX #14 line break opportunities.
 	BreakWords bool
 
 	// PreserveNewlines keeps existing newline characters as line breaks.
+	// "\n", "\r\n" and a lone "\r" each count as one break.
 	PreserveNewlines bool
+
+	// TabSize, if set, expands tabs to tab stops as ExpandTabs does. Tab
+	// stops are counted from the start of each wrapped line, so columns
+	// line up within the wrapped output. Line.Content holds the expanded
+	// text; Start/End still index the input.
+	TabSize *TabSize
+
+	// SoftHyphens renders U+00AD SOFT HYPHEN the way a browser does: it is
+	// invisible and has no width, except where a line breaks after it,
+	// where the line ends with a visible "-" whose width counts against
+	// MaxWidth. Soft hyphens are removed from Line.Content; Start/End
+	// still index the input. By default a soft hyphen is kept and
+	// measured like any other character.
+	SoftHyphens bool
 }
 
 // Line represents a wrapped line of text.
+//
+// Start and End are rune indices (not byte offsets) into the text the
+// wrapping method laid out: the input for Wrap and most wrappers, or the
+// processed text for methods that rewrite it first, such as WrapCSS and
+// TransformAndWrap. The streaming LineScanner is the one exception and
+// reports byte offsets into the stream. Use RuneIndexToByte to slice the
+// text by a line's range.
 type Line struct {
 	// Content is the text content of the line.
 	Content string
@@ -396,8 +852,25 @@ type Line struct {
 
 	// End is the rune index in the original text where this line ends.
 	End int
+
+	// BreakType records how the line ended.
+	BreakType BreakType
 }
 
+// BreakType describes the break that ends a wrapped line.
+type BreakType int
+
+const (
+	// BreakNone means no break follows the line (it ends the text).
+	BreakNone BreakType = iota
+
+	// BreakSoft means the line ended at a soft wrap opportunity chosen by the wrapper.
+	BreakSoft
+
+	// BreakHard means the line ended at a forced break (newline) in the source text.
+	BreakHard
+)
+
 // Wrap breaks text into lines that fit within maxWidth.
 //
 // Uses UAX #14 for proper line break opportunities and UAX #29 to avoid
@@ -416,21 +889,40 @@ type Line struct {
 //	// Hello 世界!
 //	// This is a test.
 func (t *Text) Wrap(text string, opts WrapOptions) []Line {
+	text = t.normalizeInput(text)
 	if opts.MaxWidth <= 0 {
-		return []Line{{Content: text, Width: t.Width(text), Start: 0, End: len([]rune(text))}}
+		content := text
+		if opts.SoftHyphens {
+			content = strings.ReplaceAll(content, softHyphen, "")
+		}
+		if opts.TabSize != nil {
+			content = t.ExpandTabs(content, *opts.TabSize)
+		}
+		return []Line{{Content: content, Width: t.Width(content), Start: 0, End: len([]rune(text))}}
 	}
 
-	if !opts.PreserveNewlines {
-		return t.wrapSegment(text, opts, 0)
+	return wrapParagraphs(text, opts.PreserveNewlines, func(part string, baseRuneOffset int) []Line {
+		return t.wrapSegment(part, opts, baseRuneOffset)
+	})
+}
+
+// wrapParagraphs wraps text with wrapSegment, which returns lines whose
+// Start/End are offset by baseRuneOffset. With preserveNewlines, each part
+// between line breaks is wrapped separately, its last line ends with
+// BreakHard, and an empty part becomes an empty line. "\n", "\r\n" and a
+// lone "\r" each count as one break.
+func wrapParagraphs(text string, preserveNewlines bool, wrapSegment func(part string, baseRuneOffset int) []Line) []Line {
+	if !preserveNewlines {
+		return wrapSegment(text, 0)
 	}
 
-	parts := strings.Split(text, "\n")
+	parts, breakRunes := splitLineBreaks(text)
 	lines := make([]Line, 0, len(parts))
 	runeOffset := 0
 	hasNewline := len(parts) > 1
 
 	for i, part := range parts {
-		partLines := t.wrapSegment(part, opts, runeOffset)
+		partLines := wrapSegment(part, runeOffset)
 		if len(partLines) == 0 && hasNewline {
 			lines = append(lines, Line{
 				Content: "",
@@ -441,126 +933,184 @@ func (t *Text) Wrap(text string, opts WrapOptions) []Line {
 		} else {
 			lines = append(lines, partLines...)
 		}
+		if i < len(parts)-1 && len(lines) > 0 {
+			lines[len(lines)-1].BreakType = BreakHard
+		}
 
 		runeOffset += len([]rune(part))
 		if i < len(parts)-1 {
-			runeOffset++ // Account for the newline rune that was removed by Split.
+			runeOffset += breakRunes[i] // Account for the line break removed by the split.
 		}
 	}
 
 	return lines
 }
 
+// splitLineBreaks splits text at "\n", "\r\n" and lone "\r" line breaks.
+// breakRunes[i] is the length in runes of the break after parts[i].
+func splitLineBreaks(text string) (parts []string, breakRunes []int) {
+	for {
+		i := strings.IndexAny(text, "\r\n")
+		if i < 0 {
+			return append(parts, text), breakRunes
+		}
+		n := 1
+		if text[i] == '\r' && i+1 < len(text) && text[i+1] == '\n' {
+			n = 2
+		}
+		parts = append(parts, text[:i])
+		breakRunes = append(breakRunes, n)
+		text = text[i+n:]
+	}
+}
+
+// WrapBytes wraps UTF-8 bytes into lines.
+// A leading UTF-8 byte order mark is ignored; Start/End are rune indices
+// into the text after the BOM. Use DecodeBOM first for UTF-16 input.
+func (t *Text) WrapBytes(b []byte, opts WrapOptions) []Line {
+	return t.Wrap(string(StripBOM(b)), opts)
+}
+
 func (t *Text) wrapSegment(text string, opts WrapOptions, baseRuneOffset int) []Line {
 	if text == "" {
 		return nil
 	}
+	return t.wrapByBreakOpportunities(text, opts, baseRuneOffset)
+}
+
+// wrapByBreakOpportunities fills lines greedily with the segments between
+// UAX #14 break opportunities. With breakWords, a segment that is too wide
+// for a line of its own (ignoring trailing white space, which hangs) is
+// split at grapheme boundaries, and its last piece starts the next line
+// like any other segment. With opts.TabSize, tabs are expanded from the
+// column they land on in their line; with opts.SoftHyphens, lines that
+// break after a soft hyphen end with "-".
+func (t *Text) wrapByBreakOpportunities(text string, opts WrapOptions, baseRuneOffset int) []Line {
+	return t.fillLines(text, t.lineBreakOpportunities(text), opts, baseRuneOffset)
+}
+
+// lineBreakOpportunities returns the byte offsets of the UAX #14 break
+// opportunities in text, starting with 0 and ending with len(text).
+func (t *Text) lineBreakOpportunities(text string) []int {
+	breakPoints := uax14.FindLineBreakOpportunities(text, t.config.HyphenationMode)
+	if len(breakPoints) < 2 {
+		breakPoints = []int{0, len(text)}
+	}
+	return breakPoints
+}
 
-	if opts.BreakWords {
-		return t.wrapByGrapheme(text, opts.MaxWidth, baseRuneOffset)
+// lineLayout returns source, the text of a line or a prefix of one, as
+// Wrap lays it out in Line.Content: without soft hyphens with
+// opts.SoftHyphens, and with tabs expanded from the start of the line with
+// opts.TabSize. The hyphen added where a line breaks after a soft hyphen is
+// not included. len(lineLayout(prefix)) is the Content byte offset that
+// corresponds to the end of prefix.
+func (t *Text) lineLayout(source string, opts WrapOptions) string {
+	if opts.SoftHyphens && strings.Contains(source, softHyphen) {
+		source = strings.ReplaceAll(source, softHyphen, "")
+	}
+	if opts.TabSize != nil && strings.Contains(source, "\t") {
+		source, _ = t.expandTabsFrom(source, *opts.TabSize, 0)
 	}
-	return t.wrapByBreakOpportunities(text, opts.MaxWidth, baseRuneOffset)
+	return source
 }
 
-func (t *Text) wrapByGrapheme(text string, maxWidth float64, baseRuneOffset int) []Line {
-	graphemes := uax29.Graphemes(text)
+// fillLines is the greedy fill behind wrapByBreakOpportunities. breakPoints
+// are byte offsets into text, starting with 0; they need not be the break
+// opportunities of text on its own, so ReWrap can resume a paragraph
+// part-way through with the opportunities found in all of it.
+func (t *Text) fillLines(text string, breakPoints []int, opts WrapOptions, baseRuneOffset int) []Line {
+	maxWidth := opts.MaxWidth
 	lines := make([]Line, 0)
 
 	currentLine := ""
 	currentWidth := 0.0
 	currentStart := 0
 	currentRuneLen := 0
+	currentHyphen := false // The line ends after a soft hyphen
 
-	for _, g := range graphemes {
-		gWidth := t.Width(g)
-		gRuneLen := len([]rune(g))
-
-		if currentWidth+gWidth > maxWidth && currentWidth > 0 {
-			lines = append(lines, Line{
-				Content: currentLine,
-				Width:   currentWidth,
-				Start:   baseRuneOffset + currentStart,
-				End:     baseRuneOffset + currentStart + currentRuneLen,
-			})
-			currentStart += currentRuneLen
-			currentLine = g
-			currentWidth = gWidth
-			currentRuneLen = gRuneLen
-			continue
-		}
-
-		currentLine += g
-		currentWidth += gWidth
-		currentRuneLen += gRuneLen
+	hyphenWidth := 0.0
+	if opts.SoftHyphens {
+		hyphenWidth = t.Width("-")
 	}
 
-	if currentLine != "" {
+	flush := func() {
+		if currentHyphen {
+			currentLine += "-"
+			currentWidth += hyphenWidth
+		}
 		lines = append(lines, Line{
-			Content: currentLine,
-			Width:   currentWidth,
-			Start:   baseRuneOffset + currentStart,
-			End:     baseRuneOffset + currentStart + currentRuneLen,
+			Content:   currentLine,
+			Width:     currentWidth,
+			Start:     baseRuneOffset + currentStart,
+			End:       baseRuneOffset + currentStart + currentRuneLen,
+			BreakType: BreakSoft,
 		})
+		currentStart += currentRuneLen
+		currentLine = ""
+		currentWidth = 0
+		currentRuneLen = 0
+		currentHyphen = false
 	}
 
-	return lines
-}
-
-func (t *Text) wrapByBreakOpportunities(text string, maxWidth float64, baseRuneOffset int) []Line {
-	breakPoints := uax14.FindLineBreakOpportunities(text, t.config.HyphenationMode)
-	if len(breakPoints) < 2 {
-		return []Line{{
-			Content: text,
-			Width:   t.Width(text),
-			Start:   baseRuneOffset,
-			End:     baseRuneOffset + len([]rune(text)),
-		}}
+	// measure returns s as laid out at column, with its width
+	measure := func(s string, column float64) (string, float64) {
+		if opts.SoftHyphens {
+			s = strings.ReplaceAll(s, softHyphen, "")
+		}
+		if opts.TabSize == nil || !strings.Contains(s, "\t") {
+			return s, t.Width(s)
+		}
+		expanded, end := t.expandTabsFrom(s, *opts.TabSize, column)
+		return expanded, end - column
 	}
 
-	lines := make([]Line, 0)
-
-	currentLine := ""
-	currentWidth := 0.0
-	currentStart := 0
-	currentRuneLen := 0
-
 	for i := 1; i < len(breakPoints); i++ {
 		segment := text[breakPoints[i-1]:breakPoints[i]]
 		if segment == "" {
 			continue
 		}
 
-		segmentWidth := t.Width(segment)
+		laidOut, segmentWidth := measure(segment, currentWidth)
 		segmentRuneLen := len([]rune(segment))
 
-		if currentLine == "" {
-			currentLine = segment
-			currentWidth = segmentWidth
-			currentRuneLen = segmentRuneLen
-			continue
+		// A line that may end after this segment's soft hyphen must leave
+		// room for the hyphen
+		endsWithHyphen := opts.SoftHyphens && strings.HasSuffix(segment, softHyphen)
+		needed := segmentWidth
+		if endsWithHyphen {
+			needed += hyphenWidth
 		}
 
-		if currentWidth+segmentWidth <= maxWidth {
-			currentLine += segment
-			currentWidth += segmentWidth
-			currentRuneLen += segmentRuneLen
-			continue
+		if currentRuneLen > 0 && currentWidth+needed > maxWidth {
+			flush()
+			laidOut, segmentWidth = measure(segment, 0)
 		}
 
-		lines = append(lines, Line{
-			Content: currentLine,
-			Width:   currentWidth,
-			Start:   baseRuneOffset + currentStart,
-			End:     baseRuneOffset + currentStart + currentRuneLen,
-		})
-		currentStart += currentRuneLen
+		if opts.BreakWords && t.Width(strings.TrimRightFunc(segment, unicode.IsSpace)) > maxWidth {
+			currentHyphen = false
+			for _, g := range uax29.Graphemes(segment) {
+				gLaidOut, gWidth := measure(g, currentWidth)
+				hangs := strings.TrimRightFunc(g, unicode.IsSpace) == ""
+				if currentRuneLen > 0 && currentWidth+gWidth > maxWidth && !hangs {
+					flush()
+					gLaidOut, gWidth = measure(g, 0)
+				}
+				currentLine += gLaidOut
+				currentWidth += gWidth
+				currentRuneLen += len([]rune(g))
+			}
+			currentHyphen = endsWithHyphen
+			continue
+		}
 
-		currentLine = segment
-		currentWidth = segmentWidth
-		currentRuneLen = segmentRuneLen
+		currentLine += laidOut
+		currentWidth += segmentWidth
+		currentRuneLen += segmentRuneLen
+		currentHyphen = endsWithHyphen
 	}
 
-	if currentLine != "" {
+	if currentRuneLen > 0 {
 		lines = append(lines, Line{
 			Content: currentLine,
 			Width:   currentWidth,
@@ -572,68 +1122,606 @@ func (t *Text) wrapByBreakOpportunities(text string, maxWidth float64, baseRuneO
 	return lines
 }
 
-// ═══════════════════════════════════════════════════════════════
-//  Truncation
-// ═══════════════════════════════════════════════════════════════
+// softHyphen is U+00AD SOFT HYPHEN, a break opportunity that shows a
+// hyphen only when a line breaks there.
+const softHyphen = "\u00AD"
 
-// TruncateOptions configures truncation behavior.
-type TruncateOptions struct {
-	// MaxWidth is the maximum width for the truncated text in abstract units.
-	// For terminals: character cells (e.g., 20 means 20 columns)
-	// For canvas: pixels (e.g., 200.0 means 200 pixels)
-	// Units are determined by the MeasureFunc configuration.
-	MaxWidth float64
+// WrapResult is the output of WrapDetailed: the lines Wrap returns plus
+// what happened while wrapping them.
+type WrapResult struct {
+	// Lines are the wrapped lines, as returned by Wrap.
+	Lines []Line
 
-	// Ellipsis is the string to append when truncating (default: "...").
-	Ellipsis string
+	// LineInfo describes each line; LineInfo[i] belongs to Lines[i].
+	LineInfo []LineInfo
 
-	// Strategy specifies where to truncate.
-	Strategy TruncateStrategy
-}
+	// Overflowed reports whether any line is wider than MaxWidth, which
+	// happens when a word (or, with BreakWords, a single grapheme) cannot
+	// be split to fit. Trailing white space hangs and never overflows.
+	Overflowed bool
 
-// TruncateStrategy specifies where to truncate text.
-type TruncateStrategy int
+	// MaxLineWidth is the width of the widest line.
+	MaxLineWidth float64
+}
 
-const (
-	// TruncateEnd truncates at the end: "Hello wo..."
-	TruncateEnd TruncateStrategy = iota
+// LineInfo describes how a wrapped line was produced.
+type LineInfo struct {
+	// Overflowed reports whether the line, without trailing white space,
+	// is wider than MaxWidth.
+	Overflowed bool
 
-	// TruncateMiddle truncates in the middle: "Hel...rld"
-	TruncateMiddle
+	// HadEmergencyBreak reports whether the line ends inside a word, at a
+	// grapheme boundary that is not a UAX #14 break opportunity. Only
+	// BreakWords produces such breaks.
+	HadEmergencyBreak bool
 
-	// TruncateStart truncates at the start: "...o world"
-	TruncateStart
-)
+	// EndedWithHyphen reports whether the line was soft-wrapped right after
+	// a hyphen: "-", U+2010 HYPHEN or U+00AD SOFT HYPHEN.
+	EndedWithHyphen bool
+}
 
-// Truncate shortens text to fit within maxWidth, adding an ellipsis.
-//
-// Uses UAX #29 to respect grapheme cluster boundaries, ensuring emoji
-// and combining marks are not broken.
+// WrapDetailed wraps text like Wrap and also reports overflow and break
+// metadata, so a UI can decide to shrink the font or show a scroll
+// indicator.
 //
 // Example:
 //
 //	txt := text.NewTerminal()
-//	short := txt.Truncate("Hello 世界!", text.TruncateOptions{
-//	    MaxWidth: 10,
-//	    Strategy: text.TruncateEnd,
-//	})
-//	fmt.Println(short)  // "Hello 世..."
-func (t *Text) Truncate(text string, opts TruncateOptions) string {
-	if opts.Ellipsis == "" {
-		opts.Ellipsis = "..."
+//	result := txt.WrapDetailed("see https://example.com/long/path", text.WrapOptions{MaxWidth: 10})
+//	if result.Overflowed {
+//	    // result.MaxLineWidth is 12: "example.com/" could not be broken
+//	}
+func (t *Text) WrapDetailed(text string, opts WrapOptions) WrapResult {
+	text = t.normalizeInput(text)
+	lines := t.Wrap(text, opts)
+	result := WrapResult{
+		Lines:    lines,
+		LineInfo: make([]LineInfo, len(lines)),
 	}
 
-	textWidth := t.Width(text)
-	if textWidth <= opts.MaxWidth {
-		return text
+	// Rune indices of the UAX #14 break opportunities
+	opportunities := make(map[int]bool)
+	runeIndex, byteIndex := 0, 0
+	for _, bp := range uax14.FindLineBreakOpportunities(text, t.config.HyphenationMode) {
+		runeIndex += utf8.RuneCountInString(text[byteIndex:bp])
+		byteIndex = bp
+		opportunities[runeIndex] = true
 	}
 
-	ellipsisWidth := t.Width(opts.Ellipsis)
-	if ellipsisWidth >= opts.MaxWidth {
-		return ""
-	}
+	for i, line := range lines {
+		info := &result.LineInfo[i]
+		result.MaxLineWidth = max(result.MaxLineWidth, line.Width)
 
-	targetWidth := opts.MaxWidth - ellipsisWidth
+		if opts.MaxWidth > 0 && line.Width > opts.MaxWidth && t.VisibleWidth(line.Content) > opts.MaxWidth {
+			info.Overflowed = true
+			result.Overflowed = true
+		}
+
+		if line.BreakType != BreakSoft {
+			continue
+		}
+		info.HadEmergencyBreak = !opportunities[line.End]
+
+		last, _ := utf8.DecodeLastRuneInString(line.Content)
+		info.EndedWithHyphen = last == '-' || last == '\u2010' || last == '\u00AD'
+	}
+
+	return result
+}
+
+// ═══════════════════════════════════════════════════════════════
+//  Incremental Wrapping
+// ═══════════════════════════════════════════════════════════════
+
+// ReWrap updates lines after an edit, for editors that re-wrap on every
+// keystroke. prev must be the result of Wrap with the same opts on the
+// text before the edit, and changedFrom the rune index of the first rune
+// that differs between the old and new text; the text before it must be
+// unchanged.
+//
+// Wrapping is greedy, so a line depends only on the text from its start
+// up to the first segment of the next line. ReWrap keeps every line that
+// ends before the line containing changedFrom, and re-wraps from the start
+// of that line's predecessor, which may now take the edited line's first
+// word. Break opportunities are still found in the whole paragraph, as
+// Wrap finds them, and if the predecessor starts inside a word that
+// BreakWords split, ReWrap goes back to the last line that starts at a
+// break opportunity. The result equals Wrap(text, opts), with Start/End
+// rune indices into the new text.
+//
+// With Config.NormalizeInput, changedFrom is still a rune index into text
+// as passed, while prev and the result index its NFC form, as Wrap does.
+//
+// Example:
+//
+//	lines := txt.Wrap(doc, opts)
+//	// The user types at rune 1200
+//	doc = doc[:i] + "x" + doc[i:] // i is the byte offset of rune 1200
+//	lines = txt.ReWrap(lines, doc, 1200, opts)
+func (t *Text) ReWrap(prev []Line, text string, changedFrom int, opts WrapOptions) []Line {
+	if normalized := t.normalizeInput(text); normalized != text {
+		offset := t.normalizedOffset(text, normalized, RuneIndexToByte(text, changedFrom))
+		changedFrom = utf8.RuneCountInString(normalized[:offset])
+		text = normalized
+	}
+	if opts.MaxWidth <= 0 || len(prev) == 0 {
+		return t.Wrap(text, opts)
+	}
+
+	// The first line touching the change; an edit at a line's End (such as
+	// typing at its end) belongs to that line
+	affected := len(prev) - 1
+	for i, line := range prev {
+		if line.End >= changedFrom {
+			affected = i
+			break
+		}
+	}
+	restart := affected - 1
+	if restart <= 0 {
+		return t.Wrap(text, opts)
+	}
+
+	// UAX #14 looks back past a line start (LB20.1, for one, keeps a
+	// word-initial hyphen with what follows), so the paragraph's break
+	// opportunities are found in all of it rather than from the restart.
+	start := prev[restart].Start
+	startByte := RuneIndexToByte(text, start)
+	paraStart, paraEnd := 0, len(text)
+	if opts.PreserveNewlines {
+		paraStart = strings.LastIndexAny(text[:startByte], "\r\n") + 1
+		if i := strings.IndexAny(text[startByte:], "\r\n"); i >= 0 {
+			paraEnd = startByte + i
+		}
+	}
+	breakPoints := t.lineBreakOpportunities(text[paraStart:paraEnd])
+
+	// A line that BreakWords started inside a segment cannot be resumed;
+	// the first line of the paragraph always starts at an opportunity.
+	from := sort.SearchInts(breakPoints, startByte-paraStart)
+	for from == len(breakPoints) || breakPoints[from] != startByte-paraStart {
+		restart--
+		if restart <= 0 {
+			return t.Wrap(text, opts)
+		}
+		start = prev[restart].Start
+		startByte = RuneIndexToByte(text, start)
+		from = sort.SearchInts(breakPoints, startByte-paraStart)
+	}
+
+	points := make([]int, len(breakPoints)-from)
+	for i, bp := range breakPoints[from:] {
+		points[i] = bp - (startByte - paraStart)
+	}
+	tail := t.fillLines(text[startByte:paraEnd], points, opts, start)
+	if len(tail) == 0 {
+		// An empty paragraph between two line breaks
+		tail = []Line{{Start: start, End: start}}
+	}
+
+	lines := make([]Line, 0, restart+len(tail))
+	lines = append(lines, prev[:restart]...)
+	lines = append(lines, tail...)
+	if paraEnd == len(text) {
+		return lines
+	}
+
+	// The rest of the text after the paragraph's line break, as
+	// wrapParagraphs would wrap it
+	lines[len(lines)-1].BreakType = BreakHard
+	breakLen := 1
+	if text[paraEnd] == '\r' && paraEnd+1 < len(text) && text[paraEnd+1] == '\n' {
+		breakLen = 2
+	}
+	restStart := start + utf8.RuneCountInString(text[startByte:paraEnd]) + breakLen
+	rest := text[paraEnd+breakLen:]
+	if rest == "" {
+		return append(lines, Line{Start: restStart, End: restStart})
+	}
+	for _, line := range t.Wrap(rest, opts) {
+		line.Start += restStart
+		line.End += restStart
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// ═══════════════════════════════════════════════════════════════
+//  Reusable Analysis
+// ═══════════════════════════════════════════════════════════════
+
+// Analysis holds the width-independent work of plain greedy wrapping: the
+// UAX #14 break opportunities of a text and the measured width of every
+// segment between them. Wrapping the same text at several widths with
+// WrapAt, as a responsive layout does, then measures and segments it only
+// once. It caches nothing finer than those segments: there are no grapheme
+// clusters or cumulative widths inside them, so WrapAt honours MaxWidth
+// only, not BreakWords (which splits inside a segment) or PreserveNewlines.
+// Only WrapAt uses it; WrapWithControls, WrapCSS, WrapWithPhrases and the
+// other wrappers still segment their input on every call.
+//
+// An Analysis is read-only after Analyze and safe for concurrent use.
+type Analysis struct {
+	text     string
+	segments []analysisSegment
+}
+
+// analysisSegment is the text between two adjacent break opportunities.
+type analysisSegment struct {
+	byteStart, byteEnd int
+	runeLen            int
+	width              float64
+}
+
+// Analyze segments and measures text for repeated wrapping with WrapAt.
+// With Config.NormalizeInput the text is normalized first, as Wrap does, so
+// line Content and offsets refer to the normalized text.
+//
+// Example:
+//
+//	txt := text.NewTerminal()
+//	a := txt.Analyze(paragraph)
+//	for _, width := range []float64{40, 60, 80} {
+//	    lines := a.WrapAt(width)
+//	    // ...
+//	}
+func (t *Text) Analyze(text string) *Analysis {
+	text = t.normalizeInput(text)
+	a := &Analysis{text: text}
+
+	breakPoints := t.lineBreakOpportunities(text)
+	a.segments = make([]analysisSegment, 0, len(breakPoints)-1)
+	for i := 1; i < len(breakPoints); i++ {
+		segment := text[breakPoints[i-1]:breakPoints[i]]
+		if segment == "" {
+			continue
+		}
+		a.segments = append(a.segments, analysisSegment{
+			byteStart: breakPoints[i-1],
+			byteEnd:   breakPoints[i],
+			runeLen:   utf8.RuneCountInString(segment),
+			width:     t.Width(segment),
+		})
+	}
+	return a
+}
+
+// WrapAt wraps the analyzed text at maxWidth. The result is the same as
+// Wrap(text, WrapOptions{MaxWidth: maxWidth}).
+func (a *Analysis) WrapAt(maxWidth float64) []Line {
+	if maxWidth <= 0 {
+		width := 0.0
+		for _, seg := range a.segments {
+			width += seg.width
+		}
+		return []Line{{Content: a.text, Width: width, Start: 0, End: utf8.RuneCountInString(a.text)}}
+	}
+	if len(a.segments) == 0 {
+		return nil
+	}
+
+	var lines []Line
+	first := a.segments[0]
+	lineWidth := first.width
+	lineStart, lineEnd := first.byteStart, first.byteEnd
+	runeStart, runeEnd := 0, first.runeLen
+
+	for _, seg := range a.segments[1:] {
+		if lineWidth+seg.width <= maxWidth {
+			lineWidth += seg.width
+			lineEnd = seg.byteEnd
+			runeEnd += seg.runeLen
+			continue
+		}
+
+		lines = append(lines, Line{
+			Content:   a.text[lineStart:lineEnd],
+			Width:     lineWidth,
+			Start:     runeStart,
+			End:       runeEnd,
+			BreakType: BreakSoft,
+		})
+		lineWidth = seg.width
+		lineStart, lineEnd = seg.byteStart, seg.byteEnd
+		runeStart, runeEnd = runeEnd, runeEnd+seg.runeLen
+	}
+
+	return append(lines, Line{
+		Content: a.text[lineStart:lineEnd],
+		Width:   lineWidth,
+		Start:   runeStart,
+		End:     runeEnd,
+	})
+}
+
+// WrapAtWidths wraps text at each of widths with one segmentation pass, so
+// a responsive layout can compare candidate widths (for example by line
+// count or raggedness) cheaply. The result is aligned with widths:
+// result[i] is what Wrap(text, WrapOptions{MaxWidth: widths[i]}) returns.
+//
+// Example:
+//
+//	txt := text.NewTerminal()
+//	widths := []float64{40, 60, 80}
+//	layouts := txt.WrapAtWidths(paragraph, widths)
+//	for i, lines := range layouts {
+//	    fmt.Println(widths[i], len(lines))
+//	}
+func (t *Text) WrapAtWidths(text string, widths []float64) [][]Line {
+	a := t.Analyze(text)
+	result := make([][]Line, len(widths))
+	for i, width := range widths {
+		result[i] = a.WrapAt(width)
+	}
+	return result
+}
+
+// ═══════════════════════════════════════════════════════════════
+//  Index Conversion
+// ═══════════════════════════════════════════════════════════════
+
+// RuneIndexToByte returns the byte offset of the rune at runeIdx in text.
+// An index at or past the end returns len(text), and a negative index
+// returns 0, so text[RuneIndexToByte(text, line.Start):RuneIndexToByte(text,
+// line.End)] is always a valid slice.
+//
+// Example:
+//
+//	text.RuneIndexToByte("café!", 4) // 5 ("é" is 2 bytes)
+func RuneIndexToByte(text string, runeIdx int) int {
+	if runeIdx <= 0 {
+		return 0
+	}
+	n := 0
+	for i := range text {
+		if n == runeIdx {
+			return i
+		}
+		n++
+	}
+	return len(text)
+}
+
+// ByteIndexToRune returns the index of the rune containing the byte at
+// byteIdx in text. An offset at or past the end returns the rune count, and
+// a negative offset returns 0. It is the inverse of RuneIndexToByte for
+// offsets at rune boundaries, such as those UAX #14 break points use.
+//
+// Example:
+//
+//	text.ByteIndexToRune("café!", 5) // 4
+func ByteIndexToRune(text string, byteIdx int) int {
+	if byteIdx <= 0 {
+		return 0
+	}
+	if byteIdx >= len(text) {
+		return utf8.RuneCountInString(text)
+	}
+	n := 0
+	for i := range text {
+		if i == byteIdx {
+			return n
+		}
+		if i > byteIdx {
+			return n - 1 // byteIdx is inside the previous rune
+		}
+		n++
+	}
+	return n - 1
+}
+
+// ═══════════════════════════════════════════════════════════════
+//  Wrapping Styled Text
+// ═══════════════════════════════════════════════════════════════
+
+// Span marks a styled range of text, such as a bold run, by byte offsets.
+type Span struct {
+	Start int // Byte offset of the first byte in the span
+	End   int // Byte offset just past the span
+	Style any // Caller-defined style, copied unchanged to clipped spans
+}
+
+// WrapWithSpans wraps text like Wrap and returns, for each line, the spans
+// that overlap it, clipped to the line and with offsets relative to the
+// start of the line's Content.
+//
+// A span crossing a line break is split into one span per line. Spans that
+// are empty after clipping are dropped, and spans keep their input order.
+//
+// With Config.NormalizeInput, span offsets index text as passed and are
+// mapped to its NFC form, which the lines' Content holds. Likewise, with
+// opts.TabSize and opts.SoftHyphens the clipped spans index Content as laid
+// out, with tabs expanded and soft hyphens removed; a span that reaches the
+// end of a line covers the hyphen added there.
+//
+// Example:
+//
+//	txt := text.NewTerminal()
+//	bold := text.Span{Start: 5, End: 14, Style: "bold"} // "bold text"
+//	lines, spans := txt.WrapWithSpans("Some bold text", []text.Span{bold}, text.WrapOptions{MaxWidth: 10})
+//	// lines:    "Some bold ", "text"
+//	// spans[0]: {Start: 5, End: 10, Style: "bold"}
+//	// spans[1]: {Start: 0, End: 4, Style: "bold"}
+func (t *Text) WrapWithSpans(text string, spans []Span, opts WrapOptions) ([]Line, [][]Span) {
+	if normalized := t.normalizeInput(text); normalized != text {
+		mapped := make([]Span, len(spans))
+		for i, span := range spans {
+			mapped[i] = Span{
+				Start: t.normalizedOffset(text, normalized, span.Start),
+				End:   t.normalizedOffset(text, normalized, span.End),
+				Style: span.Style,
+			}
+		}
+		text, spans = normalized, mapped
+	}
+
+	lines := t.Wrap(text, opts)
+	lineSpans := make([][]Span, len(lines))
+
+	byteAt := runeToByteCursor(text)
+	for i, line := range lines {
+		lineStart := byteAt(line.Start)
+		lineEnd := byteAt(line.End)
+
+		for _, span := range spans {
+			start := max(span.Start, lineStart)
+			end := min(span.End, lineEnd)
+			if start >= end {
+				continue
+			}
+			contentEnd := len(line.Content)
+			if end < lineEnd {
+				contentEnd = len(t.lineLayout(text[lineStart:end], opts))
+			}
+			lineSpans[i] = append(lineSpans[i], Span{
+				Start: len(t.lineLayout(text[lineStart:start], opts)),
+				End:   contentEnd,
+				Style: span.Style,
+			})
+		}
+	}
+
+	return lines, lineSpans
+}
+
+// ═══════════════════════════════════════════════════════════════
+//  Highlighting
+// ═══════════════════════════════════════════════════════════════
+
+// Segment is a piece of text produced by SplitAtRanges.
+type Segment struct {
+	Text    string  // The segment's text
+	Matched bool    // Whether the segment lies inside one of the ranges
+	Width   float64 // Display width of Text
+}
+
+// SplitAtRanges splits text into alternating unmatched and matched
+// segments, for highlighting search results. Ranges are [start, end) rune
+// indices; they may overlap or come in any order. A range that starts or
+// ends inside a grapheme cluster is widened to the whole cluster, so a
+// highlight never splits an emoji or a base character from its marks.
+//
+// The segments cover text exactly, and each one can be measured, styled or
+// passed to Truncate on its own.
+//
+// Example:
+//
+//	txt := text.NewTerminal()
+//	segs := txt.SplitAtRanges("Hello 世界", [][2]int{{6, 7}})
+//	// segs: {"Hello ", false, 6}, {"世", true, 2}, {"界", false, 2}
+func (t *Text) SplitAtRanges(text string, ranges [][2]int) []Segment {
+	if text == "" {
+		return nil
+	}
+
+	sorted := make([][2]int, 0, len(ranges))
+	for _, r := range ranges {
+		if r[0] < r[1] {
+			sorted = append(sorted, r)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i][0] < sorted[j][0] })
+
+	var segments []Segment
+	var current strings.Builder
+	matched := false
+	next := 0 // First range that may still overlap the current cluster
+	runeOffset := 0
+
+	for _, g := range uax29.Graphemes(text) {
+		gStart := runeOffset
+		runeOffset += utf8.RuneCountInString(g)
+
+		for next < len(sorted) && sorted[next][1] <= gStart {
+			next++
+		}
+		gMatched := next < len(sorted) && sorted[next][0] < runeOffset
+
+		if gMatched != matched && current.Len() > 0 {
+			segments = append(segments, Segment{Text: current.String(), Matched: matched, Width: t.Width(current.String())})
+			current.Reset()
+		}
+		matched = gMatched
+		current.WriteString(g)
+	}
+	segments = append(segments, Segment{Text: current.String(), Matched: matched, Width: t.Width(current.String())})
+
+	return segments
+}
+
+// ═══════════════════════════════════════════════════════════════
+//  Truncation
+// ═══════════════════════════════════════════════════════════════
+
+// TruncateOptions configures truncation behavior.
+type TruncateOptions struct {
+	// MaxWidth is the maximum width for the truncated text in abstract units.
+	// For terminals: character cells (e.g., 20 means 20 columns)
+	// For canvas: pixels (e.g., 200.0 means 200 pixels)
+	// Units are determined by the MeasureFunc configuration.
+	MaxWidth float64
+
+	// Ellipsis is the string to append when truncating (default: "...").
+	Ellipsis string
+
+	// Strategy specifies where to truncate.
+	Strategy TruncateStrategy
+
+	// PreserveANSI treats ANSI escape sequences (such as SGR colors,
+	// "\x1b[31m") as zero-width tokens that are never cut or dropped.
+	// Escape sequences from the removed part are kept, so styles stay
+	// balanced, and a reset ("\x1b[0m") is appended after the ellipsis if
+	// an SGR style would otherwise remain active.
+	PreserveANSI bool
+}
+
+// TruncateStrategy specifies where to truncate text.
+type TruncateStrategy int
+
+const (
+	// TruncateEnd truncates at the end: "Hello wo..."
+	TruncateEnd TruncateStrategy = iota
+
+	// TruncateMiddle truncates in the middle: "Hel...rld"
+	TruncateMiddle
+
+	// TruncateStart truncates at the start: "...o world"
+	TruncateStart
+)
+
+// Truncate shortens text to fit within maxWidth, adding an ellipsis.
+//
+// Uses UAX #29 to respect grapheme cluster boundaries, ensuring emoji
+// and combining marks are not broken.
+//
+// Example:
+//
+//	txt := text.NewTerminal()
+//	short := txt.Truncate("Hello 世界!", text.TruncateOptions{
+//	    MaxWidth: 10,
+//	    Strategy: text.TruncateEnd,
+//	})
+//	fmt.Println(short)  // "Hello 世..."
+func (t *Text) Truncate(text string, opts TruncateOptions) string {
+	if opts.Ellipsis == "" {
+		opts.Ellipsis = "..."
+	}
+
+	if opts.PreserveANSI {
+		return t.truncateANSI(text, opts)
+	}
+
+	textWidth := t.Width(text)
+	if textWidth <= opts.MaxWidth {
+		return text
+	}
+
+	ellipsisWidth := t.Width(opts.Ellipsis)
+	if ellipsisWidth >= opts.MaxWidth {
+		return ""
+	}
+
+	targetWidth := opts.MaxWidth - ellipsisWidth
 
 	// Use UAX #29 to respect grapheme boundaries
 	graphemes := uax29.Graphemes(text)
@@ -650,6 +1738,60 @@ func (t *Text) Truncate(text string, opts TruncateOptions) string {
 	}
 }
 
+// TruncateLines wraps text and keeps at most maxLines lines, ending the
+// last kept line with ellipsis when text was cut: the classic "show three
+// lines, then …" clamp. The last line's trailing white space is dropped
+// before the ellipsis, and the line is shortened at a grapheme boundary if
+// the ellipsis would not otherwise fit in opts.MaxWidth. Its End then
+// marks how much of text is still shown.
+//
+// If all of text fits in maxLines lines, the lines are returned as Wrap
+// returns them, without an ellipsis. An empty ellipsis defaults to "...",
+// as in Truncate.
+//
+// Example:
+//
+//	txt := text.NewTerminal()
+//	lines := txt.TruncateLines("one two three four five six", text.WrapOptions{MaxWidth: 10}, 2, "…")
+//	// "one two ", "three…"
+func (t *Text) TruncateLines(text string, opts WrapOptions, maxLines int, ellipsis string) []Line {
+	if maxLines <= 0 {
+		return nil
+	}
+	if ellipsis == "" {
+		ellipsis = "..."
+	}
+
+	text = t.normalizeInput(text)
+	lines := t.Wrap(text, opts)
+	if len(lines) <= maxLines {
+		return lines
+	}
+	lines = lines[:maxLines]
+
+	// Content may differ from the source with TabSize and SoftHyphens, so
+	// End is found by laying out the source again. A hyphen added at a
+	// soft hyphen break is dropped in favor of the ellipsis.
+	last := &lines[maxLines-1]
+	source := text[RuneIndexToByte(text, last.Start):RuneIndexToByte(text, last.End)]
+	content := t.lineLayout(source, opts)
+	content = strings.TrimRightFunc(content, unicode.IsSpace)
+	if ellipsisWidth := t.Width(ellipsis); opts.MaxWidth > 0 && t.Width(content)+ellipsisWidth > opts.MaxWidth {
+		content, _, _ = t.ClipToWidth(content, opts.MaxWidth-ellipsisWidth)
+		content = strings.TrimRightFunc(content, unicode.IsSpace)
+	}
+	end := 0
+	for end < len(source) && len(t.lineLayout(source[:end], opts)) < len(content) {
+		_, size := utf8.DecodeRuneInString(source[end:])
+		end += size
+	}
+	last.End = last.Start + utf8.RuneCountInString(source[:end])
+	last.Content = content + ellipsis
+	last.Width = t.Width(last.Content)
+
+	return lines
+}
+
 func (t *Text) truncateEnd(graphemes []string, targetWidth float64, ellipsis string) string {
 	result := ""
 	width := 0.0
@@ -719,6 +1861,97 @@ func (t *Text) truncateStart(graphemes []string, targetWidth float64, ellipsis s
 	return ellipsis + result
 }
 
+// truncateANSI truncates text containing ANSI escape sequences. Widths are
+// measured over graphemes only; every escape sequence is kept in place.
+func (t *Text) truncateANSI(text string, opts TruncateOptions) string {
+	type unit struct {
+		text   string
+		escape bool
+	}
+
+	var units []unit
+	var widths []float64
+	total := 0.0
+	for _, seg := range splitANSI(text) {
+		if seg.escape {
+			units = append(units, unit{text: seg.text, escape: true})
+			continue
+		}
+		for _, g := range uax29.Graphemes(seg.text) {
+			w := t.graphemeWidth(g)
+			units = append(units, unit{text: g})
+			widths = append(widths, w)
+			total += w
+		}
+	}
+
+	if total <= opts.MaxWidth {
+		return text
+	}
+
+	ellipsisWidth := t.Width(opts.Ellipsis)
+	if ellipsisWidth >= opts.MaxWidth {
+		return ""
+	}
+	targetWidth := opts.MaxWidth - ellipsisWidth
+
+	// Graphemes in [dropStart, dropEnd) are replaced by the ellipsis.
+	dropStart, dropEnd := 0, len(widths)
+	switch opts.Strategy {
+	case TruncateStart:
+		dropEnd = len(widths) - fitCount(widths, targetWidth, true)
+	case TruncateMiddle:
+		leftWidth := targetWidth / 2
+		dropStart = fitCount(widths, leftWidth, false)
+		dropEnd = len(widths) - fitCount(widths, targetWidth-leftWidth, true)
+	default:
+		dropStart = fitCount(widths, targetWidth, false)
+	}
+
+	var result strings.Builder
+	styled := false
+	gi := 0
+	for _, u := range units {
+		if u.escape {
+			result.WriteString(u.text)
+			if isSGR(u.text) {
+				styled = !isSGRReset(u.text)
+			}
+			continue
+		}
+
+		if gi == dropStart {
+			result.WriteString(opts.Ellipsis)
+		}
+		if gi < dropStart || gi >= dropEnd {
+			result.WriteString(u.text)
+		}
+		gi++
+	}
+	if styled {
+		result.WriteString(sgrReset)
+	}
+
+	return result.String()
+}
+
+// fitCount returns how many widths, taken from the start (or the end if
+// fromEnd), fit within maxWidth.
+func fitCount(widths []float64, maxWidth float64, fromEnd bool) int {
+	total := 0.0
+	for n := 0; n < len(widths); n++ {
+		i := n
+		if fromEnd {
+			i = len(widths) - 1 - n
+		}
+		if total+widths[i] > maxWidth {
+			return n
+		}
+		total += widths[i]
+	}
+	return len(widths)
+}
+
 // ═══════════════════════════════════════════════════════════════
 //  Direction
 // ═══════════════════════════════════════════════════════════════
@@ -794,6 +2027,257 @@ func (t *Text) Align(text string, width float64, align Alignment) string {
 	return t.AlignWithDirection(text, width, align, DirectionLTR, AlignLeft)
 }
 
+// AlignBlock aligns each line of a block, such as a TUI panel or ASCII-art
+// box, to a common width. If width <= 0, the width of the widest line is
+// used, so the block becomes a rectangle. Lines already at least width
+// wide are returned unchanged. With AlignJustify, lines that cannot be
+// stretched are left-aligned.
+//
+// Example:
+//
+//	txt := text.NewTerminal()
+//	block := txt.AlignBlock([]string{"Title", "世界", "a longer line"}, 0, text.AlignCenter)
+//	// Every line is 13 cells wide: "    Title    ", "    世界     ", "a longer line"
+func (t *Text) AlignBlock(lines []string, width float64, align Alignment) []string {
+	if width <= 0 {
+		for _, line := range lines {
+			width = max(width, t.Width(line))
+		}
+	}
+
+	aligned := make([]string, len(lines))
+	for i, line := range lines {
+		aligned[i] = t.Align(line, width, align)
+		if align == AlignJustify {
+			// Lines with no gaps to stretch, such as a single word, are
+			// left-aligned so the block stays rectangular
+			aligned[i] = t.Align(aligned[i], width, AlignLeft)
+		}
+	}
+	return aligned
+}
+
+// FitBlock places a block of lines in a width×height region, such as a TUI
+// panel: each line is aligned with AlignBlock and hAlign, then blank lines
+// of width spaces are added above and below according to vAlign so the
+// result is exactly height lines.
+//
+// vAlign reads as a vertical position: AlignLeft and AlignStart put the
+// block at the top, AlignCenter in the middle (any odd blank line goes
+// below), and AlignRight and AlignEnd at the bottom. A block taller than
+// height is cut to fit, keeping the lines nearest to that position.
+//
+// Example:
+//
+//	txt := text.NewTerminal()
+//	box := txt.FitBlock([]string{"Hi", "there"}, 7, 4, text.AlignCenter, text.AlignCenter)
+//	// "       ", "  Hi   ", " there ", "       "
+func (t *Text) FitBlock(lines []string, width, height float64, hAlign Alignment, vAlign Alignment) []string {
+	rows := int(height)
+	if rows <= 0 {
+		return nil
+	}
+
+	// Cut extra lines, or share out the blank lines, by vertical position
+	extra := rows - len(lines)
+	var above int
+	switch vAlign {
+	case AlignCenter:
+		above = extra / 2
+	case AlignRight, AlignEnd:
+		above = extra
+	}
+	if extra < 0 {
+		lines = lines[-above : -above+rows]
+		above = 0
+	}
+
+	blank := t.makePadding(width)
+	block := make([]string, 0, rows)
+	for range above {
+		block = append(block, blank)
+	}
+	block = append(block, t.AlignBlock(lines, width, hAlign)...)
+	for len(block) < rows {
+		block = append(block, blank)
+	}
+	return block
+}
+
+// Columnize lays out rows as a table with aligned columns, for terminal
+// listings. Each column is as wide as its widest cell (measured with Width,
+// so CJK and emoji columns line up), cells are padded with Align and the
+// column's alignment, and columns are separated by gap units of space.
+//
+// Rows may be ragged: missing cells are empty. Columns beyond aligns are
+// left-aligned; justified cells that cannot be stretched are left-aligned.
+//
+// Example:
+//
+//	txt := text.NewTerminal()
+//	table := txt.Columnize([][]string{
+//	    {"City", "Population"},
+//	    {"東京", "14M"},
+//	}, []text.Alignment{text.AlignLeft, text.AlignRight}, 2)
+//	// "City  Population"
+//	// "東京         14M"
+func (t *Text) Columnize(rows [][]string, aligns []Alignment, gap float64) []string {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	var widths []float64
+	for _, row := range rows {
+		for c, cell := range row {
+			if c == len(widths) {
+				widths = append(widths, 0)
+			}
+			widths[c] = max(widths[c], t.Width(cell))
+		}
+	}
+
+	sep := t.makePadding(gap)
+	out := make([]string, len(rows))
+	for r, row := range rows {
+		var line strings.Builder
+		for c, width := range widths {
+			if c > 0 {
+				line.WriteString(sep)
+			}
+			cell := ""
+			if c < len(row) {
+				cell = row[c]
+			}
+			line.WriteString(t.alignCell(cell, width, columnAlign(aligns, c)))
+		}
+		out[r] = line.String()
+	}
+	return out
+}
+
+// ColumnizeWrapped lays out rows as a table like Columnize, but wraps each
+// cell to its column's width in colWidths, so a row can take several
+// physical lines. Cells are top-aligned: shorter cells are padded with
+// blank lines to the height of the row's tallest cell. Newlines in a cell
+// are kept, and words wider than the column are broken. A column is
+// widened only when a single grapheme, such as a wide character in a
+// one-cell column, does not fit it.
+//
+// A column without a positive width in colWidths is not wrapped and is as
+// wide as its widest line, as in Columnize.
+//
+// Example:
+//
+//	txt := text.NewTerminal()
+//	table := txt.ColumnizeWrapped([][]string{
+//	    {"id", "alpha beta gamma"},
+//	}, []float64{2, 6}, nil, 1)
+//	// "id alpha "
+//	// "   beta  "
+//	// "   gamma "
+func (t *Text) ColumnizeWrapped(rows [][]string, colWidths []float64, aligns []Alignment, gap float64) []string {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	// Wrap every cell into its lines
+	numCols := len(colWidths)
+	for _, row := range rows {
+		numCols = max(numCols, len(row))
+	}
+	cells := make([][][]string, len(rows))
+	widths := make([]float64, numCols)
+	for r, row := range rows {
+		cells[r] = make([][]string, numCols)
+		for c := range numCols {
+			cell := ""
+			if c < len(row) {
+				cell = row[c]
+			}
+			cells[r][c] = t.wrapCell(cell, columnWidth(colWidths, c))
+			for _, line := range cells[r][c] {
+				widths[c] = max(widths[c], t.Width(line))
+			}
+		}
+	}
+	for c := range widths {
+		// A single grapheme wider than the column overflows it; widen the
+		// column so the rows stay aligned.
+		if w := columnWidth(colWidths, c); w > 0 {
+			widths[c] = max(widths[c], w)
+		}
+	}
+
+	sep := t.makePadding(gap)
+	var out []string
+	for r := range rows {
+		height := 1
+		for _, lines := range cells[r] {
+			height = max(height, len(lines))
+		}
+		for i := range height {
+			var line strings.Builder
+			for c, width := range widths {
+				if c > 0 {
+					line.WriteString(sep)
+				}
+				cellLine := ""
+				if i < len(cells[r][c]) {
+					cellLine = cells[r][c][i]
+				}
+				line.WriteString(t.alignCell(cellLine, width, columnAlign(aligns, c)))
+			}
+			out = append(out, line.String())
+		}
+	}
+	return out
+}
+
+// wrapCell wraps a table cell to width, without trailing white space so
+// the cell aligns by its visible text. A width <= 0 only splits at
+// newlines.
+func (t *Text) wrapCell(cell string, width float64) []string {
+	if width <= 0 {
+		lines := strings.Split(cell, "\n")
+		for i, line := range lines {
+			lines[i] = strings.TrimRightFunc(line, unicode.IsSpace)
+		}
+		return lines
+	}
+
+	lines := t.Wrap(cell, WrapOptions{MaxWidth: width, BreakWords: true, PreserveNewlines: true})
+	out := make([]string, 0, len(lines))
+	for _, line := range lines {
+		out = append(out, strings.TrimRightFunc(line.Content, unicode.IsSpace))
+	}
+	return out
+}
+
+// columnWidth returns the width of column c, 0 if unset.
+func columnWidth(colWidths []float64, c int) float64 {
+	if c < len(colWidths) {
+		return colWidths[c]
+	}
+	return 0
+}
+
+// alignCell pads cell to width like AlignBlock does for a line.
+func (t *Text) alignCell(cell string, width float64, align Alignment) string {
+	aligned := t.Align(cell, width, align)
+	if align == AlignJustify {
+		aligned = t.Align(aligned, width, AlignLeft)
+	}
+	return aligned
+}
+
+// columnAlign returns the alignment of column c, AlignLeft if unset.
+func columnAlign(aligns []Alignment, c int) Alignment {
+	if c < len(aligns) {
+		return aligns[c]
+	}
+	return AlignLeft
+}
+
 // AlignWithDirection pads text to a specific width with the specified alignment,
 // respecting text direction for flow-relative alignments (start/end/match-parent).
 //
@@ -801,9 +2285,13 @@ func (t *Text) Align(text string, width float64, align Alignment) string {
 //   - text: The text to align
 //   - width: The target width
 //   - align: The alignment mode
-//   - direction: Text direction (LTR, RTL, or Auto)
+//   - direction: Text direction (LTR, RTL, or Auto, resolved from the
+//     first strong character of text)
 //   - parentAlign: Parent's alignment (used for match-parent)
 //
+// Justified RTL text is anchored on the right: a single word, or any
+// padding that cannot be spread evenly, goes on the left.
+//
 // Example:
 //
 //	txt := text.NewTerminal()
@@ -819,6 +2307,7 @@ func (t *Text) AlignWithDirection(text string, width float64, align Alignment, d
 	padding := width - textWidth
 
 	// Resolve flow-relative alignments
+	direction = t.resolveDirection(text, direction)
 	resolvedAlign := t.resolveAlignment(align, direction, parentAlign)
 
 	switch resolvedAlign {
@@ -827,16 +2316,29 @@ func (t *Text) AlignWithDirection(text string, width float64, align Alignment, d
 	case AlignRight:
 		return t.makePadding(padding) + text
 	case AlignCenter:
-		leftPad := padding / 2
-		rightPad := padding - leftPad
-		return t.makePadding(leftPad) + text + t.makePadding(rightPad)
+		// The right side takes up what the left side's padding fell short by
+		leftPad := t.makePadding(padding / 2)
+		rightPad := t.makePadding(padding - t.Width(leftPad))
+		return leftPad + text + rightPad
 	case AlignJustify:
-		return t.justify(text, padding)
+		return t.justify(text, padding, direction)
 	default:
 		return text
 	}
 }
 
+// resolveDirection resolves DirectionAuto to the direction of the first
+// strong character in text (LTR if there is none).
+func (t *Text) resolveDirection(text string, direction Direction) Direction {
+	if direction != DirectionAuto {
+		return direction
+	}
+	if firstStrongDirection(text) == uax9.DirectionRTL {
+		return DirectionRTL
+	}
+	return DirectionLTR
+}
+
 // resolveAlignment resolves flow-relative alignments (start/end/match-parent) to physical alignments.
 func (t *Text) resolveAlignment(align Alignment, direction Direction, parentAlign Alignment) Alignment {
 	// Handle match-parent first
@@ -868,34 +2370,62 @@ func (t *Text) resolveAlignment(align Alignment, direction Direction, parentAlig
 	}
 }
 
-// makePadding creates padding of specified width using spaces.
+// makePadding creates padding of specified width using spaces, or
+// Config.PadFunc when set. Without PadFunc the result is the largest whole
+// number of spaces that fits, so it falls short of width by less than one
+// space.
 func (t *Text) makePadding(width float64) string {
 	if width <= 0 {
 		return ""
 	}
+	if t.config.PadFunc != nil {
+		return t.config.PadFunc(width)
+	}
 
 	spaceWidth := t.config.MeasureFunc(' ')
-	count := int(width / spaceWidth)
+	// The epsilon keeps 12.6/4.2 = 2.9999999999999996 from losing a space
+	count := int(width/spaceWidth + 1e-9)
 	return strings.Repeat(" ", count)
 }
 
-// justify distributes padding between words.
-func (t *Text) justify(text string, padding float64) string {
+// gapPaddings returns n paddings of about gapWidth each. Whatever one gap's
+// padding falls short by is carried into the next, so together they fall
+// short of n*gapWidth by less than one space rather than by up to n.
+func (t *Text) gapPaddings(n int, gapWidth float64) []string {
+	pads := make([]string, n)
+	filled := 0.0
+	for i := range pads {
+		pads[i] = t.makePadding(float64(i+1)*gapWidth - filled)
+		filled += t.Width(pads[i])
+	}
+	return pads
+}
+
+// justify distributes padding between words. In RTL text, whatever padding
+// is left over goes before the text so the first word stays on the right.
+func (t *Text) justify(text string, padding float64, direction Direction) string {
 	// Simple justification: distribute padding between words
 	words := strings.Fields(text)
 	if len(words) <= 1 {
+		if direction == DirectionRTL {
+			return t.makePadding(padding) + text
+		}
 		return text
 	}
 
 	gaps := len(words) - 1
 	extraSpacePerGap := padding / float64(gaps)
+	pads := t.gapPaddings(gaps, t.Width(" ")+extraSpacePerGap)
 
 	result := words[0]
 	for i := 1; i < len(words); i++ {
-		result += t.makePadding(t.Width(" ") + extraSpacePerGap)
+		result += pads[i-1]
 		result += words[i]
 	}
 
+	if direction == DirectionRTL {
+		result = t.makePadding(t.Width(text)+padding-t.Width(result)) + result
+	}
 	return result
 }
 
@@ -906,6 +2436,8 @@ func (t *Text) justify(text string, padding float64) string {
 // Reorder applies the bidirectional algorithm for display.
 //
 // Uses UAX #9 to properly reorder mixed LTR/RTL text (e.g., Latin + Arabic).
+// Brackets and other mirrored characters in RTL runs are replaced by their
+// mirror glyphs (see MirrorChar).
 //
 // Example:
 //
@@ -913,17 +2445,24 @@ func (t *Text) justify(text string, padding float64) string {
 //	display := txt.Reorder("Hello שלום world")
 //	fmt.Println(display)  // Properly reordered for display
 func (t *Text) Reorder(text string) string {
-	return uax9.Reorder(text, t.config.BaseDirection)
+	return reorderMirrored(text, t.config.BaseDirection)
 }
 
 // ReorderWithDirection applies bidirectional algorithm with explicit direction.
 func (t *Text) ReorderWithDirection(text string, dir uax9.Direction) string {
-	return uax9.Reorder(text, dir)
+	return reorderMirrored(text, dir)
 }
 
 // DetectDirection automatically detects paragraph direction.
+//
+// Only the first paragraph is examined: detection stops at U+2029
+// PARAGRAPH SEPARATOR, but continues across U+2028 LINE SEPARATOR. Use
+// ParagraphDirections to get the direction of every paragraph.
 func (t *Text) DetectDirection(text string) uax9.Direction {
-	return uax9.GetParagraphDirection(text)
+	if i := strings.IndexRune(text, '\u2029'); i >= 0 {
+		text = text[:i]
+	}
+	return firstStrongDirection(text)
 }
 
 // ═══════════════════════════════════════════════════════════════
@@ -944,17 +2483,17 @@ func (t *Text) DetectDirection(text string) uax9.Direction {
 //	graphemes := txt.Graphemes("Hello👋🏻")
 //	fmt.Println(len(graphemes))  // 6, not 7 (emoji+modifier is 1 grapheme)
 func (t *Text) Graphemes(text string) []string {
-	return uax29.Graphemes(text)
+	return uax29.Graphemes(t.normalizeInput(text))
 }
 
 // GraphemeCount returns the number of grapheme clusters.
 func (t *Text) GraphemeCount(text string) int {
-	return len(uax29.Graphemes(text))
+	return len(uax29.Graphemes(t.normalizeInput(text)))
 }
 
 // GraphemeAt returns the grapheme cluster at the specified index.
 func (t *Text) GraphemeAt(text string, index int) string {
-	graphemes := uax29.Graphemes(text)
+	graphemes := uax29.Graphemes(t.normalizeInput(text))
 	if index >= 0 && index < len(graphemes) {
 		return graphemes[index]
 	}
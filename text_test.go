@@ -1,7 +1,14 @@
 package text
 
 import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"strings"
+	"sync"
 	"testing"
+
+	"github.com/SCKelemen/units"
 )
 
 func TestWidth(t *testing.T) {
@@ -30,6 +37,109 @@ func TestWidth(t *testing.T) {
 	}
 }
 
+func TestWidth_TextPresentationUsesMeasureFunc(t *testing.T) {
+	// Digits, "#", "*" and "©" are emoji characters (keycap bases and
+	// text-default symbols), but alone they are text and must be measured
+	// with the MeasureFunc.
+	txt := New(Config{MeasureFunc: pixelMeasure})
+	if got := txt.Width("2024#©"); got != 45 {
+		t.Errorf("Width = %.1f, want 45.0 (6 × 7.5)", got)
+	}
+
+	// Emoji presentation and keycap sequences keep their cell widths.
+	if got := NewTerminal().Width("0#©"); got != 3 {
+		t.Errorf("terminal Width = %.1f, want 3.0", got)
+	}
+	if got := NewTerminal().Width("1\uFE0F\u20E3"); got != 2 {
+		t.Errorf("keycap Width = %.1f, want 2.0", got)
+	}
+}
+
+func TestWidth_MixedSkinToneZWJ(t *testing.T) {
+	// Woman (medium skin tone) + ZWJ + handshake + ZWJ + man (light skin tone):
+	// modifiers on two different bases inside one ZWJ cluster.
+	handshake := "👩🏽‍🤝‍👨🏻"
+
+	txt := NewTerminal()
+	if got := txt.GraphemeCount(handshake); got != 1 {
+		t.Fatalf("GraphemeCount = %d, want 1", got)
+	}
+	if got := txt.Width(handshake); got != 2 {
+		t.Errorf("Width = %.1f, want 2.0", got)
+	}
+	if got := NewTerminalEastAsian().Width(handshake); got != 2 {
+		t.Errorf("East Asian Width = %.1f, want 2.0", got)
+	}
+
+	split := New(Config{SplitZWJSequences: true})
+	if got := split.Width(handshake); got != 6 {
+		t.Errorf("SplitZWJSequences Width = %.1f, want 6.0 (three emoji)", got)
+	}
+
+	// The cluster must never be split, even when forced to break words.
+	for _, tt := range []*Text{txt, split} {
+		for _, opts := range []WrapOptions{{MaxWidth: 1}, {MaxWidth: 1, BreakWords: true}} {
+			lines := tt.Wrap("ab "+handshake+" cd", opts)
+			found := false
+			for _, line := range lines {
+				if strings.Contains(line.Content, handshake) {
+					found = true
+				} else if strings.ContainsAny(line.Content, "\u200D\U0001F91D") {
+					t.Errorf("line %q contains part of a split ZWJ sequence", line.Content)
+				}
+			}
+			if !found {
+				t.Errorf("Wrap(%+v) lost the intact ZWJ sequence: %+v", opts, lines)
+			}
+		}
+	}
+}
+
+func TestCacheWidths(t *testing.T) {
+	calls := 0
+	counting := func(r rune) float64 {
+		calls++
+		return TerminalMeasure(r)
+	}
+
+	cached := New(Config{MeasureFunc: counting, CacheWidths: true})
+	plain := NewTerminal()
+
+	inputs := []string{"Hello 世界", "Hello 世界", "👋🏻 café", "ｶﾞ±"}
+	for _, s := range inputs {
+		if got, want := cached.Width(s), plain.Width(s); got != want {
+			t.Errorf("cached Width(%q) = %.1f, want %.1f", s, got, want)
+		}
+	}
+
+	before := calls
+	cached.Width("Hello 世界")
+	if calls != before {
+		t.Errorf("MeasureFunc called %d times for already-cached runes", calls-before)
+	}
+}
+
+func TestCacheWidths_Concurrent(t *testing.T) {
+	txt := New(Config{CacheWidths: true})
+	text := "Hello 世界! 中文 and emoji 😀"
+	want := NewTerminal().Width(text)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				if got := txt.Width(text); got != want {
+					t.Errorf("Width = %.1f, want %.1f", got, want)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
 func TestTruncate(t *testing.T) {
 	txt := NewTerminal()
 
@@ -90,6 +200,157 @@ func TestTruncate(t *testing.T) {
 	}
 }
 
+func TestTruncateLines(t *testing.T) {
+	txt := NewTerminal()
+	fiveLines := "one two three four five six seven eight nine"
+	if n := len(txt.Wrap(fiveLines, WrapOptions{MaxWidth: 10})); n != 5 {
+		t.Fatalf("Wrap gives %d lines, want 5", n)
+	}
+
+	tests := []struct {
+		name     string
+		input    string
+		opts     WrapOptions
+		maxLines int
+		ellipsis string
+		want     []Line
+	}{
+		{
+			name:     "Five lines cut to two",
+			input:    fiveLines,
+			opts:     WrapOptions{MaxWidth: 10},
+			maxLines: 2,
+			ellipsis: "…",
+			want: []Line{
+				{Content: "one two ", Width: 8, Start: 0, End: 8, BreakType: BreakSoft},
+				{Content: "three…", Width: 6, Start: 8, End: 13, BreakType: BreakSoft},
+			},
+		},
+		{
+			name:     "Full last line is shortened",
+			input:    "aaaaaaaaa bbbbbbbbbb cc",
+			opts:     WrapOptions{MaxWidth: 10},
+			maxLines: 2,
+			want: []Line{
+				{Content: "aaaaaaaaa ", Width: 10, Start: 0, End: 10, BreakType: BreakSoft},
+				{Content: "bbbbbbb...", Width: 10, Start: 10, End: 17, BreakType: BreakSoft},
+			},
+		},
+		{
+			name:     "Whole text fits",
+			input:    "one two",
+			opts:     WrapOptions{MaxWidth: 10},
+			maxLines: 2,
+			ellipsis: "…",
+			want: []Line{
+				{Content: "one two", Width: 7, Start: 0, End: 7},
+			},
+		},
+		{
+			name:     "Tabs expanded",
+			input:    "a\tbb cc\tdd ee ff gg",
+			opts:     WrapOptions{MaxWidth: 9, TabSize: &TabSize{Value: 4}},
+			maxLines: 2,
+			ellipsis: "…",
+			want: []Line{
+				{Content: "a   bb ", Width: 7, Start: 0, End: 5, BreakType: BreakSoft},
+				{Content: "cc  dd…", Width: 7, Start: 5, End: 10, BreakType: BreakSoft},
+			},
+		},
+		{
+			name:     "Soft hyphen replaced by ellipsis",
+			input:    "fan\u00ADtas\u00ADtic word",
+			opts:     WrapOptions{MaxWidth: 5, SoftHyphens: true},
+			maxLines: 2,
+			ellipsis: "…",
+			want: []Line{
+				{Content: "fan-", Width: 4, Start: 0, End: 4, BreakType: BreakSoft},
+				{Content: "tas…", Width: 4, Start: 4, End: 7, BreakType: BreakSoft},
+			},
+		},
+		{
+			name:     "No lines",
+			input:    fiveLines,
+			opts:     WrapOptions{MaxWidth: 10},
+			maxLines: 0,
+			want:     nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := txt.TruncateLines(tt.input, tt.opts, tt.maxLines, tt.ellipsis)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TruncateLines()\ngot  %+v\nwant %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTruncate_PreserveANSI(t *testing.T) {
+	txt := NewTerminal()
+
+	tests := []struct {
+		name     string
+		text     string
+		maxWidth float64
+		strategy TruncateStrategy
+		want     string
+	}{
+		{
+			name:     "End keeps reset after ellipsis",
+			text:     "\x1b[31mHello world\x1b[0m",
+			maxWidth: 8,
+			want:     "\x1b[31mHello...\x1b[0m",
+		},
+		{
+			name:     "End adds missing reset",
+			text:     "\x1b[31mHello world",
+			maxWidth: 8,
+			want:     "\x1b[31mHello...\x1b[0m",
+		},
+		{
+			name:     "Start",
+			text:     "\x1b[31mHello world\x1b[0m",
+			maxWidth: 8,
+			strategy: TruncateStart,
+			want:     "\x1b[31m...world\x1b[0m",
+		},
+		{
+			name:     "Middle",
+			text:     "\x1b[31mHello world\x1b[0m",
+			maxWidth: 8,
+			strategy: TruncateMiddle,
+			want:     "\x1b[31mHe...ld\x1b[0m",
+		},
+		{
+			name:     "Escapes do not count toward width",
+			text:     "\x1b[1;31mHi\x1b[0m",
+			maxWidth: 2,
+			want:     "\x1b[1;31mHi\x1b[0m",
+		},
+		{
+			name:     "OSC hyperlink",
+			text:     "\x1b]8;;https://example.com\x07link text\x1b]8;;\x07",
+			maxWidth: 6,
+			want:     "\x1b]8;;https://example.com\x07lin...\x1b]8;;\x07",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := txt.Truncate(tt.text, TruncateOptions{
+				MaxWidth:     tt.maxWidth,
+				Strategy:     tt.strategy,
+				PreserveANSI: true,
+			})
+			if got != tt.want {
+				t.Errorf("Truncate(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestAlign(t *testing.T) {
 	txt := NewTerminal()
 
@@ -137,6 +398,326 @@ func TestAlign(t *testing.T) {
 	}
 }
 
+// pixelMeasure is a proportional font whose space is a fractional width.
+func pixelMeasure(r rune) float64 {
+	switch r {
+	case ' ':
+		return 4.2
+	case '\u200A': // HAIR SPACE
+		return 0.5
+	default:
+		return 7.5
+	}
+}
+
+func TestAlign_FractionalSpace(t *testing.T) {
+	txt := New(Config{MeasureFunc: pixelMeasure})
+
+	aligns := []struct {
+		name  string
+		align Alignment
+		text  string
+	}{
+		{"Left", AlignLeft, "Hello"},
+		{"Right", AlignRight, "Hello"},
+		{"Center", AlignCenter, "Hello"},
+		{"Justify", AlignJustify, "a b c d e"},
+	}
+
+	for _, a := range aligns {
+		for _, width := range []float64{60, 63.3, 100, 121.7} {
+			t.Run(fmt.Sprintf("%s/%.1f", a.name, width), func(t *testing.T) {
+				got := txt.Width(txt.Align(a.text, width, a.align))
+				if got > width || width-got >= 4.2 {
+					t.Errorf("Width(Align(%q, %.1f)) = %.2f, want within one space of %.1f",
+						a.text, width, got, width)
+				}
+			})
+		}
+	}
+}
+
+func TestAlign_PadFunc(t *testing.T) {
+	// Fill with spaces, then hair spaces for the remainder.
+	pad := func(width float64) string {
+		spaces := int(width / 4.2)
+		hair := int((width - float64(spaces)*4.2) / 0.5)
+		return strings.Repeat(" ", spaces) + strings.Repeat("\u200A", hair)
+	}
+	txt := New(Config{MeasureFunc: pixelMeasure, PadFunc: pad})
+
+	for _, align := range []Alignment{AlignLeft, AlignRight, AlignCenter} {
+		got := txt.Width(txt.Align("Hello", 63.3, align))
+		if got > 63.3 || 63.3-got >= 0.5 {
+			t.Errorf("align %d: width = %.2f, want within 0.5 of 63.3", align, got)
+		}
+	}
+}
+
+func TestAlignBlock(t *testing.T) {
+	txt := NewTerminal()
+
+	lines := []string{"Title", "世界", "👋 hi", ""}
+
+	for _, align := range []Alignment{AlignLeft, AlignCenter, AlignRight, AlignJustify} {
+		for _, width := range []float64{0, 12} {
+			t.Run(fmt.Sprintf("align %d width %.0f", align, width), func(t *testing.T) {
+				want := width
+				if want == 0 {
+					want = 5 // Widest line: "Title" and "👋 hi"
+				}
+				got := txt.AlignBlock(lines, width, align)
+				if len(got) != len(lines) {
+					t.Fatalf("got %d lines, want %d", len(got), len(lines))
+				}
+				for i, line := range got {
+					if w := txt.Width(line); w != want {
+						t.Errorf("line %d %q width = %.1f, want %.1f", i, line, w, want)
+					}
+				}
+			})
+		}
+	}
+
+	centered := txt.AlignBlock([]string{"ab", "世界世"}, 0, AlignCenter)
+	if centered[0] != "  ab  " || centered[1] != "世界世" {
+		t.Errorf("centered block = %q", centered)
+	}
+
+	// Lines wider than width are left as they are.
+	got := txt.AlignBlock([]string{"a very long line", "short"}, 8, AlignRight)
+	if got[0] != "a very long line" || got[1] != "   short" {
+		t.Errorf("AlignBlock with overflow = %q", got)
+	}
+}
+
+func TestFitBlock(t *testing.T) {
+	txt := NewTerminal()
+
+	tests := []struct {
+		name   string
+		lines  []string
+		width  float64
+		height float64
+		hAlign Alignment
+		vAlign Alignment
+		want   []string
+	}{
+		{
+			name:   "Centered in 6x5",
+			lines:  []string{"ab", "世界"},
+			width:  6,
+			height: 5,
+			hAlign: AlignCenter,
+			vAlign: AlignCenter,
+			want:   []string{"      ", "  ab  ", " 世界 ", "      ", "      "},
+		},
+		{
+			name:   "Top left",
+			lines:  []string{"ab"},
+			width:  4,
+			height: 3,
+			hAlign: AlignLeft,
+			vAlign: AlignStart,
+			want:   []string{"ab  ", "    ", "    "},
+		},
+		{
+			name:   "Bottom right",
+			lines:  []string{"ab"},
+			width:  4,
+			height: 3,
+			hAlign: AlignRight,
+			vAlign: AlignEnd,
+			want:   []string{"    ", "    ", "  ab"},
+		},
+		{
+			name:   "Too tall keeps top lines",
+			lines:  []string{"1", "2", "3", "4"},
+			width:  2,
+			height: 2,
+			hAlign: AlignLeft,
+			vAlign: AlignLeft,
+			want:   []string{"1 ", "2 "},
+		},
+		{
+			name:   "Too tall keeps middle lines",
+			lines:  []string{"1", "2", "3", "4"},
+			width:  2,
+			height: 2,
+			hAlign: AlignLeft,
+			vAlign: AlignCenter,
+			want:   []string{"2 ", "3 "},
+		},
+		{
+			name:   "Too tall keeps bottom lines",
+			lines:  []string{"1", "2", "3", "4"},
+			width:  2,
+			height: 2,
+			hAlign: AlignLeft,
+			vAlign: AlignRight,
+			want:   []string{"3 ", "4 "},
+		},
+		{
+			name:   "Zero height",
+			lines:  []string{"ab"},
+			width:  4,
+			height: 0,
+			want:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := txt.FitBlock(tt.lines, tt.width, tt.height, tt.hAlign, tt.vAlign)
+			if fmt.Sprintf("%q", got) != fmt.Sprintf("%q", tt.want) {
+				t.Errorf("FitBlock() = %q, want %q", got, tt.want)
+			}
+			for i, line := range got {
+				if w := txt.Width(line); w != tt.width {
+					t.Errorf("line %d %q width = %.1f, want %.1f", i, line, w, tt.width)
+				}
+			}
+		})
+	}
+}
+
+func TestColumnize(t *testing.T) {
+	txt := NewTerminal()
+
+	tests := []struct {
+		name   string
+		rows   [][]string
+		aligns []Alignment
+		gap    float64
+		want   []string
+	}{
+		{
+			name: "Mixed ASCII and CJK",
+			rows: [][]string{
+				{"Name", "City"},
+				{"Alice", "東京"},
+				{"李", "Paris"},
+			},
+			aligns: []Alignment{AlignLeft, AlignRight},
+			gap:    2,
+			want: []string{
+				"Name    City",
+				"Alice   東京",
+				"李     Paris",
+			},
+		},
+		{
+			name: "Ragged rows",
+			rows: [][]string{
+				{"a", "bb", "ccc"},
+				{"dd"},
+			},
+			aligns: []Alignment{AlignCenter},
+			gap:    1,
+			want: []string{
+				"a  bb ccc",
+				"dd       ",
+			},
+		},
+		{
+			name: "No rows",
+			rows: nil,
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := txt.Columnize(tt.rows, tt.aligns, tt.gap)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Columnize() =\n%q\nwant\n%q", got, tt.want)
+			}
+			for i, line := range got {
+				if w := txt.Width(line); w != txt.Width(tt.want[0]) {
+					t.Errorf("row %d width = %.1f, want %.1f", i, w, txt.Width(tt.want[0]))
+				}
+			}
+		})
+	}
+}
+
+func TestColumnizeWrapped(t *testing.T) {
+	txt := NewTerminal()
+
+	tests := []struct {
+		name      string
+		rows      [][]string
+		colWidths []float64
+		aligns    []Alignment
+		gap       float64
+		want      []string
+	}{
+		{
+			name: "Cell wraps to three lines",
+			rows: [][]string{
+				{"id", "alpha beta gamma"},
+				{"2", "delta"},
+			},
+			colWidths: []float64{2, 6},
+			aligns:    []Alignment{AlignRight, AlignLeft},
+			gap:       1,
+			want: []string{
+				"id alpha ",
+				"   beta  ",
+				"   gamma ",
+				" 2 delta ",
+			},
+		},
+		{
+			name: "Newlines and unwrapped column",
+			rows: [][]string{
+				{"a\nbb", "中文中文"},
+			},
+			colWidths: []float64{0, 4},
+			gap:       2,
+			want: []string{
+				"a   中文",
+				"bb  中文",
+			},
+		},
+		{
+			name: "Long word is broken",
+			rows: [][]string{
+				{"abcdefgh"},
+			},
+			colWidths: []float64{3},
+			want: []string{
+				"abc",
+				"def",
+				"gh ",
+			},
+		},
+		{
+			name: "Wide character widens a narrow column",
+			rows: [][]string{
+				{"中a", "x"},
+				{"b", "y"},
+			},
+			colWidths: []float64{1, 1},
+			gap:       1,
+			want: []string{
+				"中 x",
+				"a   ",
+				"b  y",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := txt.ColumnizeWrapped(tt.rows, tt.colWidths, tt.aligns, tt.gap)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ColumnizeWrapped() =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestWrap(t *testing.T) {
 	txt := NewTerminal()
 
@@ -217,6 +798,41 @@ func TestWrap_BreakWordsOption(t *testing.T) {
 	}
 }
 
+func TestWrap_BreakWordsPrefersBreakOpportunities(t *testing.T) {
+	txt := NewTerminal()
+
+	tests := []struct {
+		name           string
+		input          string
+		width          float64
+		breakWordsOnly bool
+		want           []string
+	}{
+		{"Words fit", "Hello world", 8, false, []string{"Hello ", "world"}},
+		{"Long word", "a supercalifragilistic b", 8, true, []string{"a ", "supercal", "ifragili", "stic b"}},
+		{"Trailing space hangs", "abcdefgh ijk", 8, false, []string{"abcdefgh ", "ijk"}},
+		{"CJK", "中文中文中文", 5, false, []string{"中文", "中文", "中文"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, breakWords := range []bool{false, true} {
+				if !breakWords && tt.breakWordsOnly {
+					continue
+				}
+				lines := txt.Wrap(tt.input, WrapOptions{MaxWidth: tt.width, BreakWords: breakWords})
+				got := make([]string, len(lines))
+				for i, line := range lines {
+					got[i] = line.Content
+				}
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("BreakWords=%v: got %q, want %q", breakWords, got, tt.want)
+				}
+			}
+		})
+	}
+}
+
 func TestWrap_PreserveNewlines(t *testing.T) {
 	txt := NewTerminal()
 	text := "a\n👨‍👩‍👧‍👦b"
@@ -237,23 +853,373 @@ func TestWrap_PreserveNewlines(t *testing.T) {
 	}
 }
 
-func TestWrap_RuneIndicesWithGrapheme(t *testing.T) {
+func TestWrap_PreserveNewlinesEmptyLines(t *testing.T) {
 	txt := NewTerminal()
-	text := "👨‍👩‍👧‍👦a"
 
-	lines := txt.Wrap(text, WrapOptions{
-		MaxWidth:   2,
-		BreakWords: true,
-	})
-	if len(lines) != 2 {
-		t.Fatalf("Wrap() returned %d lines, want 2", len(lines))
+	tests := []struct {
+		name  string
+		input string
+		opts  WrapOptions
+		want  []Line
+	}{
+		{
+			name:  "Blank line",
+			input: "a\n\nb",
+			opts:  WrapOptions{MaxWidth: 10, PreserveNewlines: true},
+			want: []Line{
+				{Content: "a", Width: 1, Start: 0, End: 1, BreakType: BreakHard},
+				{Content: "", Width: 0, Start: 2, End: 2, BreakType: BreakHard},
+				{Content: "b", Width: 1, Start: 3, End: 4},
+			},
+		},
+		{
+			name:  "Trailing newline",
+			input: "a\n",
+			opts:  WrapOptions{MaxWidth: 10, PreserveNewlines: true},
+			want: []Line{
+				{Content: "a", Width: 1, Start: 0, End: 1, BreakType: BreakHard},
+				{Content: "", Width: 0, Start: 2, End: 2},
+			},
+		},
+		{
+			name:  "Wrapped paragraph",
+			input: "ab cd\n\nef",
+			opts:  WrapOptions{MaxWidth: 3, PreserveNewlines: true},
+			want: []Line{
+				{Content: "ab ", Width: 3, Start: 0, End: 3, BreakType: BreakSoft},
+				{Content: "cd", Width: 2, Start: 3, End: 5, BreakType: BreakHard},
+				{Content: "", Width: 0, Start: 6, End: 6, BreakType: BreakHard},
+				{Content: "ef", Width: 2, Start: 7, End: 9},
+			},
+		},
+		{
+			name:  "CRLF and lone CR",
+			input: "a\r\n\r\nb\rc",
+			opts:  WrapOptions{MaxWidth: 10, PreserveNewlines: true},
+			want: []Line{
+				{Content: "a", Width: 1, Start: 0, End: 1, BreakType: BreakHard},
+				{Content: "", Width: 0, Start: 3, End: 3, BreakType: BreakHard},
+				{Content: "b", Width: 1, Start: 5, End: 6, BreakType: BreakHard},
+				{Content: "c", Width: 1, Start: 7, End: 8},
+			},
+		},
 	}
 
-	if lines[0].Content != "👨‍👩‍👧‍👦" || lines[0].Start != 0 || lines[0].End != 7 {
-		t.Fatalf("line 0 = %+v, want content=%q start=0 end=7", lines[0], "👨‍👩‍👧‍👦")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := txt.Wrap(tt.input, tt.opts)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Wrap(%q)\ngot  %+v\nwant %+v", tt.input, got, tt.want)
+			}
+		})
 	}
-	if lines[1].Content != "a" || lines[1].Start != 7 || lines[1].End != 8 {
-		t.Fatalf("line 1 = %+v, want content=%q start=7 end=8", lines[1], "a")
+}
+
+func TestWrap_TabSize(t *testing.T) {
+	txt := NewTerminal()
+	tabs := &TabSize{Value: 4}
+
+	tests := []struct {
+		name  string
+		input string
+		opts  WrapOptions
+		want  []Line
+	}{
+		{
+			name:  "Stops restart on each line",
+			input: "a\tb\tc d",
+			opts:  WrapOptions{MaxWidth: 6, TabSize: tabs},
+			want: []Line{
+				{Content: "a   ", Width: 4, Start: 0, End: 2, BreakType: BreakSoft},
+				{Content: "b   c ", Width: 6, Start: 2, End: 6, BreakType: BreakSoft},
+				{Content: "d", Width: 1, Start: 6, End: 7},
+			},
+		},
+		{
+			name:  "Aligned columns",
+			input: "x\tyy\tz",
+			opts:  WrapOptions{MaxWidth: 10, TabSize: tabs},
+			want: []Line{
+				{Content: "x   yy  z", Width: 9, Start: 0, End: 6},
+			},
+		},
+		{
+			name:  "No wrapping",
+			input: "ab\tc",
+			opts:  WrapOptions{TabSize: tabs},
+			want: []Line{
+				{Content: "ab  c", Width: 5, Start: 0, End: 4},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := txt.Wrap(tt.input, tt.opts)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Wrap(%q)\ngot  %+v\nwant %+v", tt.input, got, tt.want)
+			}
+			if n := txt.CountWrappedLines(tt.input, tt.opts); n != len(got) {
+				t.Errorf("CountWrappedLines = %d, want %d", n, len(got))
+			}
+		})
+	}
+}
+
+func TestWrap_SoftHyphens(t *testing.T) {
+	txt := NewTerminal()
+	word := "ex\u00ADam\u00ADple"
+
+	tests := []struct {
+		name  string
+		input string
+		opts  WrapOptions
+		want  []Line
+	}{
+		{
+			name:  "Narrow",
+			input: word,
+			opts:  WrapOptions{MaxWidth: 4, SoftHyphens: true},
+			want: []Line{
+				{Content: "ex-", Width: 3, Start: 0, End: 3, BreakType: BreakSoft},
+				{Content: "am-", Width: 3, Start: 3, End: 6, BreakType: BreakSoft},
+				{Content: "ple", Width: 3, Start: 6, End: 9},
+			},
+		},
+		{
+			name:  "Hyphen needs room",
+			input: word,
+			opts:  WrapOptions{MaxWidth: 6, SoftHyphens: true},
+			want: []Line{
+				{Content: "exam-", Width: 5, Start: 0, End: 6, BreakType: BreakSoft},
+				{Content: "ple", Width: 3, Start: 6, End: 9},
+			},
+		},
+		{
+			name:  "No break, soft hyphens invisible",
+			input: word,
+			opts:  WrapOptions{MaxWidth: 20, SoftHyphens: true},
+			want: []Line{
+				{Content: "example", Width: 7, Start: 0, End: 9},
+			},
+		},
+		{
+			name:  "Unwrapped",
+			input: word,
+			opts:  WrapOptions{SoftHyphens: true},
+			want: []Line{
+				{Content: "example", Width: 7, Start: 0, End: 9},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := txt.Wrap(tt.input, tt.opts)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Wrap(%q)\ngot  %+v\nwant %+v", tt.input, got, tt.want)
+			}
+			if n := txt.CountWrappedLines(tt.input, tt.opts); n != len(got) {
+				t.Errorf("CountWrappedLines = %d, want %d", n, len(got))
+			}
+		})
+	}
+
+	// Without the option a soft hyphen stays in the text
+	lines := txt.Wrap(word, WrapOptions{MaxWidth: 4})
+	if !strings.HasSuffix(lines[0].Content, "\u00AD") {
+		t.Errorf("without SoftHyphens, line 0 = %q, want the soft hyphen kept", lines[0].Content)
+	}
+}
+
+func TestWrap_NoBreakSpaces(t *testing.T) {
+	txt := NewTerminal()
+
+	tests := []struct {
+		name  string
+		input string
+		width float64
+		want  []string
+	}{
+		{"No-break space kept together", "go 10\u00A0km now", 6, []string{"go ", "10\u00A0km ", "now"}},
+		{"Narrow no-break space", "go 10\u202Fkm now", 6, []string{"go ", "10\u202Fkm ", "now"}},
+		{"Word joiner", "go 10\u2060km now", 5, []string{"go ", "10\u2060km ", "now"}},
+		{"Overflows rather than breaking", "10\u00A0km", 3, []string{"10\u00A0km"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines := txt.Wrap(tt.input, WrapOptions{MaxWidth: tt.width})
+			got := make([]string, len(lines))
+			for i, line := range lines {
+				got[i] = line.Content
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Wrap(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWrap_RuneIndicesWithGrapheme(t *testing.T) {
+	txt := NewTerminal()
+	text := "👨‍👩‍👧‍👦a"
+
+	lines := txt.Wrap(text, WrapOptions{
+		MaxWidth:   2,
+		BreakWords: true,
+	})
+	if len(lines) != 2 {
+		t.Fatalf("Wrap() returned %d lines, want 2", len(lines))
+	}
+
+	if lines[0].Content != "👨‍👩‍👧‍👦" || lines[0].Start != 0 || lines[0].End != 7 {
+		t.Fatalf("line 0 = %+v, want content=%q start=0 end=7", lines[0], "👨‍👩‍👧‍👦")
+	}
+	if lines[1].Content != "a" || lines[1].Start != 7 || lines[1].End != 8 {
+		t.Fatalf("line 1 = %+v, want content=%q start=7 end=8", lines[1], "a")
+	}
+}
+
+func TestWrapWithSpans(t *testing.T) {
+	txt := NewTerminal()
+
+	text := "Some bold text here"
+	bold := Span{Start: 5, End: 14, Style: "bold"} // "bold text"
+	lines, spans := txt.WrapWithSpans(text, []Span{bold}, WrapOptions{MaxWidth: 10})
+
+	wantLines := []string{"Some bold ", "text here"}
+	if len(lines) != len(wantLines) {
+		t.Fatalf("got %d lines, want %d", len(lines), len(wantLines))
+	}
+	for i, want := range wantLines {
+		if lines[i].Content != want {
+			t.Errorf("line %d = %q, want %q", i, lines[i].Content, want)
+		}
+	}
+
+	want := [][]Span{
+		{{Start: 5, End: 10, Style: "bold"}},
+		{{Start: 0, End: 4, Style: "bold"}},
+	}
+	if len(spans) != len(want) {
+		t.Fatalf("got %d span lists, want %d", len(spans), len(want))
+	}
+	for i := range want {
+		if len(spans[i]) != len(want[i]) {
+			t.Fatalf("line %d spans = %v, want %v", i, spans[i], want[i])
+		}
+		for j := range want[i] {
+			if spans[i][j] != want[i][j] {
+				t.Errorf("line %d span %d = %v, want %v", i, j, spans[i][j], want[i][j])
+			}
+			got := lines[i].Content[spans[i][j].Start:spans[i][j].End]
+			if i == 0 && got != "bold " || i == 1 && got != "text" {
+				t.Errorf("line %d span %d covers %q", i, j, got)
+			}
+		}
+	}
+}
+
+func TestWrapWithSpans_Multibyte(t *testing.T) {
+	txt := NewTerminal()
+
+	// Each ideograph is 3 bytes and 2 cells wide.
+	text := "世界你好"
+	spans := []Span{
+		{Start: 3, End: 9, Style: 1},   // "界你"
+		{Start: 9, End: 12, Style: 2},  // "好"
+		{Start: 12, End: 20, Style: 3}, // Past the end
+	}
+	lines, lineSpans := txt.WrapWithSpans(text, spans, WrapOptions{MaxWidth: 4})
+
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2: %v", len(lines), lines)
+	}
+	want := [][]Span{
+		{{Start: 3, End: 6, Style: 1}},
+		{{Start: 0, End: 3, Style: 1}, {Start: 3, End: 6, Style: 2}},
+	}
+	for i := range want {
+		if len(lineSpans[i]) != len(want[i]) {
+			t.Fatalf("line %d spans = %v, want %v", i, lineSpans[i], want[i])
+		}
+		for j := range want[i] {
+			if lineSpans[i][j] != want[i][j] {
+				t.Errorf("line %d span %d = %v, want %v", i, j, lineSpans[i][j], want[i][j])
+			}
+		}
+	}
+}
+
+func TestWrapWithSpans_NormalizeInput(t *testing.T) {
+	txt := New(Config{MeasureFunc: TerminalMeasure, NormalizeInput: true})
+
+	// Span offsets index the NFD input; lines hold its NFC form.
+	text := "cafe\u0301 bold"
+	spans := []Span{
+		{Start: 0, End: 6, Style: 1},  // "cafe\u0301"
+		{Start: 7, End: 11, Style: 2}, // "bold"
+	}
+	lines, lineSpans := txt.WrapWithSpans(text, spans, WrapOptions{MaxWidth: 5})
+
+	wantLines := []string{"caf\u00e9 ", "bold"}
+	var gotLines []string
+	for _, line := range lines {
+		gotLines = append(gotLines, line.Content)
+	}
+	if !reflect.DeepEqual(gotLines, wantLines) {
+		t.Fatalf("lines = %q, want %q", gotLines, wantLines)
+	}
+	want := [][]Span{
+		{{Start: 0, End: 5, Style: 1}},
+		{{Start: 0, End: 4, Style: 2}},
+	}
+	if !reflect.DeepEqual(lineSpans, want) {
+		t.Errorf("spans = %v, want %v", lineSpans, want)
+	}
+}
+
+func TestWrapWithSpans_LaidOutContent(t *testing.T) {
+	txt := NewTerminal()
+
+	tests := []struct {
+		name  string
+		text  string
+		spans []Span
+		opts  WrapOptions
+		want  []string // Content covered by each line's spans
+	}{
+		{
+			name:  "Tab expanded before the span",
+			text:  "a\tbold x",
+			spans: []Span{{Start: 2, End: 6}},
+			opts:  WrapOptions{MaxWidth: 20, TabSize: &TabSize{Value: 4}},
+			want:  []string{"bold"},
+		},
+		{
+			name:  "Soft hyphens removed and added at breaks",
+			text:  "fan\u00ADtas\u00ADtic bold",
+			spans: []Span{{Start: 0, End: 13}, {Start: 14, End: 18}},
+			opts:  WrapOptions{MaxWidth: 5, SoftHyphens: true},
+			want:  []string{"fan-", "tas-", "tic", "bold"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines, lineSpans := txt.WrapWithSpans(tt.text, tt.spans, tt.opts)
+
+			var got []string
+			for i, spans := range lineSpans {
+				for _, span := range spans {
+					got = append(got, lines[i].Content[span.Start:span.End])
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("spans cover %q, want %q", got, tt.want)
+			}
+		})
 	}
 }
 
@@ -293,6 +1259,45 @@ func BenchmarkWidth(b *testing.B) {
 	}
 }
 
+func BenchmarkWidth_Cached(b *testing.B) {
+	text := "Hello 世界! Mixed Latin and 中文文本 measured every frame."
+
+	b.Run("Uncached", func(b *testing.B) {
+		txt := NewTerminal()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			txt.Width(text)
+		}
+	})
+
+	b.Run("Cached", func(b *testing.B) {
+		txt := New(Config{MeasureFunc: TerminalMeasure, CacheWidths: true})
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			txt.Width(text)
+		}
+	})
+}
+
+func BenchmarkFits(b *testing.B) {
+	txt := NewTerminal()
+	text := strings.Repeat("Hello 世界! This line overflows almost immediately. ", 50)
+
+	b.Run("Fits", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			txt.Fits(text, 20)
+		}
+	})
+
+	b.Run("Width", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = txt.Width(text) <= 20
+		}
+	})
+}
+
 func BenchmarkTruncate(b *testing.B) {
 	txt := NewTerminal()
 	text := "Hello 世界! This is a long text that needs truncation with emoji 😀."
@@ -318,6 +1323,45 @@ func BenchmarkWrap(b *testing.B) {
 	}
 }
 
+// responsiveWidths are the widths a responsive layout might wrap one
+// paragraph at.
+var responsiveWidths = []float64{20, 28, 36, 44, 52, 60, 68, 76, 84, 92}
+
+func BenchmarkWrap_TenWidths(b *testing.B) {
+	txt := NewTerminal()
+	text := strings.Repeat("Hello 世界! This is a long text that needs wrapping with emoji 😀 and combining marks. ", 4)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, width := range responsiveWidths {
+			txt.Wrap(text, WrapOptions{MaxWidth: width})
+		}
+	}
+}
+
+func BenchmarkAnalysis_WrapAtTenWidths(b *testing.B) {
+	txt := NewTerminal()
+	text := strings.Repeat("Hello 世界! This is a long text that needs wrapping with emoji 😀 and combining marks. ", 4)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		a := txt.Analyze(text)
+		for _, width := range responsiveWidths {
+			a.WrapAt(width)
+		}
+	}
+}
+
+func BenchmarkWrapAtWidths(b *testing.B) {
+	txt := NewTerminal()
+	text := strings.Repeat("Hello 世界! This is a long text that needs wrapping with emoji 😀 and combining marks. ", 4)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		txt.WrapAtWidths(text, responsiveWidths)
+	}
+}
+
 func TestWidthRange(t *testing.T) {
 	txt := NewTerminal()
 
@@ -346,111 +1390,310 @@ func TestWidthRange(t *testing.T) {
 	}
 }
 
-func TestTerminalMeasureEastAsian(t *testing.T) {
-	// Test East Asian context where ambiguous characters are wide
+func TestWidthRangeGraphemes(t *testing.T) {
+	txt := NewTerminal()
+	decomposed := "cafe\u0301!" // c a f e ◌́ !
+
 	tests := []struct {
 		name     string
-		char     rune
+		text     string
+		start    int
+		end      int
 		expected float64
 	}{
-		{"ASCII", 'A', 1.0},
-		{"CJK", '世', 2.0},
-		{"Emoji", '😀', 2.0},
+		{"Whole string", decomposed, 0, 6, 5.0},
+		{"End before mark", decomposed, 0, 4, 4.0},
+		{"Start on mark", decomposed, 4, 6, 2.0},
+		{"Only mark", decomposed, 4, 5, 1.0},
+		{"Only base", decomposed, 3, 4, 1.0},
+		{"CJK range", "Hello世界!", 5, 7, 4.0},
+		{"Inside emoji ZWJ cluster", "a👨‍👩‍👧‍👦b", 2, 3, 2.0},
+		{"Empty range", decomposed, 2, 2, 0.0},
+		{"Out of bounds", decomposed, -3, 100, 5.0},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			width := TerminalMeasureEastAsian(tt.char)
+			width := txt.WidthRangeGraphemes(tt.text, tt.start, tt.end)
 			if width != tt.expected {
-				t.Errorf("TerminalMeasureEastAsian(%q) = %.1f, want %.1f",
-					tt.char, width, tt.expected)
+				t.Errorf("WidthRangeGraphemes(%q, %d, %d) = %.1f, want %.1f",
+					tt.text, tt.start, tt.end, width, tt.expected)
 			}
 		})
 	}
-}
-
-func TestNewTerminalEastAsian(t *testing.T) {
-	txt := NewTerminalEastAsian()
-	if txt == nil {
-		t.Fatal("NewTerminalEastAsian() returned nil")
-	}
 
-	if got := txt.Width("世界"); got != 4.0 {
-		t.Fatalf("Width(\"世界\") = %.1f, want 4.0", got)
-	}
-	if got := txt.Width("A"); got != 1.0 {
-		t.Fatalf("Width(\"A\") = %.1f, want 1.0", got)
+	// Without snapping, the base and mark are measured separately.
+	if got := txt.WidthRange(decomposed, 4, 5); got != 0 {
+		t.Errorf("WidthRange(mark only) = %.1f, want 0", got)
 	}
 }
 
-func TestGraphemeCount(t *testing.T) {
+func TestWidth_CombiningMarks(t *testing.T) {
 	txt := NewTerminal()
 
 	tests := []struct {
 		name     string
 		text     string
-		expected int
+		expected float64
 	}{
-		{"ASCII", "Hello", 5},
-		{"CJK", "世界", 2},
-		{"Emoji with modifier", "👋🏻", 1},
-		{"Mixed", "Hello👋", 6},
+		{"Decomposed é", "e\u0301", 1.0},
+		{"Stacked marks", "a\u0301\u0323\u0308", 1.0},
+		{"Enclosing circle", "A\u20DD", 1.0},
+		{"Hebrew points", "שָׁלוֹם", 4.0},
+		{"Devanagari spacing mark", "कि", 2.0},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			count := txt.GraphemeCount(tt.text)
-			if count != tt.expected {
-				t.Errorf("GraphemeCount(%q) = %d, want %d",
-					tt.text, count, tt.expected)
+			if got := txt.Width(tt.text); got != tt.expected {
+				t.Errorf("Width(%q) = %.1f, want %.1f", tt.text, got, tt.expected)
 			}
 		})
 	}
 }
 
-func TestGraphemeAt(t *testing.T) {
-	txt := NewTerminal()
-	text := "Hello👋🏻"
-
+func TestTerminalMeasure_CombiningMarks(t *testing.T) {
 	tests := []struct {
 		name     string
-		index    int
-		expected string
+		char     rune
+		expected float64
 	}{
-		{"First char", 0, "H"},
-		{"Middle char", 2, "l"},
-		{"Emoji", 5, "👋🏻"},
-		{"Out of range", 10, ""},
-		{"Negative index", -1, ""},
+		{"Combining acute", '\u0301', 0},
+		{"Enclosing circle", '\u20DD', 0},
+		{"Hebrew qamats", '\u05B8', 0},
+		{"Devanagari vowel sign i (spacing)", '\u093F', 1},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := txt.GraphemeAt(text, tt.index)
-			if result != tt.expected {
-				t.Errorf("GraphemeAt(%q, %d) = %q, want %q",
-					text, tt.index, result, tt.expected)
+			if got := TerminalMeasure(tt.char); got != tt.expected {
+				t.Errorf("TerminalMeasure(%U) = %.1f, want %.1f", tt.char, got, tt.expected)
+			}
+			if got := TerminalMeasureEastAsian(tt.char); got != tt.expected {
+				t.Errorf("TerminalMeasureEastAsian(%U) = %.1f, want %.1f", tt.char, got, tt.expected)
 			}
 		})
 	}
 }
 
-func TestReorderWithDirection(t *testing.T) {
+func TestCombiningMarks_Callers(t *testing.T) {
 	txt := NewTerminal()
+	decomposed := "cafe\u0301 cafe\u0301"
 
-	tests := []struct {
-		name      string
-		text      string
-		direction Direction
-	}{
-		{"LTR explicit", "Hello world", DirectionLTR},
-		{"RTL explicit", "שלום עולם", DirectionRTL},
+	// A decomposed é takes one cell, like a precomposed one.
+	if lines := txt.Wrap(decomposed, WrapOptions{MaxWidth: 9}); len(lines) != 1 {
+		t.Errorf("Wrap(%q, 9) = %d lines, want 1", decomposed, len(lines))
 	}
+	got := strings.ReplaceAll(txt.Elide(decomposed, 6), "e\u0301", "\u00e9")
+	if want := txt.Elide("caf\u00e9 caf\u00e9", 6); got != want {
+		t.Errorf("Elide(%q, 6) = %q, want %q", decomposed, got, want)
+	}
+	if got := txt.Align("e\u0301", 3, AlignRight); got != "  e\u0301" {
+		t.Errorf("Align(%q, 3, AlignRight) = %q, want %q", "e\u0301", got, "  e\u0301")
+	}
+	if got := txt.WidthRangeGraphemes(decomposed, 0, 4); got != 4 {
+		t.Errorf("WidthRangeGraphemes(%q, 0, 4) = %.1f, want 4.0", decomposed, got)
+	}
+}
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			// Just verify it doesn't panic
-			result := txt.ReorderWithDirection(tt.text, toUAX9Direction(tt.direction))
+func TestWidth_Newlines(t *testing.T) {
+	txt := NewTerminal()
+	spaced := New(Config{NewlinesAsSpaces: true})
+
+	tests := []struct {
+		name     string
+		text     string
+		expected float64
+		asSpaces float64
+	}{
+		{"LF", "ab\ncd", 4.0, 5.0},
+		{"CRLF", "ab\r\ncd", 4.0, 5.0},
+		{"CR", "ab\rcd", 4.0, 5.0},
+		{"Blank lines", "世界\n\n", 4.0, 6.0},
+		{"Only newline", "\n", 0.0, 1.0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := txt.Width(tt.text); got != tt.expected {
+				t.Errorf("Width(%q) = %.1f, want %.1f", tt.text, got, tt.expected)
+			}
+			if got := spaced.Width(tt.text); got != tt.asSpaces {
+				t.Errorf("NewlinesAsSpaces: Width(%q) = %.1f, want %.1f", tt.text, got, tt.asSpaces)
+			}
+			if !txt.Fits(tt.text, tt.expected) {
+				t.Errorf("Fits(%q, %.1f) = false", tt.text, tt.expected)
+			}
+		})
+	}
+
+	if txt.Width("ab\ncd") != txt.Width("abcd") {
+		t.Errorf("Width(%q) != Width(%q)", "ab\ncd", "abcd")
+	}
+}
+
+func TestIsValidFlagSequence(t *testing.T) {
+	ri := func(c byte) rune { return rune(0x1F1E6 + int(c-'A')) }
+
+	tests := []struct {
+		code string
+		want bool
+	}{
+		{"US", true},
+		{"JP", true},
+		{"GB", true},
+		{"EU", true},
+		{"UN", true},
+		{"XK", true},
+		{"ZZ", false},
+		{"AA", false},
+		{"UK", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			if got := IsValidFlagSequence(ri(tt.code[0]), ri(tt.code[1])); got != tt.want {
+				t.Errorf("IsValidFlagSequence(%s) = %v, want %v", tt.code, got, tt.want)
+			}
+		})
+	}
+
+	if IsValidFlagSequence('U', 'S') {
+		t.Error("ASCII letters are not regional indicators")
+	}
+	if n := len(strings.Fields(validFlagRegions)); n != 258 {
+		t.Errorf("validFlagRegions has %d codes, want 258", n)
+	}
+}
+
+func TestWidth_FlagSequences(t *testing.T) {
+	txt := NewTerminal()
+
+	tests := []struct {
+		name     string
+		text     string
+		expected float64
+	}{
+		{"Valid flag", "🇺🇸", 2.0},
+		{"Invalid pair", "🇿🇿", 4.0},
+		{"Valid then invalid", "🇯🇵🇿🇿", 6.0},
+		{"Flag in text", "Go 🇩🇪!", 6.0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := txt.Width(tt.text); got != tt.expected {
+				t.Errorf("Width(%q) = %.1f, want %.1f", tt.text, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTerminalMeasureEastAsian(t *testing.T) {
+	// Test East Asian context where ambiguous characters are wide
+	tests := []struct {
+		name     string
+		char     rune
+		expected float64
+	}{
+		{"ASCII", 'A', 1.0},
+		{"CJK", '世', 2.0},
+		{"Emoji", '😀', 2.0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			width := TerminalMeasureEastAsian(tt.char)
+			if width != tt.expected {
+				t.Errorf("TerminalMeasureEastAsian(%q) = %.1f, want %.1f",
+					tt.char, width, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNewTerminalEastAsian(t *testing.T) {
+	txt := NewTerminalEastAsian()
+	if txt == nil {
+		t.Fatal("NewTerminalEastAsian() returned nil")
+	}
+
+	if got := txt.Width("世界"); got != 4.0 {
+		t.Fatalf("Width(\"世界\") = %.1f, want 4.0", got)
+	}
+	if got := txt.Width("A"); got != 1.0 {
+		t.Fatalf("Width(\"A\") = %.1f, want 1.0", got)
+	}
+}
+
+func TestGraphemeCount(t *testing.T) {
+	txt := NewTerminal()
+
+	tests := []struct {
+		name     string
+		text     string
+		expected int
+	}{
+		{"ASCII", "Hello", 5},
+		{"CJK", "世界", 2},
+		{"Emoji with modifier", "👋🏻", 1},
+		{"Mixed", "Hello👋", 6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			count := txt.GraphemeCount(tt.text)
+			if count != tt.expected {
+				t.Errorf("GraphemeCount(%q) = %d, want %d",
+					tt.text, count, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGraphemeAt(t *testing.T) {
+	txt := NewTerminal()
+	text := "Hello👋🏻"
+
+	tests := []struct {
+		name     string
+		index    int
+		expected string
+	}{
+		{"First char", 0, "H"},
+		{"Middle char", 2, "l"},
+		{"Emoji", 5, "👋🏻"},
+		{"Out of range", 10, ""},
+		{"Negative index", -1, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := txt.GraphemeAt(text, tt.index)
+			if result != tt.expected {
+				t.Errorf("GraphemeAt(%q, %d) = %q, want %q",
+					text, tt.index, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestReorderWithDirection(t *testing.T) {
+	txt := NewTerminal()
+
+	tests := []struct {
+		name      string
+		text      string
+		direction Direction
+	}{
+		{"LTR explicit", "Hello world", DirectionLTR},
+		{"RTL explicit", "שלום עולם", DirectionRTL},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Just verify it doesn't panic
+			result := txt.ReorderWithDirection(tt.text, toUAX9Direction(tt.direction))
 			if result == "" && tt.text != "" {
 				t.Errorf("ReorderWithDirection returned empty string")
 			}
@@ -486,6 +1729,42 @@ func TestResolveAlignment(t *testing.T) {
 	}
 }
 
+func TestAlignWithDirection_JustifyRTL(t *testing.T) {
+	txt := NewTerminal()
+
+	tests := []struct {
+		name      string
+		text      string
+		direction Direction
+		want      string
+	}{
+		{"Hebrew words keep order", "שלום עולם", DirectionRTL, "שלום    עולם"},
+		{"Arabic words keep order", "مرحبا عالم", DirectionRTL, "مرحبا   عالم"},
+		{"Single RTL word anchored right", "שלום", DirectionRTL, "        שלום"},
+		{"Single word auto-detected RTL", "שלום", DirectionAuto, "        שלום"},
+		{"Single LTR word unchanged", "Hello", DirectionLTR, "Hello"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := txt.AlignWithDirection(tt.text, 12, AlignJustify, tt.direction, AlignLeft)
+			if got != tt.want {
+				t.Errorf("AlignWithDirection(%q, justify) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+
+	// With fractional spaces an RTL word still ends flush right.
+	pixel := New(Config{MeasureFunc: pixelMeasure})
+	got := pixel.AlignWithDirection("שלום", 100, AlignJustify, DirectionRTL, AlignLeft)
+	if !strings.HasPrefix(got, " ") || !strings.HasSuffix(got, "שלום") {
+		t.Errorf("RTL justified word = %q, want padding before the word", got)
+	}
+	if w := pixel.Width(got); w > 100 || 100-w >= 4.2 {
+		t.Errorf("width = %.2f, want within one space of 100", w)
+	}
+}
+
 func TestWidthBytes(t *testing.T) {
 	txt := NewTerminal()
 
@@ -573,6 +1852,173 @@ func TestWidthUpTo(t *testing.T) {
 	}
 }
 
+func TestFitWidth(t *testing.T) {
+	txt := NewTerminal()
+
+	tests := []struct {
+		name      string
+		text      string
+		maxWidth  float64
+		wantRunes int
+		wantWidth float64
+	}{
+		{"Stops before overflow", "Hello world", 8, 8, 8},
+		{"Everything fits", "Hello", 10, 5, 5},
+		{"Exact fit", "Hello", 5, 5, 5},
+		{"Wide char does not fit", "Hello 世界", 7, 6, 6},
+		{"Wide char fits", "Hello 世界", 8, 7, 8},
+		{"Combining mark kept with base", "e\u0301x", 1, 2, 1},
+		{"ZWJ sequence is one cluster", "👨‍👩‍👧x", 1, 0, 0},
+		{"ZWJ sequence fits", "👨‍👩‍👧x", 2, 5, 2},
+		{"Prepend joins following digit", "a\u06001b", 2, 1, 1},
+		{"Zero width", "Hello", 0, 0, 0},
+		{"Empty", "", 5, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n, w := txt.FitWidth(tt.text, tt.maxWidth)
+			if n != tt.wantRunes || w != tt.wantWidth {
+				t.Errorf("FitWidth(%q, %.1f) = (%d, %.1f), want (%d, %.1f)",
+					tt.text, tt.maxWidth, n, w, tt.wantRunes, tt.wantWidth)
+			}
+		})
+	}
+}
+
+func TestClipToWidth(t *testing.T) {
+	txt := NewTerminal()
+
+	tests := []struct {
+		name      string
+		text      string
+		maxWidth  float64
+		wantHead  string
+		wantTail  string
+		wantWidth float64
+	}{
+		{"Split inside text", "Hello world", 8, "Hello wo", "rld", 8},
+		{"Everything fits", "Hello", 10, "Hello", "", 5},
+		{"Wide char moves to tail", "Hello 世界", 7, "Hello ", "世界", 6},
+		{"First cluster too wide", "世界", 1, "", "世界", 0},
+		{"ZWJ sequence not split", "a👨‍👩‍👧b", 2, "a", "👨‍👩‍👧b", 1},
+		{"ZWJ sequence fits whole", "a👨‍👩‍👧b", 3, "a👨‍👩‍👧", "b", 3},
+		{"Combining mark stays in head", "e\u0301xyz", 1, "e\u0301", "xyz", 1},
+		{"Prepend cluster not split", "a\u06001b", 2, "a", "\u06001b", 1},
+		{"Prepend cluster fits whole", "a\u06001b", 3, "a\u06001", "b", 3},
+		{"Cluster longer than a segmentation window", "a" + longCluster + "b", 2, "a" + longCluster, "b", 2},
+		{"Flags pair up after text", "ab\U0001F1FA\U0001F1F8\U0001F1E9\U0001F1EA", 4, "ab\U0001F1FA\U0001F1F8", "\U0001F1E9\U0001F1EA", 4},
+		{"Empty", "", 5, "", "", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			head, tail, w := txt.ClipToWidth(tt.text, tt.maxWidth)
+			if head != tt.wantHead || tail != tt.wantTail || w != tt.wantWidth {
+				t.Errorf("ClipToWidth(%q, %.1f) = (%q, %q, %.1f), want (%q, %q, %.1f)",
+					tt.text, tt.maxWidth, head, tail, w, tt.wantHead, tt.wantTail, tt.wantWidth)
+			}
+			if head+tail != tt.text {
+				t.Errorf("head+tail = %q, want %q", head+tail, tt.text)
+			}
+		})
+	}
+}
+
+// longCluster is a single grapheme cluster longer than the window
+// nextGrapheme segments at first.
+var longCluster = "e" + strings.Repeat("\u0301", 40)
+
+func TestFits(t *testing.T) {
+	txt := NewTerminal()
+
+	tests := []struct {
+		name     string
+		text     string
+		maxWidth float64
+		want     bool
+	}{
+		{"Exact boundary", "Hello", 5, true},
+		{"Just over boundary", "Hello", 4.9, false},
+		{"CJK exact", "世界", 4, true},
+		{"CJK over", "世界", 3, false},
+		{"Combining mark", "e\u0301", 2, true},
+		{"Emoji ZWJ sequence", "👨‍👩‍👧", 2, true},
+		{"Mixed over", "Hello 世界", 8, false},
+		{"CRLF", "ab\r\n", 4, true},
+		{"Prepend cluster", "\u06001 x", 4, true},
+		{"Prepend cluster over", "x \u06001", 3, false},
+		{"Cluster longer than a segmentation window", longCluster + "x", 2, true},
+		{"Family emoji across windows", strings.Repeat("👨‍👩‍👧‍👦", 3) + "x", 6, false},
+		{"Empty", "", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := txt.Fits(tt.text, tt.maxWidth); got != tt.want {
+				t.Errorf("Fits(%q, %.1f) = %v, want %v", tt.text, tt.maxWidth, got, tt.want)
+			}
+			if want := txt.Width(tt.text) <= tt.maxWidth; want != tt.want {
+				t.Errorf("Width(%q) <= %.1f = %v, inconsistent with Fits", tt.text, tt.maxWidth, want)
+			}
+		})
+	}
+}
+
+func TestFits_NoAllocations(t *testing.T) {
+	txt := NewTerminal()
+	text := strings.Repeat("The quick brown fox jumps over the lazy dog. ", 20)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		txt.Fits(text, 1000)
+	})
+	if allocs != 0 {
+		t.Errorf("Fits allocated %.0f times per run on ASCII text, want 0", allocs)
+	}
+}
+
+func TestFits_StopsEarly(t *testing.T) {
+	txt := NewTerminal()
+	short := strings.Repeat("世界", 100)
+	long := strings.Repeat("世界", 10000)
+
+	shortAllocs := testing.AllocsPerRun(20, func() { txt.Fits(short, 10) })
+	longAllocs := testing.AllocsPerRun(20, func() { txt.Fits(long, 10) })
+	if longAllocs > shortAllocs {
+		t.Errorf("Fits allocated %.0f times on long text, %.0f on short; cost should not depend on length", longAllocs, shortAllocs)
+	}
+}
+
+func TestVisibleWidth(t *testing.T) {
+	txt := NewTerminal()
+
+	tests := []struct {
+		name        string
+		text        string
+		wantWidth   float64
+		wantVisible float64
+	}{
+		{"No trailing space", "Hello", 5.0, 5.0},
+		{"Trailing spaces", "Hello   ", 8.0, 5.0},
+		{"Leading spaces kept", "  Hello", 7.0, 7.0},
+		{"Ideographic space", "世界\u3000\u3000", 8.0, 4.0},
+		{"Mixed whitespace", "Hi \t\u00A0\u3000", 7.0, 2.0},
+		{"Only spaces", "   ", 3.0, 0.0},
+		{"Empty", "", 0.0, 0.0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := txt.Width(tt.text); got != tt.wantWidth {
+				t.Errorf("Width(%q) = %.1f, want %.1f", tt.text, got, tt.wantWidth)
+			}
+			if got := txt.VisibleWidth(tt.text); got != tt.wantVisible {
+				t.Errorf("VisibleWidth(%q) = %.1f, want %.1f", tt.text, got, tt.wantVisible)
+			}
+		})
+	}
+}
+
 func TestWidthMany(t *testing.T) {
 	txt := NewTerminal()
 
@@ -618,3 +2064,460 @@ func TestWidthMany(t *testing.T) {
 		})
 	}
 }
+
+func TestPalette(t *testing.T) {
+	txt := NewTerminal()
+	labels := []string{"Running", "Stopped", "失敗", "👨‍👩‍👧", "", "Running"}
+	p := txt.Palette(labels)
+
+	for _, s := range labels {
+		t.Run(s, func(t *testing.T) {
+			got, ok := p.Width(s)
+			if !ok {
+				t.Fatalf("Width(%q) not in palette", s)
+			}
+			if want := txt.Width(s); got != want {
+				t.Errorf("Width(%q) = %.1f, want %.1f", s, got, want)
+			}
+			if got := p.WidthOrMeasure(s); got != txt.Width(s) {
+				t.Errorf("WidthOrMeasure(%q) = %.1f, want %.1f", s, got, txt.Width(s))
+			}
+		})
+	}
+
+	t.Run("Unknown string", func(t *testing.T) {
+		if w, ok := p.Width("世界"); ok || w != 0 {
+			t.Errorf("Width(unknown) = %.1f, %v; want 0, false", w, ok)
+		}
+		if got := p.WidthOrMeasure("世界"); got != 4 {
+			t.Errorf("WidthOrMeasure(unknown) = %.1f, want 4", got)
+		}
+		if _, ok := p.Width("世界"); ok {
+			t.Error("WidthOrMeasure added the string to the palette")
+		}
+	})
+}
+
+func TestWrapDetailed(t *testing.T) {
+	txt := NewTerminal()
+
+	tests := []struct {
+		name           string
+		input          string
+		opts           WrapOptions
+		wantLines      []string
+		wantInfo       []LineInfo
+		wantOverflowed bool
+		wantMaxWidth   float64
+		normalize      bool
+	}{
+		{
+			name:         "Fits",
+			input:        "Hello world",
+			opts:         WrapOptions{MaxWidth: 6},
+			wantLines:    []string{"Hello ", "world"},
+			wantInfo:     []LineInfo{{}, {}},
+			wantMaxWidth: 6,
+		},
+		{
+			name:           "Unbreakable token overflows",
+			input:          "see https://example.com/long/path",
+			opts:           WrapOptions{MaxWidth: 10},
+			wantLines:      []string{"see ", "https://", "example.com/", "long/path"},
+			wantInfo:       []LineInfo{{}, {}, {Overflowed: true}, {}},
+			wantOverflowed: true,
+			wantMaxWidth:   12,
+		},
+		{
+			name:         "Trailing space does not overflow",
+			input:        "abcdef gh",
+			opts:         WrapOptions{MaxWidth: 6},
+			wantLines:    []string{"abcdef ", "gh"},
+			wantInfo:     []LineInfo{{}, {}},
+			wantMaxWidth: 7,
+		},
+		{
+			name:         "Emergency breaks",
+			input:        "abcdefgh ij",
+			opts:         WrapOptions{MaxWidth: 3, BreakWords: true},
+			wantLines:    []string{"abc", "def", "gh ", "ij"},
+			wantInfo:     []LineInfo{{HadEmergencyBreak: true}, {HadEmergencyBreak: true}, {}, {}},
+			wantMaxWidth: 3,
+		},
+		{
+			name:         "Hyphen",
+			input:        "well-known fact",
+			opts:         WrapOptions{MaxWidth: 6},
+			wantLines:    []string{"well-", "known ", "fact"},
+			wantInfo:     []LineInfo{{EndedWithHyphen: true}, {}, {}},
+			wantMaxWidth: 6,
+		},
+		{
+			name:           "Soft hyphen",
+			input:          "extra\u00ADordinary",
+			opts:           WrapOptions{MaxWidth: 7},
+			wantLines:      []string{"extra\u00AD", "ordinary"},
+			wantInfo:       []LineInfo{{EndedWithHyphen: true}, {Overflowed: true}},
+			wantOverflowed: true,
+			wantMaxWidth:   8,
+		},
+		{
+			name:         "Hard break is not a hyphenation",
+			input:        "a-\nbb",
+			opts:         WrapOptions{MaxWidth: 3, PreserveNewlines: true},
+			wantLines:    []string{"a-", "bb"},
+			wantInfo:     []LineInfo{{}, {}},
+			wantMaxWidth: 2,
+		},
+		{
+			name:         "NormalizeInput",
+			input:        "cafe\u0301 cafe\u0301 cafe\u0301",
+			opts:         WrapOptions{MaxWidth: 5},
+			wantLines:    []string{"caf\u00e9 ", "caf\u00e9 ", "caf\u00e9"},
+			wantInfo:     []LineInfo{{}, {}, {}},
+			wantMaxWidth: 5,
+			normalize:    true,
+		},
+	}
+
+	normalizing := New(Config{MeasureFunc: TerminalMeasure, NormalizeInput: true})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			txt := txt
+			if tt.normalize {
+				txt = normalizing
+			}
+			result := txt.WrapDetailed(tt.input, tt.opts)
+
+			var got []string
+			for _, line := range result.Lines {
+				got = append(got, line.Content)
+			}
+			if !reflect.DeepEqual(got, tt.wantLines) {
+				t.Fatalf("Lines = %q, want %q", got, tt.wantLines)
+			}
+			if !reflect.DeepEqual(result.LineInfo, tt.wantInfo) {
+				t.Errorf("LineInfo = %+v, want %+v", result.LineInfo, tt.wantInfo)
+			}
+			if result.Overflowed != tt.wantOverflowed {
+				t.Errorf("Overflowed = %v, want %v", result.Overflowed, tt.wantOverflowed)
+			}
+			if result.MaxLineWidth != tt.wantMaxWidth {
+				t.Errorf("MaxLineWidth = %.1f, want %.1f", result.MaxLineWidth, tt.wantMaxWidth)
+			}
+			if !reflect.DeepEqual(result.Lines, txt.Wrap(tt.input, tt.opts)) {
+				t.Error("Lines differ from Wrap")
+			}
+		})
+	}
+}
+
+func TestReWrap_MatchesWrap(t *testing.T) {
+	txt := NewTerminal()
+	normalizing := New(Config{MeasureFunc: TerminalMeasure, NormalizeInput: true})
+	pieces := []string{"a", "ab", "word", "longerword", "well-known", "-x", " ", " ", "\n", "\r\n", "中文", "café", "cafe\u0301", "-", "👍"}
+
+	tests := []struct {
+		txt  *Text
+		opts WrapOptions
+	}{
+		{txt, WrapOptions{MaxWidth: 10}},
+		{txt, WrapOptions{MaxWidth: 4, BreakWords: true}},
+		{txt, WrapOptions{MaxWidth: 7, BreakWords: true}},
+		{txt, WrapOptions{MaxWidth: 12, PreserveNewlines: true}},
+		{txt, WrapOptions{MaxWidth: 5, BreakWords: true, PreserveNewlines: true}},
+		{normalizing, WrapOptions{MaxWidth: 6}},
+	}
+
+	for _, tt := range tests {
+		txt, opts := tt.txt, tt.opts
+		name := fmt.Sprintf("width=%.0f/break=%v/newlines=%v/normalize=%v", opts.MaxWidth, opts.BreakWords, opts.PreserveNewlines, txt == normalizing)
+		t.Run(name, func(t *testing.T) {
+			for seed := int64(1); seed <= 10; seed++ {
+				rng := rand.New(rand.NewSource(seed))
+				randomText := func(n int) string {
+					var b strings.Builder
+					for i := 0; i < n; i++ {
+						b.WriteString(pieces[rng.Intn(len(pieces))])
+					}
+					return b.String()
+				}
+
+				for i := 0; i < 100; i++ {
+					old := []rune(randomText(20))
+					prev := txt.Wrap(string(old), opts)
+
+					// Replace a random rune range with random text
+					from := rng.Intn(len(old) + 1)
+					to := from + rng.Intn(len(old)-from+1)
+					edited := string(old[:from]) + randomText(rng.Intn(4)) + string(old[to:])
+
+					got := txt.ReWrap(prev, edited, from, opts)
+					want := txt.Wrap(edited, opts)
+					if !reflect.DeepEqual(got, want) {
+						t.Fatalf("seed %d: ReWrap(%q -> %q, from %d)\ngot  %+v\nwant %+v", seed, string(old), edited, from, got, want)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestReWrap_WordInitialHyphen(t *testing.T) {
+	txt := NewTerminal()
+	opts := WrapOptions{MaxWidth: 4, BreakWords: true}
+
+	// LB20.1 does not break after a word-initial hyphen, so the "-" that
+	// BreakWords left on its own line must not be re-wrapped as if it
+	// started the text.
+	prev := txt.Wrap("well-known ab", opts)
+	got := txt.ReWrap(prev, "well-knowx ab", 9, opts)
+	want := txt.Wrap("well-knowx ab", opts)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ReWrap\ngot  %+v\nwant %+v", got, want)
+	}
+}
+
+func TestSplitAtRanges(t *testing.T) {
+	txt := NewTerminal()
+
+	tests := []struct {
+		name   string
+		input  string
+		ranges [][2]int
+		want   []Segment
+	}{
+		{
+			name:   "CJK",
+			input:  "世界",
+			ranges: [][2]int{{0, 1}},
+			want: []Segment{
+				{Text: "世", Matched: true, Width: 2},
+				{Text: "界", Matched: false, Width: 2},
+			},
+		},
+		{
+			name:   "Range inside emoji snaps to cluster",
+			input:  "a👍🏽b",
+			ranges: [][2]int{{2, 3}},
+			want: []Segment{
+				{Text: "a", Matched: false, Width: 1},
+				{Text: "👍🏽", Matched: true, Width: 2},
+				{Text: "b", Matched: false, Width: 1},
+			},
+		},
+		{
+			name:   "Overlapping and unsorted",
+			input:  "abcdefgh",
+			ranges: [][2]int{{5, 7}, {1, 3}, {2, 4}},
+			want: []Segment{
+				{Text: "a", Matched: false, Width: 1},
+				{Text: "bcd", Matched: true, Width: 3},
+				{Text: "e", Matched: false, Width: 1},
+				{Text: "fg", Matched: true, Width: 2},
+				{Text: "h", Matched: false, Width: 1},
+			},
+		},
+		{
+			name:   "Out of bounds and empty ranges",
+			input:  "abc",
+			ranges: [][2]int{{2, 10}, {1, 1}},
+			want: []Segment{
+				{Text: "ab", Matched: false, Width: 2},
+				{Text: "c", Matched: true, Width: 1},
+			},
+		},
+		{
+			name:   "No ranges",
+			input:  "abc",
+			ranges: nil,
+			want:   []Segment{{Text: "abc", Matched: false, Width: 3}},
+		},
+		{
+			name:   "Empty text",
+			input:  "",
+			ranges: [][2]int{{0, 1}},
+			want:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := txt.SplitAtRanges(tt.input, tt.ranges)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SplitAtRanges(%q, %v)\ngot  %+v\nwant %+v", tt.input, tt.ranges, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAnalysis_WrapAt(t *testing.T) {
+	txt := NewTerminal()
+	texts := []string{
+		"Hello 世界! This is a long text that needs wrapping with emoji 😀 and combining marks.",
+		"supercalifragilistic expialidocious",
+		"a\nb c",
+		"",
+	}
+
+	for _, text := range texts {
+		a := txt.Analyze(text)
+		for _, width := range []float64{0, 1, 5, 12, 30, 200} {
+			t.Run(fmt.Sprintf("%q/%.0f", text, width), func(t *testing.T) {
+				got := a.WrapAt(width)
+				want := txt.Wrap(text, WrapOptions{MaxWidth: width})
+				if !reflect.DeepEqual(got, want) {
+					t.Errorf("WrapAt(%.0f)\ngot  %+v\nwant %+v", width, got, want)
+				}
+			})
+		}
+	}
+
+	t.Run("NormalizeInput", func(t *testing.T) {
+		normalizing := New(Config{MeasureFunc: TerminalMeasure, NormalizeInput: true})
+		text := "cafe\u0301 cafe\u0301 cafe\u0301 end"
+		got := normalizing.Analyze(text).WrapAt(6)
+		want := normalizing.Wrap(text, WrapOptions{MaxWidth: 6})
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("WrapAt(6)\ngot  %+v\nwant %+v", got, want)
+		}
+	})
+}
+
+func TestWrapAtWidths(t *testing.T) {
+	txt := NewTerminal()
+	text := "Hello 世界! This is a long text that needs wrapping."
+	widths := []float64{10, 0, 25, 10}
+
+	got := txt.WrapAtWidths(text, widths)
+	if len(got) != len(widths) {
+		t.Fatalf("WrapAtWidths returned %d line sets, want %d", len(got), len(widths))
+	}
+	for i, width := range widths {
+		want := txt.Wrap(text, WrapOptions{MaxWidth: width})
+		if !reflect.DeepEqual(got[i], want) {
+			t.Errorf("widths[%d] = %.0f:\ngot  %+v\nwant %+v", i, width, got[i], want)
+		}
+	}
+
+	if got := txt.WrapAtWidths(text, nil); len(got) != 0 {
+		t.Errorf("WrapAtWidths(nil widths) = %v, want empty", got)
+	}
+}
+
+func TestRuneIndexToByte(t *testing.T) {
+	tests := []struct {
+		text    string
+		runeIdx int
+		want    int
+	}{
+		{"café!", 0, 0},
+		{"café!", 3, 3},
+		{"café!", 4, 5},
+		{"café!", 5, 6},
+		{"café!", 9, 6},
+		{"café!", -1, 0},
+		{"世界", 1, 3},
+		{"", 2, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s/%d", tt.text, tt.runeIdx), func(t *testing.T) {
+			if got := RuneIndexToByte(tt.text, tt.runeIdx); got != tt.want {
+				t.Errorf("RuneIndexToByte(%q, %d) = %d, want %d", tt.text, tt.runeIdx, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestByteIndexToRune(t *testing.T) {
+	tests := []struct {
+		text    string
+		byteIdx int
+		want    int
+	}{
+		{"café!", 0, 0},
+		{"café!", 3, 3},
+		{"café!", 4, 3}, // Inside "é"
+		{"café!", 5, 4},
+		{"café!", 6, 5},
+		{"café!", 99, 5},
+		{"café!", -1, 0},
+		{"世界", 3, 1},
+		{"世界", 4, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s/%d", tt.text, tt.byteIdx), func(t *testing.T) {
+			if got := ByteIndexToRune(tt.text, tt.byteIdx); got != tt.want {
+				t.Errorf("ByteIndexToRune(%q, %d) = %d, want %d", tt.text, tt.byteIdx, got, tt.want)
+			}
+		})
+	}
+
+	// Round trip at every rune boundary.
+	s := "añb世c👋d"
+	for i := 0; i <= len([]rune(s)); i++ {
+		if got := ByteIndexToRune(s, RuneIndexToByte(s, i)); got != i {
+			t.Errorf("round trip of rune %d = %d", i, got)
+		}
+	}
+}
+
+// stepPhraseBreaker reports a phrase boundary every n runes.
+type stepPhraseBreaker struct{ n int }
+
+func (b stepPhraseBreaker) FindPhrases(text string) []int {
+	count := len([]rune(text))
+	var positions []int
+	for i := 0; i < count; i += b.n {
+		positions = append(positions, i)
+	}
+	return append(positions, count)
+}
+
+func TestLineOffsets_RuneIndices(t *testing.T) {
+	txt := NewTerminal()
+	input := "Zoë and Chloé met 世界の友達 in Zürich, naïvely café-hopping."
+
+	wrappers := map[string]func() []Line{
+		"Wrap":          func() []Line { return txt.Wrap(input, WrapOptions{MaxWidth: 12}) },
+		"BreakWords":    func() []Line { return txt.Wrap(input, WrapOptions{MaxWidth: 7, BreakWords: true}) },
+		"WrapDetailed":  func() []Line { return txt.WrapDetailed(input, WrapOptions{MaxWidth: 12}).Lines },
+		"WrapWithSpans": func() []Line { lines, _ := txt.WrapWithSpans(input, nil, WrapOptions{MaxWidth: 12}); return lines },
+		"WrapCSS": func() []Line {
+			return txt.WrapCSS(input, CSSWrapOptions{MaxWidth: units.Px(12), Style: DefaultCSSTextStyle()})
+		},
+		"WrapDictionary": func() []Line { return txt.WrapWithDictionary(input, WrapOptions{MaxWidth: 12}, NewEnglishDictionary()) },
+		"WrapWithControls": func() []Line {
+			return txt.WrapWithControls(input, 12, []WrapPoint{{Position: 3, After: WrapControlAvoid}})
+		},
+		"WrapWithPhrases": func() []Line { return txt.WrapWithPhrases(input, 12, stepPhraseBreaker{3}) },
+		"WrapWithPhrasesAndControls": func() []Line {
+			return txt.WrapWithPhrasesAndControls(input, 12, stepPhraseBreaker{3}, []WrapPoint{{Position: 5, Before: WrapControlAvoid}})
+		},
+	}
+
+	for name, wrap := range wrappers {
+		t.Run(name, func(t *testing.T) {
+			lines := wrap()
+			if len(lines) < 2 {
+				t.Fatalf("got %d lines, want the text wrapped", len(lines))
+			}
+			next := 0
+			for _, line := range lines {
+				got := input[RuneIndexToByte(input, line.Start):RuneIndexToByte(input, line.End)]
+				if got != line.Content {
+					t.Errorf("text[%d:%d] = %q, want Content %q", line.Start, line.End, got, line.Content)
+				}
+				if line.Start != next {
+					t.Errorf("line %q starts at %d, want %d", line.Content, line.Start, next)
+				}
+				next = line.End
+			}
+			if next != len([]rune(input)) {
+				t.Errorf("last line ends at %d, want %d", next, len([]rune(input)))
+			}
+		})
+	}
+}
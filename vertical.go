@@ -1,6 +1,11 @@
 package text
 
 import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/SCKelemen/unicode/v6/uax14"
 	"github.com/SCKelemen/unicode/v6/uax50"
 )
 
@@ -73,12 +78,20 @@ const (
 
 	// TextCombineUprightAll enables combining for all characters.
 	// Used for numbers, acronyms, etc. in vertical text (tate-chu-yoko in Japanese).
+	// Each run of horizontal-script characters (those UAX #50 rotates, such
+	// as Latin letters and digits, but not spaces) is combined.
 	TextCombineUprightAll
 
 	// TextCombineUprightDigits enables combining for digit sequences only.
+	// Like CSS "digits" (which defaults to 2), only runs of one or two ASCII
+	// digits are combined; longer numbers are laid out as usual.
 	TextCombineUprightDigits
 )
 
+// maxCombinedDigits is the longest ASCII digit run TextCombineUprightDigits
+// combines, matching the CSS default of "digits 2".
+const maxCombinedDigits = 2
+
 // ═══════════════════════════════════════════════════════════════
 //  Vertical Text Configuration
 // ═══════════════════════════════════════════════════════════════
@@ -144,6 +157,35 @@ func (t *Text) IsRotated(r rune, style VerticalTextStyle) bool {
 	return orientation == uax50.Rotated || orientation == uax50.TransformedRotated
 }
 
+// GlyphRotation returns the clockwise rotation in degrees applied to r in
+// the given vertical style: 0 for upright glyphs, 90 for rotated glyphs in
+// vertical-rl, vertical-lr, and sideways-rl, and -90 in sideways-lr.
+//
+// Mongolian letters are rotated (UAX #50 R), so in vertical-lr they are
+// drawn turned 90° clockwise, which is how the script is written: lines
+// read top to bottom and columns progress left to right.
+//
+// Example:
+//
+//	style := text.VerticalTextStyle{WritingMode: text.WritingModeVerticalLR}
+//	txt.GlyphRotation('ᠮ', style) // 90 (Mongolian MA)
+//	txt.GlyphRotation('世', style) // 0
+func (t *Text) GlyphRotation(r rune, style VerticalTextStyle) float64 {
+	switch style.WritingMode {
+	case WritingModeHorizontalTB:
+		return 0
+	case WritingModeSidewaysRL:
+		return 90
+	case WritingModeSidewaysLR:
+		return -90
+	}
+
+	if t.IsRotated(r, style) {
+		return 90
+	}
+	return 0
+}
+
 // ═══════════════════════════════════════════════════════════════
 //  Vertical Metrics
 // ═══════════════════════════════════════════════════════════════
@@ -169,6 +211,9 @@ type VerticalMetrics struct {
 //   - Advance is the vertical distance (top to bottom or bottom to top)
 //   - InlineSize is the width (perpendicular to flow)
 //   - BlockSize is the height (parallel to flow)
+//
+// With style.TextCombineUpright set, each combined run (such as the "12" in
+// "令和12年") advances a single cell, as tate-chu-yoko does.
 func (t *Text) MeasureVertical(text string, style VerticalTextStyle) VerticalMetrics {
 	var metrics VerticalMetrics
 
@@ -180,20 +225,15 @@ func (t *Text) MeasureVertical(text string, style VerticalTextStyle) VerticalMet
 		metrics.BlockSize = 1.0 // Assume 1 line height
 
 	case WritingModeVerticalRL, WritingModeVerticalLR:
-		// Vertical layout: each character takes vertical space
-		graphemes := t.Graphemes(text)
-		metrics.Advance = float64(len(graphemes))
-
-		// Calculate maximum inline size (widest character)
-		maxWidth := 0.0
-		for _, g := range graphemes {
-			w := t.Width(g)
-			if w > maxWidth {
-				maxWidth = w
+		// Vertical layout: upright graphemes stack one cell each, rotated
+		// ones (Latin, Mongolian) advance by their horizontal width
+		for _, u := range t.verticalUnits(text, style) {
+			metrics.Advance += u.advance
+			if u.inline > metrics.InlineSize {
+				metrics.InlineSize = u.inline
 			}
 		}
-		metrics.InlineSize = maxWidth
-		metrics.BlockSize = float64(len(graphemes))
+		metrics.BlockSize = metrics.Advance
 
 	case WritingModeSidewaysRL, WritingModeSidewaysLR:
 		// Sideways: rotated horizontal text
@@ -205,6 +245,138 @@ func (t *Text) MeasureVertical(text string, style VerticalTextStyle) VerticalMet
 	return metrics
 }
 
+// verticalExtent returns the advance along the column and the inline size
+// across it for one grapheme in vertical layout. Upright graphemes take one
+// cell of advance and their width across; rotated graphemes lie on their
+// side, so their width becomes the advance and they are one cell across.
+func (t *Text) verticalExtent(g string, style VerticalTextStyle) (advance, inline float64) {
+	r, _ := utf8.DecodeRuneInString(g)
+	if t.GlyphRotation(r, style) != 0 {
+		return t.Width(g), 1.0
+	}
+	return 1.0, t.Width(g)
+}
+
+// verticalUnit is a grapheme, or a run combined upright (tate-chu-yoko),
+// with its extent in vertical layout.
+type verticalUnit struct {
+	text     string
+	advance  float64
+	inline   float64
+	combined bool
+}
+
+// verticalUnits splits text into the units stacked in a vertical column.
+// With TextCombineUpright set, each combinable run becomes one unit that
+// advances a single cell and is as wide as the run set horizontally, up to
+// the width of an ideographic cell (U+3000).
+func (t *Text) verticalUnits(text string, style VerticalTextStyle) []verticalUnit {
+	graphemes := t.Graphemes(text)
+	units := make([]verticalUnit, 0, len(graphemes))
+
+	for i := 0; i < len(graphemes); {
+		end := i
+		for end < len(graphemes) && t.isCombinable(graphemes[end], style.TextCombineUpright) {
+			end++
+		}
+
+		combine := end > i
+		if style.TextCombineUpright == TextCombineUprightDigits && end-i > maxCombinedDigits {
+			combine = false
+		}
+		if combine {
+			run := strings.Join(graphemes[i:end], "")
+			units = append(units, verticalUnit{
+				text:     run,
+				advance:  1.0,
+				inline:   min(t.Width(run), t.Width("\u3000")),
+				combined: true,
+			})
+			i = end
+			continue
+		}
+
+		// Not combined: lay out the run (or the next grapheme) one by one
+		for end = max(end, i+1); i < end; i++ {
+			advance, inline := t.verticalExtent(graphemes[i], style)
+			units = append(units, verticalUnit{text: graphemes[i], advance: advance, inline: inline})
+		}
+	}
+
+	return units
+}
+
+// isCombinable reports whether grapheme g joins a text-combine-upright run.
+func (t *Text) isCombinable(g string, mode TextCombineUpright) bool {
+	r, _ := utf8.DecodeRuneInString(g)
+	switch mode {
+	case TextCombineUprightDigits:
+		return r >= '0' && r <= '9'
+	case TextCombineUprightAll:
+		return !unicode.IsSpace(r) && uax50.LookupOrientation(r) == uax50.Rotated
+	}
+	return false
+}
+
+// ═══════════════════════════════════════════════════════════════
+//  Orientation Runs
+// ═══════════════════════════════════════════════════════════════
+
+// OrientedRun is a stretch of text drawn with a single glyph orientation in
+// vertical layout.
+type OrientedRun struct {
+	Text     string
+	Upright  bool // Glyphs stand upright; otherwise they are rotated (see GlyphRotation)
+	Combined bool // Text is one text-combine-upright run, set horizontally in a single cell
+	Start    int  // Rune index of the run's first character
+	End      int  // Rune index just past the run
+}
+
+// OrientRun splits text into runs of uniform orientation for vertical
+// layout, so a renderer knows where to switch between upright and rotated
+// glyphs without querying each rune. It is the vertical counterpart of
+// ReorderRuns.
+//
+// Orientation follows GlyphRotation, per grapheme cluster. Runs combined by
+// style.TextCombineUpright are upright and always form a run of their own.
+//
+// Example:
+//
+//	txt := text.NewTerminal()
+//	style := text.VerticalTextStyle{WritingMode: text.WritingModeVerticalRL}
+//	runs := txt.OrientRun("東京Tower", style)
+//	// runs[0]: {Text: "東京", Upright: true, Start: 0, End: 2}
+//	// runs[1]: {Text: "Tower", Upright: false, Start: 2, End: 7}
+func (t *Text) OrientRun(text string, style VerticalTextStyle) []OrientedRun {
+	var runs []OrientedRun
+	runeIdx := 0
+
+	for _, u := range t.verticalUnits(text, style) {
+		upright := u.combined
+		if !upright {
+			r, _ := utf8.DecodeRuneInString(u.text)
+			upright = t.GlyphRotation(r, style) == 0
+		}
+		end := runeIdx + utf8.RuneCountInString(u.text)
+
+		if n := len(runs); n > 0 && !u.combined && !runs[n-1].Combined && runs[n-1].Upright == upright {
+			runs[n-1].Text += u.text
+			runs[n-1].End = end
+		} else {
+			runs = append(runs, OrientedRun{
+				Text:     u.text,
+				Upright:  upright,
+				Combined: u.combined,
+				Start:    runeIdx,
+				End:      end,
+			})
+		}
+		runeIdx = end
+	}
+
+	return runs
+}
+
 // ═══════════════════════════════════════════════════════════════
 //  Vertical Line Breaking
 // ═══════════════════════════════════════════════════════════════
@@ -220,6 +392,10 @@ type VerticalWrapOptions struct {
 
 	// BaseOptions provides standard wrapping options.
 	BaseOptions WrapOptions
+
+	// WordBreak adjusts the UAX #14 break opportunities between columns as
+	// CSS word-break does; WordBreakKeepAll keeps runs of CJK together.
+	WordBreak WordBreak
 }
 
 // VerticalLine represents a line in vertical layout (a column).
@@ -229,12 +405,25 @@ type VerticalLine struct {
 	InlineSize float64 // Horizontal size (column width)
 	Start      int
 	End        int
+
+	// Offset is the horizontal position of the column's left edge,
+	// measured from the left of the laid-out block. Columns progress right
+	// to left in vertical-rl and sideways-rl, so the first column has the
+	// largest offset there; in vertical-lr (Mongolian) and sideways-lr the
+	// first column starts at 0.
+	Offset float64
 }
 
 // WrapVertical wraps text for vertical layout.
 //
 // In vertical layout, "lines" are vertical columns that flow from top to bottom.
 // When a column reaches MaxBlockSize, text wraps to the next column.
+// Columns break at UAX #14 line break opportunities (adjusted by
+// opts.WordBreak), so a sideways Latin word stays in one column. A word
+// taller than MaxBlockSize is broken between grapheme clusters instead,
+// continuing from wherever the current column ends.
+// Columns are positioned according to the writing mode's block flow
+// direction (see VerticalLine.Offset).
 func (t *Text) WrapVertical(text string, opts VerticalWrapOptions) []VerticalLine {
 	if opts.MaxBlockSize <= 0 {
 		// No wrapping
@@ -248,57 +437,108 @@ func (t *Text) WrapVertical(text string, opts VerticalWrapOptions) []VerticalLin
 		}}
 	}
 
-	// For vertical text, wrap by grapheme clusters
-	graphemes := t.Graphemes(text)
+	breakPoints := uax14.FindLineBreakOpportunities(text, t.config.HyphenationMode)
+	breakPoints = t.applyWordBreak(text, breakPoints, opts.WordBreak)
+	canBreak := make(map[int]bool, len(breakPoints))
+	for _, bp := range breakPoints {
+		canBreak[bp] = true
+	}
+
 	var lines []VerticalLine
+	var column strings.Builder
+	height, width := 0.0, 0.0
+	columnStart, runeIdx := 0, 0
 
-	currentColumn := ""
-	currentHeight := 0.0
-	maxWidth := 0.0
-	columnStart := 0
-
-	for i, g := range graphemes {
-		gHeight := 1.0 // Each grapheme takes 1 unit of vertical space
-		gWidth := t.Width(g)
-
-		// Check if adding this grapheme exceeds the column height
-		if currentHeight+gHeight > opts.MaxBlockSize && currentHeight > 0 {
-			// Start new column
-			lines = append(lines, VerticalLine{
-				Content:    currentColumn,
-				Advance:    currentHeight,
-				InlineSize: maxWidth,
-				Start:      columnStart,
-				End:        columnStart + len([]rune(currentColumn)),
-			})
+	flush := func() {
+		content := column.String()
+		lines = append(lines, VerticalLine{
+			Content:    content,
+			Advance:    height,
+			InlineSize: width,
+			Start:      columnStart,
+			End:        columnStart + utf8.RuneCountInString(content),
+		})
+		column.Reset()
+		height, width = 0, 0
+		columnStart = runeIdx
+	}
+	add := func(u verticalUnit) {
+		column.WriteString(u.text)
+		height += u.advance
+		width = max(width, u.inline)
+		runeIdx += utf8.RuneCountInString(u.text)
+	}
 
-			currentColumn = g
-			currentHeight = gHeight
-			maxWidth = gWidth
-			columnStart = i
-		} else {
-			currentColumn += g
-			currentHeight += gHeight
-			if gWidth > maxWidth {
-				maxWidth = gWidth
+	// Group units (graphemes and combined upright runs) into words that end
+	// at break opportunities, then fill columns word by word.
+	units := t.verticalUnits(text, opts.Style)
+	offset := 0
+	for i := 0; i < len(units); {
+		end, advance := i, 0.0
+		for end < len(units) {
+			advance += units[end].advance
+			offset += len(units[end].text)
+			end++
+			if canBreak[offset] {
+				break
+			}
+		}
+		word := units[i:end]
+		i = end
+
+		if height+advance <= opts.MaxBlockSize {
+			for _, u := range word {
+				add(u)
+			}
+			continue
+		}
+
+		if advance <= opts.MaxBlockSize {
+			flush()
+			for _, u := range word {
+				add(u)
+			}
+			continue
+		}
+
+		// Emergency break: the word is taller than a column
+		for _, u := range word {
+			if height+u.advance > opts.MaxBlockSize && height > 0 {
+				flush()
 			}
+			add(u)
 		}
 	}
 
 	// Add final column
-	if currentColumn != "" {
-		lines = append(lines, VerticalLine{
-			Content:    currentColumn,
-			Advance:    currentHeight,
-			InlineSize: maxWidth,
-			Start:      columnStart,
-			End:        columnStart + len([]rune(currentColumn)),
-		})
+	if column.Len() > 0 {
+		flush()
 	}
 
+	positionColumns(lines, opts.Style.WritingMode)
 	return lines
 }
 
+// positionColumns sets each column's Offset following the block flow
+// direction of mode.
+func positionColumns(lines []VerticalLine, mode WritingMode) {
+	total := 0.0
+	for _, line := range lines {
+		total += line.InlineSize
+	}
+
+	leftToRight := mode == WritingModeVerticalLR || mode == WritingModeSidewaysLR
+	x := 0.0
+	for i := range lines {
+		if leftToRight {
+			lines[i].Offset = x
+		} else {
+			lines[i].Offset = total - x - lines[i].InlineSize
+		}
+		x += lines[i].InlineSize
+	}
+}
+
 // ═══════════════════════════════════════════════════════════════
 //  Utility Functions
 // ═══════════════════════════════════════════════════════════════
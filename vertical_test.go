@@ -1,6 +1,7 @@
 package text
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/SCKelemen/unicode/v6/uax50"
@@ -75,3 +76,278 @@ func TestWritingModeHelpers(t *testing.T) {
 		t.Fatal("IsHorizontalWritingMode(VerticalLR) = true, want false")
 	}
 }
+
+func TestGlyphRotation(t *testing.T) {
+	txt := NewTerminal()
+
+	tests := []struct {
+		name string
+		r    rune
+		mode WritingMode
+		want float64
+	}{
+		{"Mongolian in vertical-lr", 'ᠮ', WritingModeVerticalLR, 90},
+		{"Mongolian in vertical-rl", 'ᠮ', WritingModeVerticalRL, 90},
+		{"CJK upright", '世', WritingModeVerticalLR, 0},
+		{"Latin rotated", 'A', WritingModeVerticalRL, 90},
+		{"Sideways-lr turns counter-clockwise", 'ᠮ', WritingModeSidewaysLR, -90},
+		{"Sideways-rl rotates CJK too", '世', WritingModeSidewaysRL, 90},
+		{"Horizontal", 'ᠮ', WritingModeHorizontalTB, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			style := VerticalTextStyle{WritingMode: tt.mode}
+			if got := txt.GlyphRotation(tt.r, style); got != tt.want {
+				t.Errorf("GlyphRotation(%q) = %v, want %v", tt.r, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWrapVertical_Mongolian(t *testing.T) {
+	txt := NewTerminal()
+	text := "ᠮᠣᠩᠭᠣᠯ ᠪᠢᠴᠢᠭ" // "Mongol bichig"
+
+	wrap := func(mode WritingMode) []VerticalLine {
+		return txt.WrapVertical(text, VerticalWrapOptions{
+			MaxBlockSize: 4,
+			Style:        VerticalTextStyle{WritingMode: mode},
+		})
+	}
+
+	lr := wrap(WritingModeVerticalLR)
+	rl := wrap(WritingModeVerticalRL)
+
+	if len(lr) != 3 || len(rl) != 3 {
+		t.Fatalf("got %d (lr) and %d (rl) columns, want 3", len(lr), len(rl))
+	}
+
+	runes := []rune(text)
+	for i := range lr {
+		if lr[i].Content != rl[i].Content {
+			t.Errorf("column %d content differs: %q vs %q", i, lr[i].Content, rl[i].Content)
+		}
+		if string(runes[lr[i].Start:lr[i].End]) != lr[i].Content {
+			t.Errorf("column %d range [%d:%d] does not match %q", i, lr[i].Start, lr[i].End, lr[i].Content)
+		}
+		if lr[i].Advance > 4 || lr[i].InlineSize != 1 {
+			t.Errorf("column %d = %+v, want advance <= 4 and inline size 1", i, lr[i])
+		}
+	}
+
+	// vertical-lr progresses left to right, vertical-rl right to left.
+	for i, want := range []float64{0, 1, 2} {
+		if lr[i].Offset != want {
+			t.Errorf("vertical-lr column %d offset = %v, want %v", i, lr[i].Offset, want)
+		}
+		if rl[i].Offset != 2-want {
+			t.Errorf("vertical-rl column %d offset = %v, want %v", i, rl[i].Offset, 2-want)
+		}
+	}
+}
+
+func TestMeasureVertical_Orientation(t *testing.T) {
+	txt := NewTerminal()
+	style := VerticalTextStyle{WritingMode: WritingModeVerticalLR}
+
+	// Upright CJK stacks one cell per character and is two cells across.
+	if m := txt.MeasureVertical("世界", style); m.Advance != 2 || m.InlineSize != 2 {
+		t.Errorf("CJK metrics = %+v, want advance 2, inline size 2", m)
+	}
+
+	// Rotated CJK lies on its side: width becomes advance.
+	style.TextOrientation = TextOrientationSideways
+	if m := txt.MeasureVertical("世界", style); m.Advance != 4 || m.InlineSize != 1 {
+		t.Errorf("sideways CJK metrics = %+v, want advance 4, inline size 1", m)
+	}
+}
+
+func TestMeasureVertical_TextCombineUpright(t *testing.T) {
+	txt := NewTerminal()
+
+	tests := []struct {
+		name        string
+		text        string
+		combine     TextCombineUpright
+		wantAdvance float64
+		wantInline  float64
+	}{
+		{"Single digit combined", "令和2年", TextCombineUprightDigits, 4, 2},
+		{"Two digits combined", "令和12年", TextCombineUprightDigits, 4, 2},
+		{"Two digits not combined", "令和12年", TextCombineUprightNone, 5, 2},
+		{"Long number not combined", "西暦2024年", TextCombineUprightDigits, 7, 2},
+		{"Digits mode skips letters", "第AB章", TextCombineUprightDigits, 4, 2},
+		{"All combines letters", "第AB章", TextCombineUprightAll, 3, 2},
+		{"All caps at column width", "第ABCD章", TextCombineUprightAll, 3, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			style := VerticalTextStyle{
+				WritingMode:        WritingModeVerticalRL,
+				TextCombineUpright: tt.combine,
+			}
+			m := txt.MeasureVertical(tt.text, style)
+			if m.Advance != tt.wantAdvance || m.InlineSize != tt.wantInline {
+				t.Errorf("MeasureVertical(%q) = advance %v, inline %v; want %v, %v",
+					tt.text, m.Advance, m.InlineSize, tt.wantAdvance, tt.wantInline)
+			}
+		})
+	}
+}
+
+func TestWrapVertical_TextCombineUpright(t *testing.T) {
+	txt := NewTerminal()
+
+	opts := VerticalWrapOptions{
+		MaxBlockSize: 3,
+		Style: VerticalTextStyle{
+			WritingMode:        WritingModeVerticalRL,
+			TextCombineUpright: TextCombineUprightDigits,
+		},
+	}
+	lines := txt.WrapVertical("令和12年です", opts)
+
+	want := []struct {
+		content string
+		advance float64
+		start   int
+		end     int
+	}{
+		{"令和12", 3, 0, 4},
+		{"年です", 3, 4, 7},
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("got %d columns, want %d: %+v", len(lines), len(want), lines)
+	}
+	for i, w := range want {
+		l := lines[i]
+		if l.Content != w.content || l.Advance != w.advance || l.Start != w.start || l.End != w.end {
+			t.Errorf("column %d = %q advance %v [%d,%d), want %q advance %v [%d,%d)",
+				i, l.Content, l.Advance, l.Start, l.End, w.content, w.advance, w.start, w.end)
+		}
+	}
+}
+
+func TestWrapVertical_BreakOpportunities(t *testing.T) {
+	txt := NewTerminal()
+
+	tests := []struct {
+		name      string
+		text      string
+		max       float64
+		wordBreak WordBreak
+		want      []string
+	}{
+		{"Latin word after kana moves whole", "こんにちはHello世界", 7, WordBreakNormal, []string{"こんにちは", "Hello世界"}},
+		{"Latin word before CJK", "Hello世界こんにちは", 6, WordBreakNormal, []string{"Hello世", "界こんにちは"}},
+		{"CJK breaks anywhere", "世界 こんにちは", 6, WordBreakNormal, []string{"世界 こんに", "ちは"}},
+		{"Keep-all keeps CJK runs", "世界 こんにちは", 6, WordBreakKeepAll, []string{"世界 ", "こんにちは"}},
+		{"Overlong word breaks by grapheme", "ab Wonderful", 4, WordBreakNormal, []string{"ab W", "onde", "rful"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines := txt.WrapVertical(tt.text, VerticalWrapOptions{
+				MaxBlockSize: tt.max,
+				Style:        VerticalTextStyle{WritingMode: WritingModeVerticalRL},
+				WordBreak:    tt.wordBreak,
+			})
+
+			var got []string
+			runes := []rune(tt.text)
+			for _, l := range lines {
+				got = append(got, l.Content)
+				if string(runes[l.Start:l.End]) != l.Content {
+					t.Errorf("column range [%d:%d] does not match %q", l.Start, l.End, l.Content)
+				}
+				if l.Advance > tt.max {
+					t.Errorf("column %q advance %v exceeds %v", l.Content, l.Advance, tt.max)
+				}
+			}
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("columns = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOrientRun(t *testing.T) {
+	txt := NewTerminal()
+
+	tests := []struct {
+		name  string
+		text  string
+		style VerticalTextStyle
+		want  []OrientedRun
+	}{
+		{
+			name:  "CJK and Latin",
+			text:  "東京Tower",
+			style: VerticalTextStyle{WritingMode: WritingModeVerticalRL},
+			want: []OrientedRun{
+				{Text: "東京", Upright: true, Start: 0, End: 2},
+				{Text: "Tower", Start: 2, End: 7},
+			},
+		},
+		{
+			name:  "Digits rotate without combining",
+			text:  "令和12年",
+			style: VerticalTextStyle{WritingMode: WritingModeVerticalRL},
+			want: []OrientedRun{
+				{Text: "令和", Upright: true, Start: 0, End: 2},
+				{Text: "12", Start: 2, End: 4},
+				{Text: "年", Upright: true, Start: 4, End: 5},
+			},
+		},
+		{
+			name: "Combined digits are an upright run of their own",
+			text: "令和12年",
+			style: VerticalTextStyle{
+				WritingMode:        WritingModeVerticalRL,
+				TextCombineUpright: TextCombineUprightDigits,
+			},
+			want: []OrientedRun{
+				{Text: "令和", Upright: true, Start: 0, End: 2},
+				{Text: "12", Upright: true, Combined: true, Start: 2, End: 4},
+				{Text: "年", Upright: true, Start: 4, End: 5},
+			},
+		},
+		{
+			name: "Upright orientation",
+			text: "東京Tower",
+			style: VerticalTextStyle{
+				WritingMode:     WritingModeVerticalRL,
+				TextOrientation: TextOrientationUpright,
+			},
+			want: []OrientedRun{{Text: "東京Tower", Upright: true, Start: 0, End: 7}},
+		},
+		{
+			name:  "Sideways mode rotates everything",
+			text:  "東京Tower",
+			style: VerticalTextStyle{WritingMode: WritingModeSidewaysRL},
+			want:  []OrientedRun{{Text: "東京Tower", Start: 0, End: 7}},
+		},
+		{
+			name:  "Empty",
+			text:  "",
+			style: VerticalTextStyle{WritingMode: WritingModeVerticalRL},
+			want:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := txt.OrientRun(tt.text, tt.style)
+			if len(got) != len(tt.want) {
+				t.Fatalf("OrientRun(%q) = %+v, want %+v", tt.text, got, tt.want)
+			}
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Errorf("run %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}